@@ -0,0 +1,149 @@
+package account
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bytom/bytom/blockchain/txbuilder"
+	"github.com/bytom/bytom/common"
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/sha3pool"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// pre-define errors for bumping transaction fee
+var (
+	ErrBumpFeeInput  = errors.New("transaction input is not spent from the wallet accounts")
+	ErrBumpFeeChange = errors.New("transaction has no BTM change output to pay the bumped fee")
+	ErrBumpFeeLow    = errors.New("bumped fee must be higher than the origin fee")
+)
+
+// BuildReplaceTx rebuild the unconfirmed transaction with the same account spend inputs, the fee
+// is raised to the given amount by reducing its BTM change output. The result template double
+// spends the origin transaction and replaces it in the mempool once submitted.
+func (m *Manager) BuildReplaceTx(tx *types.Tx, fee uint64, maxTime time.Time) (*txbuilder.Template, error) {
+	originFee := tx.Fee()
+	if fee <= originFee {
+		return nil, ErrBumpFeeLow
+	}
+
+	changeIndex, _, err := m.findChangeOutput(tx, fee-originFee)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := txbuilder.NewBuilder(maxTime)
+	for _, input := range tx.Inputs {
+		if input.InputType() != types.SpendInputType && input.InputType() != types.VetoInputType {
+			return nil, ErrBumpFeeInput
+		}
+
+		outputID, err := input.SpentOutputID()
+		if err != nil {
+			return nil, err
+		}
+
+		txInput, sigInst, err := m.accountUtxoToInput(outputID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := builder.AddInput(txInput, sigInst); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, output := range tx.Outputs {
+		newOutput := *output
+		if i == changeIndex {
+			newOutput.Amount -= fee - originFee
+		}
+
+		if err := builder.AddOutput(&newOutput); err != nil {
+			return nil, err
+		}
+	}
+
+	tpl, _, err := builder.Build()
+	return tpl, err
+}
+
+// BuildChildPaysTx build a transaction spending the BTM change output of the unconfirmed transaction
+// back to the same control program and paying the given fee, so the package of the parent and the
+// child is packed by the block proposer with the combined fee rate.
+func (m *Manager) BuildChildPaysTx(tx *types.Tx, fee uint64, maxTime time.Time) (*txbuilder.Template, error) {
+	changeIndex, cp, err := m.findChangeOutput(tx, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	txInput, sigInst, err := m.accountUtxoToInput(*tx.ResultIds[changeIndex])
+	if err != nil {
+		return nil, err
+	}
+
+	builder := txbuilder.NewBuilder(maxTime)
+	if err := builder.AddInput(txInput, sigInst); err != nil {
+		return nil, err
+	}
+
+	output := types.NewOriginalTxOutput(*consensus.BTMAssetID, txInput.Amount()-fee, cp.ControlProgram, nil)
+	if err := builder.AddOutput(output); err != nil {
+		return nil, err
+	}
+
+	tpl, _, err := builder.Build()
+	return tpl, err
+}
+
+// accountUtxoToInput convert the confirmed or unconfirmed account utxo to the tx input, the utxo
+// is not reserved since it's already spent by the transaction to be bumped
+func (m *Manager) accountUtxoToInput(outputID bc.Hash) (*types.TxInput, *txbuilder.SigningInstruction, error) {
+	m.utxoKeeper.mtx.RLock()
+	u, err := m.utxoKeeper.findUtxo(outputID, true)
+	m.utxoKeeper.mtx.RUnlock()
+	if err == ErrMatchUTXO {
+		return nil, nil, errors.Wrap(ErrBumpFeeInput, outputID.String())
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if u.AccountID == "" {
+		return nil, nil, errors.Wrap(ErrBumpFeeInput, outputID.String())
+	}
+
+	account, err := m.FindByID(u.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return UtxoToInputs(account.Signer, u)
+}
+
+// findChangeOutput return the first BTM change output of the wallet accounts which is larger than the fee
+func (m *Manager) findChangeOutput(tx *types.Tx, fee uint64) (int, *CtrlProgram, error) {
+	for i, output := range tx.Outputs {
+		if output.OutputType() != types.OriginalOutputType || *output.AssetId != *consensus.BTMAssetID || output.Amount <= fee {
+			continue
+		}
+
+		var hash common.Hash
+		sha3pool.Sum256(hash[:], output.ControlProgram)
+		rawProgram := m.db.Get(ContractKey(hash))
+		if rawProgram == nil {
+			continue
+		}
+
+		cp := &CtrlProgram{}
+		if err := json.Unmarshal(rawProgram, cp); err != nil {
+			return 0, nil, err
+		}
+
+		if cp.Change {
+			return i, cp, nil
+		}
+	}
+	return 0, nil, ErrBumpFeeChange
+}
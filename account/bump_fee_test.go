@@ -0,0 +1,169 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytom/bytom/blockchain/signers"
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/testutil"
+)
+
+// mockBumpFeeTx create the tx spending 1000 from the account, it pays 200 to the receive address of
+// the account, 100 to the external program and the changeAmount back to the change address
+func mockBumpFeeTx(t *testing.T, m *Manager, changeAmount uint64) *types.Tx {
+	acct, err := m.Create([]chainkd.XPub{testutil.TestXPub}, 1, "bumpfee", signers.BIP0044)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receiveCP, err := m.CreateAddress(acct.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changeCP, err := m.CreateAddress(acct.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	utxo := &UTXO{
+		SourceID:       bc.NewHash([32]byte{0x01}),
+		AssetID:        *consensus.BTMAssetID,
+		Amount:         1000,
+		ControlProgram: receiveCP.ControlProgram,
+		AccountID:      acct.ID,
+		Address:        receiveCP.Address,
+	}
+	tx := types.NewTx(types.TxData{
+		Inputs: []*types.TxInput{
+			types.NewSpendInput(nil, utxo.SourceID, utxo.AssetID, utxo.Amount, utxo.SourcePos, utxo.ControlProgram, nil),
+		},
+		Outputs: []*types.TxOutput{
+			types.NewOriginalTxOutput(*consensus.BTMAssetID, 200, receiveCP.ControlProgram, nil),
+			types.NewOriginalTxOutput(*consensus.BTMAssetID, 100, []byte{0x51}, nil),
+			types.NewOriginalTxOutput(*consensus.BTMAssetID, changeAmount, changeCP.ControlProgram, nil),
+		},
+	})
+
+	utxo.OutputID = tx.SpentOutputIDs[0]
+	changeOutput := tx.Entries[*tx.ResultIds[2]].(*bc.OriginalOutput)
+	change := &UTXO{
+		OutputID:       *tx.ResultIds[2],
+		SourceID:       *changeOutput.Source.Ref,
+		AssetID:        *consensus.BTMAssetID,
+		Amount:         changeAmount,
+		SourcePos:      changeOutput.Source.Position,
+		ControlProgram: changeCP.ControlProgram,
+		AccountID:      acct.ID,
+		Address:        changeCP.Address,
+		Change:         true,
+	}
+	m.utxoKeeper.AddUnconfirmedUtxo([]*UTXO{utxo, change})
+	return tx
+}
+
+func TestBuildReplaceTx(t *testing.T) {
+	cases := []struct {
+		desc        string
+		fee         uint64
+		wantAmounts []uint64
+		wantErr     error
+	}{
+		{
+			desc:        "bump the fee by the change output",
+			fee:         250,
+			wantAmounts: []uint64{200, 100, 450},
+		},
+		{
+			desc:    "fee is not higher than the origin",
+			fee:     100,
+			wantErr: ErrBumpFeeLow,
+		},
+		{
+			desc:    "change is not enough for the bumped fee",
+			fee:     800,
+			wantErr: ErrBumpFeeChange,
+		},
+	}
+
+	for i, c := range cases {
+		m := mockAccountManager(t)
+		tx := mockBumpFeeTx(t, m, 600)
+		tpl, err := m.BuildReplaceTx(tx, c.fee, time.Now().Add(time.Minute))
+		if errors.Root(err) != c.wantErr {
+			t.Fatalf("case %d(%s): got error %v want %v", i, c.desc, err, c.wantErr)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		gotAmounts := []uint64{}
+		for _, output := range tpl.Transaction.Outputs {
+			gotAmounts = append(gotAmounts, output.Amount)
+		}
+
+		if !testutil.DeepEqual(gotAmounts, c.wantAmounts) {
+			t.Errorf("case %d(%s): got output amounts %v want %v", i, c.desc, gotAmounts, c.wantAmounts)
+		}
+
+		if fee := tpl.Transaction.Fee(); fee != c.fee {
+			t.Errorf("case %d(%s): got fee %d want %d", i, c.desc, fee, c.fee)
+		}
+
+		if len(tpl.Transaction.Inputs) != 1 || tpl.Transaction.SpentOutputIDs[0] != tx.SpentOutputIDs[0] {
+			t.Errorf("case %d(%s): replacement doesn't double spend the origin tx", i, c.desc)
+		}
+	}
+}
+
+func TestBuildChildPaysTx(t *testing.T) {
+	cases := []struct {
+		desc       string
+		fee        uint64
+		wantAmount uint64
+		wantErr    error
+	}{
+		{
+			desc:       "spend the change output",
+			fee:        300,
+			wantAmount: 300,
+		},
+		{
+			desc:    "change is not enough for the fee",
+			fee:     600,
+			wantErr: ErrBumpFeeChange,
+		},
+	}
+
+	for i, c := range cases {
+		m := mockAccountManager(t)
+		tx := mockBumpFeeTx(t, m, 600)
+		tpl, err := m.BuildChildPaysTx(tx, c.fee, time.Now().Add(time.Minute))
+		if errors.Root(err) != c.wantErr {
+			t.Fatalf("case %d(%s): got error %v want %v", i, c.desc, err, c.wantErr)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		child := tpl.Transaction
+		if len(child.Inputs) != 1 || child.SpentOutputIDs[0] != *tx.ResultIds[2] {
+			t.Fatalf("case %d(%s): child doesn't spend the change output of the parent", i, c.desc)
+		}
+
+		if len(child.Outputs) != 1 || child.Outputs[0].Amount != c.wantAmount {
+			t.Errorf("case %d(%s): got child outputs %v want amount %d", i, c.desc, child.Outputs, c.wantAmount)
+		}
+
+		if fee := child.Fee(); fee != c.fee {
+			t.Errorf("case %d(%s): got fee %d want %d", i, c.desc, fee, c.fee)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+
+	"github.com/bytom/bytom/common"
+	"github.com/bytom/bytom/consensus"
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/protocol/vm/vmutil"
+)
+
+// ErrBadAddress is returned when neither a valid address nor a control program is given
+var ErrBadAddress = errors.New("invalid address or control program")
+
+type addressQuery struct {
+	Address        string             `json:"address"`
+	ControlProgram chainjson.HexBytes `json:"control_program"`
+}
+
+// program return the control program to query, the control program is used
+// directly when it's given, otherwise it's built from the address
+func (q *addressQuery) program() ([]byte, error) {
+	if len(q.ControlProgram) != 0 {
+		return q.ControlProgram, nil
+	}
+
+	address, err := common.DecodeAddress(q.Address, &consensus.ActiveNetParams)
+	if err != nil {
+		return nil, errors.WithDetail(ErrBadAddress, err.Error())
+	}
+
+	switch address.(type) {
+	case *common.AddressWitnessPubKeyHash:
+		return vmutil.P2WPKHProgram(address.ScriptAddress())
+	case *common.AddressWitnessScriptHash:
+		return vmutil.P2WSHProgram(address.ScriptAddress())
+	}
+	return nil, errors.WithDetailf(ErrBadAddress, "unsupported address %q", q.Address)
+}
+
+// the address responses report the height of the first indexed block, since the address
+// index misses the transactions and utxos of the blocks saved before it's enabled
+type addressHistoryResp struct {
+	Transactions      []*state.AddressTx `json:"transactions"`
+	After             string             `json:"after"`
+	IndexedFromHeight uint64             `json:"indexed_from_height"`
+}
+
+type addressUtxosResp struct {
+	Utxos             []*state.AddressUtxo `json:"utxos"`
+	IndexedFromHeight uint64               `json:"indexed_from_height"`
+}
+
+type addressBalanceResp struct {
+	Balances          []*addressBalance `json:"balances"`
+	IndexedFromHeight uint64            `json:"indexed_from_height"`
+}
+
+// POST /get-address-history
+func (a *API) getAddressHistory(ctx context.Context, ins struct {
+	addressQuery
+	After string `json:"after"`
+	Count uint   `json:"count"`
+}) Response {
+	program, err := ins.program()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	if ins.Count == 0 {
+		ins.Count = defaultQueryTxsCount
+	}
+
+	addressTxs, after, err := a.chain.GetAddressHistory(program, ins.After, int(ins.Count))
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	indexStart, err := a.chain.AddressIndexStart()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(&addressHistoryResp{Transactions: addressTxs, After: after, IndexedFromHeight: indexStart})
+}
+
+// POST /get-address-utxos
+func (a *API) getAddressUtxos(ctx context.Context, ins struct {
+	addressQuery
+	AssetID *bc.AssetID `json:"asset_id"`
+}) Response {
+	program, err := ins.program()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	utxos, err := a.chain.GetAddressUtxos(program, ins.AssetID)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	indexStart, err := a.chain.AddressIndexStart()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(&addressUtxosResp{Utxos: utxos, IndexedFromHeight: indexStart})
+}
+
+type addressBalance struct {
+	AssetID   bc.AssetID `json:"asset_id"`
+	Amount    uint64     `json:"amount"`
+	UtxoCount int        `json:"utxo_count"`
+}
+
+// POST /get-address-balance
+func (a *API) getAddressBalance(ctx context.Context, ins addressQuery) Response {
+	program, err := ins.program()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	utxos, err := a.chain.GetAddressUtxos(program, nil)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	indexStart, err := a.chain.AddressIndexStart()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	// the utxos are sorted by asset id in the index
+	balances := []*addressBalance{}
+	for _, utxo := range utxos {
+		if len(balances) == 0 || balances[len(balances)-1].AssetID != utxo.AssetID {
+			balances = append(balances, &addressBalance{AssetID: utxo.AssetID})
+		}
+
+		balance := balances[len(balances)-1]
+		balance.Amount += utxo.Amount
+		balance.UtxoCount++
+	}
+	return NewSuccessResponse(&addressBalanceResp{Balances: balances, IndexedFromHeight: indexStart})
+}
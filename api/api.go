@@ -13,6 +13,7 @@ import (
 	cmn "github.com/tendermint/tmlibs/common"
 
 	"github.com/bytom/bytom/accesstoken"
+	"github.com/bytom/bytom/blockchain/txfeed"
 	cfg "github.com/bytom/bytom/config"
 	"github.com/bytom/bytom/dashboard/dashboard"
 	"github.com/bytom/bytom/dashboard/equity"
@@ -109,6 +110,7 @@ type API struct {
 	sync            NetSync
 	wallet          *wallet.Wallet
 	accessTokens    *accesstoken.CredentialStore
+	txFeedTracker   *txfeed.Tracker
 	chain           *protocol.Chain
 	contractTracer  *contract.TraceService
 	server          *http.Server
@@ -180,13 +182,14 @@ type NetSync interface {
 }
 
 // NewAPI create and initialize the API
-func NewAPI(sync NetSync, wallet *wallet.Wallet, blockProposer *blockproposer.BlockProposer, chain *protocol.Chain, traceService *contract.TraceService, config *cfg.Config, token *accesstoken.CredentialStore, dispatcher *event.Dispatcher, notificationMgr *websocket.WSNotificationManager) *API {
+func NewAPI(sync NetSync, wallet *wallet.Wallet, blockProposer *blockproposer.BlockProposer, chain *protocol.Chain, traceService *contract.TraceService, config *cfg.Config, token *accesstoken.CredentialStore, txFeedTracker *txfeed.Tracker, dispatcher *event.Dispatcher, notificationMgr *websocket.WSNotificationManager) *API {
 	api := &API{
 		sync:            sync,
 		wallet:          wallet,
 		chain:           chain,
 		contractTracer:  traceService,
 		accessTokens:    token,
+		txFeedTracker:   txFeedTracker,
 		blockProposer:   blockProposer,
 		eventDispatcher: dispatcher,
 		notificationMgr: notificationMgr,
@@ -237,6 +240,14 @@ func (a *API) buildHandler() {
 		m.Handle("/build-chain-transactions", jsonHandler(a.buildChainTxs))
 		m.Handle("/sign-transaction", jsonHandler(a.signTemplate))
 		m.Handle("/sign-transactions", jsonHandler(a.signTemplates))
+		m.Handle("/bump-transaction-fee", jsonHandler(a.bumpTxFee))
+
+		m.Handle("/create-transaction-feed", jsonHandler(a.createTxFeed))
+		m.Handle("/get-transaction-feed", jsonHandler(a.getTxFeed))
+		m.Handle("/list-transaction-feeds", jsonHandler(a.listTxFeeds))
+		m.Handle("/update-transaction-feed", jsonHandler(a.updateTxFeed))
+		m.Handle("/delete-transaction-feed", jsonHandler(a.deleteTxFeed))
+		m.Handle("/list-transaction-feed-transactions", jsonHandler(a.listTxFeedTxs))
 
 		m.Handle("/get-transaction", jsonHandler(a.getTransaction))
 		m.Handle("/list-transactions", jsonHandler(a.listTransactions))
@@ -276,7 +287,11 @@ func (a *API) buildHandler() {
 
 	m.Handle("/get-unconfirmed-transaction", jsonHandler(a.getUnconfirmedTx))
 	m.Handle("/list-unconfirmed-transactions", jsonHandler(a.listUnconfirmedTxs))
+	m.Handle("/get-mempool-info", jsonHandler(a.getMempoolInfo))
+	m.Handle("/estimate-fee-rate", jsonHandler(a.estimateFeeRate))
 	m.Handle("/decode-raw-transaction", jsonHandler(a.decodeRawTransaction))
+	m.Handle("/get-raw-transaction", jsonHandler(a.getRawTransaction))
+	m.Handle("/get-output-spender", jsonHandler(a.getOutputSpender))
 
 	m.Handle("/get-block", jsonHandler(a.getBlock))
 	m.Handle("/get-raw-block", jsonHandler(a.getRawBlock))
@@ -284,6 +299,10 @@ func (a *API) buildHandler() {
 	m.Handle("/get-block-header", jsonHandler(a.getBlockHeader))
 	m.Handle("/get-block-count", jsonHandler(a.getBlockCount))
 
+	m.Handle("/get-address-history", jsonHandler(a.getAddressHistory))
+	m.Handle("/get-address-utxos", jsonHandler(a.getAddressUtxos))
+	m.Handle("/get-address-balance", jsonHandler(a.getAddressBalance))
+
 	m.Handle("/is-mining", jsonHandler(a.isMining))
 	m.Handle("/set-mining", jsonHandler(a.setMining))
 
@@ -299,6 +318,16 @@ func (a *API) buildHandler() {
 
 	m.Handle("/get-merkle-proof", jsonHandler(a.getMerkleProof))
 	m.Handle("/get-vote-result", jsonHandler(a.getVoteResult))
+	m.Handle("/list-casper-evidence", jsonHandler(a.listCasperEvidence))
+	m.Handle("/list-checkpoints", jsonHandler(a.listCheckpoints))
+	m.Handle("/get-checkpoint", jsonHandler(a.getCheckpoint))
+	m.Handle("/get-validator-stats", jsonHandler(a.getValidatorStats))
+	m.Handle("/get-consensus-params", jsonHandler(a.getConsensusParams))
+	m.Handle("/sign-param-proposal", jsonHandler(a.signParamProposal))
+	m.Handle("/submit-param-proposal", jsonHandler(a.submitParamProposal))
+	m.Handle("/list-deployments", jsonHandler(a.listDeployments))
+	m.Handle("/debug-transaction", jsonHandler(a.debugTransaction))
+	m.Handle("/simulate-transaction", jsonHandler(a.simulateTransaction))
 
 	m.Handle("/get-contract-instance", jsonHandler(a.getContractInstance))
 	m.Handle("/create-contract-instance", jsonHandler(a.createContractInstance))
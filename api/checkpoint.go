@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+type supLinkResp struct {
+	SourceHeight uint64   `json:"source_height"`
+	SourceHash   bc.Hash  `json:"source_hash"`
+	Signers      []string `json:"signers"`
+	VoteWeight   float64  `json:"vote_weight"`
+	IsMajority   bool     `json:"is_majority"`
+}
+
+type checkpointRewardResp struct {
+	ControlProgram string `json:"control_program"`
+	PubKey         string `json:"pub_key,omitempty"`
+	Amount         uint64 `json:"amount"`
+}
+
+type checkpointResp struct {
+	Height     uint64                  `json:"height"`
+	Hash       bc.Hash                 `json:"hash"`
+	ParentHash bc.Hash                 `json:"parent_hash"`
+	Timestamp  uint64                  `json:"timestamp"`
+	Status     string                  `json:"status"`
+	SupLinks   []*supLinkResp          `json:"sup_links"`
+	Rewards    []*checkpointRewardResp `json:"rewards"`
+	Slashed    []string                `json:"slashed,omitempty"`
+}
+
+// newCheckpointResp collect the signers of the sup links by the validators of the checkpoint,
+// the vote weight is the percentage of the votes of the signers, or the percentage of the
+// number of signers if the validators have no vote, e.g. the federation validators
+func (a *API) newCheckpointResp(checkpoint *state.Checkpoint) (*checkpointResp, error) {
+	validators, err := a.chain.CheckpointValidators(checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &checkpointResp{
+		Height:     checkpoint.Height,
+		Hash:       checkpoint.Hash,
+		ParentHash: checkpoint.ParentHash,
+		Timestamp:  checkpoint.Timestamp,
+		Status:     checkpoint.Status.String(),
+		SupLinks:   []*supLinkResp{},
+		Rewards:    []*checkpointRewardResp{},
+	}
+
+	totalVotes := uint64(0)
+	orderToValidator := map[int]*state.Validator{}
+	for _, validator := range validators {
+		totalVotes += validator.VoteNum
+		orderToValidator[validator.Order] = validator
+	}
+
+	for _, supLink := range checkpoint.SupLinks {
+		linkResp := &supLinkResp{
+			SourceHeight: supLink.SourceHeight,
+			SourceHash:   supLink.SourceHash,
+			Signers:      []string{},
+			IsMajority:   supLink.IsMajority(len(validators)),
+		}
+
+		signedVotes := uint64(0)
+		for order, signature := range supLink.Signatures {
+			validator, ok := orderToValidator[order]
+			if !ok || len(signature) == 0 {
+				continue
+			}
+
+			linkResp.Signers = append(linkResp.Signers, validator.PubKey)
+			signedVotes += validator.VoteNum
+		}
+
+		if totalVotes != 0 {
+			linkResp.VoteWeight = float64(signedVotes) * 100 / float64(totalVotes)
+		} else if len(validators) != 0 {
+			linkResp.VoteWeight = float64(len(linkResp.Signers)) * 100 / float64(len(validators))
+		}
+		resp.SupLinks = append(resp.SupLinks, linkResp)
+	}
+
+	for controlProgram, amount := range checkpoint.Rewards {
+		resp.Rewards = append(resp.Rewards, &checkpointRewardResp{
+			ControlProgram: controlProgram,
+			PubKey:         checkpoint.Producers[controlProgram],
+			Amount:         amount,
+		})
+	}
+	sort.Slice(resp.Rewards, func(i, j int) bool {
+		return resp.Rewards[i].ControlProgram < resp.Rewards[j].ControlProgram
+	})
+
+	for pubKey := range checkpoint.Slashed {
+		resp.Slashed = append(resp.Slashed, pubKey)
+	}
+	sort.Strings(resp.Slashed)
+	return resp, nil
+}
+
+// POST /list-checkpoints
+func (a *API) listCheckpoints(ctx context.Context, ins struct {
+	Height *uint64 `json:"height"`
+}) Response {
+	checkpoints := a.chain.ListCheckpoints()
+	if ins.Height != nil {
+		var err error
+		if checkpoints, err = a.chain.GetCheckpointsByHeight(*ins.Height); err != nil {
+			return NewErrorResponse(err)
+		}
+	}
+
+	resps := []*checkpointResp{}
+	for _, checkpoint := range checkpoints {
+		resp, err := a.newCheckpointResp(checkpoint)
+		if err != nil {
+			return NewErrorResponse(err)
+		}
+
+		resps = append(resps, resp)
+	}
+	return NewSuccessResponse(resps)
+}
+
+// POST /get-checkpoint
+func (a *API) getCheckpoint(ctx context.Context, ins struct {
+	Hash bc.Hash `json:"hash"`
+}) Response {
+	checkpoint, err := a.chain.GetCheckpoint(&ins.Hash)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	resp, err := a.newCheckpointResp(checkpoint)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(resp)
+}
@@ -1,5 +1,17 @@
 package api
 
+import (
+	"context"
+	"sort"
+
+	"github.com/bytom/bytom/consensus"
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/casper"
+	"github.com/bytom/bytom/protocol/state"
+)
+
 type VoteInfo struct {
 	Vote    string `json:"vote"`
 	VoteNum uint64 `json:"vote_number"`
@@ -30,3 +42,183 @@ func (a *API) getVoteResult(req BlockReq) Response {
 	}
 	return NewSuccessResponse(voteInfos)
 }
+
+// POST /list-casper-evidence
+func (a *API) listCasperEvidence(ctx context.Context, ins struct {
+	PubKey string `json:"pub_key"`
+}) Response {
+	evidences, err := a.chain.ListCasperEvidences()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	result := []*state.Evidence{}
+	for _, evidence := range evidences {
+		if ins.PubKey == "" || evidence.PubKey == ins.PubKey {
+			result = append(result, evidence)
+		}
+	}
+	return NewSuccessResponse(result)
+}
+
+const (
+	defaultValidatorStatsEpochs = 10
+	maxValidatorStatsEpochs     = 100
+)
+
+type validatorEpochStats struct {
+	CheckpointHeight   uint64  `json:"checkpoint_height"`
+	CheckpointHash     bc.Hash `json:"checkpoint_hash"`
+	Status             string  `json:"status"`
+	ProposedBlocks     uint64  `json:"proposed_blocks"`
+	MissedSlots        uint64  `json:"missed_slots"`
+	SignedVerification bool    `json:"signed_verification"`
+	Reward             uint64  `json:"reward"`
+}
+
+type validatorStats struct {
+	PubKey              string                 `json:"pub_key"`
+	ProposedBlocks      uint64                 `json:"proposed_blocks"`
+	MissedSlots         uint64                 `json:"missed_slots"`
+	SignedVerifications uint64                 `json:"signed_verifications"`
+	MissedVerifications uint64                 `json:"missed_verifications"`
+	Reward              uint64                 `json:"reward"`
+	Epochs              []*validatorEpochStats `json:"epochs"`
+}
+
+// POST /get-validator-stats
+// the verification of the growing checkpoint is neither counted as signed nor missed
+func (a *API) getValidatorStats(ctx context.Context, ins struct {
+	PubKey string `json:"pub_key"`
+	Epochs int    `json:"epochs"`
+}) Response {
+	if ins.Epochs <= 0 {
+		ins.Epochs = defaultValidatorStatsEpochs
+	} else if ins.Epochs > maxValidatorStatsEpochs {
+		ins.Epochs = maxValidatorStatsEpochs
+	}
+
+	epochStatsList, err := a.chain.GetValidatorStats(ins.Epochs)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	statsMap := map[string]*validatorStats{}
+	for _, epochStats := range epochStatsList {
+		pubKeys := map[string]bool{}
+		for pubKey := range epochStats.Validators {
+			pubKeys[pubKey] = true
+		}
+		for pubKey := range epochStats.Stats {
+			pubKeys[pubKey] = true
+		}
+
+		for pubKey := range pubKeys {
+			if ins.PubKey != "" && pubKey != ins.PubKey {
+				continue
+			}
+
+			if _, ok := statsMap[pubKey]; !ok {
+				statsMap[pubKey] = &validatorStats{PubKey: pubKey, Epochs: []*validatorEpochStats{}}
+			}
+
+			stats := statsMap[pubKey]
+			epoch := &validatorEpochStats{
+				CheckpointHeight:   epochStats.CheckpointHeight,
+				CheckpointHash:     epochStats.CheckpointHash,
+				Status:             epochStats.Status.String(),
+				SignedVerification: epochStats.Signers[pubKey],
+			}
+			if s, ok := epochStats.Stats[pubKey]; ok {
+				epoch.ProposedBlocks, epoch.MissedSlots, epoch.Reward = s.ProposedBlocks, s.MissedSlots, s.Reward
+			}
+
+			stats.ProposedBlocks += epoch.ProposedBlocks
+			stats.MissedSlots += epoch.MissedSlots
+			stats.Reward += epoch.Reward
+			if _, ok := epochStats.Validators[pubKey]; ok && epochStats.Status != state.Growing {
+				if epoch.SignedVerification {
+					stats.SignedVerifications++
+				} else {
+					stats.MissedVerifications++
+				}
+			}
+			stats.Epochs = append(stats.Epochs, epoch)
+		}
+	}
+
+	result := []*validatorStats{}
+	for _, stats := range statsMap {
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PubKey < result[j].PubKey
+	})
+	return NewSuccessResponse(result)
+}
+
+type consensusParamsResp struct {
+	Height  uint64               `json:"height"`
+	Params  map[string]uint64    `json:"params"`
+	Pending []*state.ParamChange `json:"pending"`
+}
+
+// POST /get-consensus-params
+func (a *API) getConsensusParams() Response {
+	params, err := a.chain.GetConsensusParams()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(&consensusParamsResp{Height: params.Height, Params: params.Params, Pending: params.Pending})
+}
+
+// POST /list-deployments
+func (a *API) listDeployments() Response {
+	deployments, err := a.chain.ListDeployments()
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(deployments)
+}
+
+type paramProposalReq struct {
+	Key            string `json:"key"`
+	Value          uint64 `json:"value"`
+	ActivateHeight uint64 `json:"activate_height"`
+}
+
+// POST /sign-param-proposal
+func (a *API) signParamProposal(ctx context.Context, ins paramProposalReq) Response {
+	if err := consensus.ValidateParam(ins.Key, ins.Value); err != nil {
+		return NewErrorResponse(errors.WithDetail(casper.ErrBadParamProposal, err.Error()))
+	}
+
+	signature, err := a.chain.SignParamProposal(ins.Key, ins.Value, ins.ActivateHeight)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(map[string]interface{}{
+		"pub_key":   a.chain.XPub().String(),
+		"signature": chainjson.HexBytes(signature),
+	})
+}
+
+// POST /submit-param-proposal
+func (a *API) submitParamProposal(ctx context.Context, ins struct {
+	paramProposalReq
+	Signatures map[string]chainjson.HexBytes `json:"signatures"`
+}) Response {
+	signatures := map[string][]byte{}
+	for pubKey, signature := range ins.Signatures {
+		signatures[pubKey] = signature
+	}
+
+	if err := a.chain.SubmitParamProposal(ins.Key, ins.Value, ins.ActivateHeight, signatures); err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(nil)
+}
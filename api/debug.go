@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+
+	"github.com/bytom/bytom/blockchain/txbuilder"
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/vm"
+)
+
+type traceStepResp struct {
+	Depth     int                  `json:"depth"`
+	PC        uint32               `json:"pc"`
+	Op        string               `json:"op"`
+	Data      chainjson.HexBytes   `json:"data,omitempty"`
+	RunLimit  int64                `json:"run_limit"`
+	DataStack []chainjson.HexBytes `json:"data_stack"`
+	AltStack  []chainjson.HexBytes `json:"alt_stack"`
+}
+
+func newTraceStepResp(step *vm.TraceStep) *traceStepResp {
+	toHex := func(stack [][]byte) []chainjson.HexBytes {
+		items := []chainjson.HexBytes{}
+		for _, item := range stack {
+			items = append(items, item)
+		}
+		return items
+	}
+
+	return &traceStepResp{
+		Depth:     step.Depth,
+		PC:        step.PC,
+		Op:        step.Op.String(),
+		Data:      step.Data,
+		RunLimit:  step.RunLimit,
+		DataStack: toHex(step.DataStack),
+		AltStack:  toHex(step.AltStack),
+	}
+}
+
+type inputTraceResp struct {
+	InputIndex int              `json:"input_index"`
+	InputID    bc.Hash          `json:"input_id"`
+	Steps      []*traceStepResp `json:"steps"`
+}
+
+type debugTxResp struct {
+	TxID        bc.Hash           `json:"tx_id"`
+	Valid       bool              `json:"valid"`
+	GasUsed     int64             `json:"gas_used,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	FailedInput *int              `json:"failed_input,omitempty"`
+	FailingStep *traceStepResp    `json:"failing_step,omitempty"`
+	Inputs      []*inputTraceResp `json:"inputs"`
+}
+
+// POST /debug-transaction
+// replay the input programs of the transaction against the utxos of the chain, the steps of
+// the programs and the failing step are returned
+func (a *API) debugTransaction(ctx context.Context, ins struct {
+	Tx types.Tx `json:"raw_transaction"`
+}) Response {
+	resp := &debugTxResp{TxID: ins.Tx.ID, Inputs: []*inputTraceResp{}}
+	inputs := map[bc.Hash]*inputTraceResp{}
+	for i, inputID := range ins.Tx.InputIDs {
+		input := &inputTraceResp{InputIndex: i, InputID: inputID, Steps: []*traceStepResp{}}
+		resp.Inputs = append(resp.Inputs, input)
+		inputs[inputID] = input
+	}
+
+	var lastInput *inputTraceResp
+	gasStatus, err := a.chain.TraceTx(&ins.Tx, func(entryID bc.Hash, step *vm.TraceStep) {
+		if input, ok := inputs[entryID]; ok {
+			input.Steps = append(input.Steps, newTraceStepResp(step))
+			lastInput = input
+		}
+	})
+	if err == nil {
+		resp.Valid, resp.GasUsed = true, gasStatus.GasUsed
+		return NewSuccessResponse(resp)
+	}
+
+	resp.Error = err.Error()
+	if vm.IsVMError(err) && lastInput != nil {
+		resp.FailedInput = &lastInput.InputIndex
+		if len(lastInput.Steps) > 0 {
+			resp.FailingStep = lastInput.Steps[len(lastInput.Steps)-1]
+		}
+	}
+	return NewSuccessResponse(resp)
+}
+
+type simulateInputResp struct {
+	InputIndex int     `json:"input_index"`
+	InputID    bc.Hash `json:"input_id"`
+	GasUsed    int64   `json:"gas_used"`
+	Valid      bool    `json:"valid"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type simulateOutputResp struct {
+	OutputID       bc.Hash              `json:"id"`
+	Position       int                  `json:"position"`
+	AssetID        bc.AssetID           `json:"asset_id"`
+	Amount         uint64               `json:"amount"`
+	ControlProgram chainjson.HexBytes   `json:"control_program"`
+	StateData      []chainjson.HexBytes `json:"state_data,omitempty"`
+	Vote           chainjson.HexBytes   `json:"vote,omitempty"`
+}
+
+type simulateTxResp struct {
+	TxID       bc.Hash               `json:"tx_id"`
+	Valid      bool                  `json:"valid"`
+	Error      string                `json:"error,omitempty"`
+	GasUsed    int64                 `json:"gas_used"`
+	StorageGas int64                 `json:"storage_gas"`
+	Fee        uint64                `json:"fee"`
+	Inputs     []*simulateInputResp  `json:"inputs"`
+	Outputs    []*simulateOutputResp `json:"outputs"`
+}
+
+// POST /simulate-transaction
+// run the transaction template or the raw transaction against the utxos of the chain and the
+// mempool without submitting it, the missing signatures of the template are filled with
+// placeholders and the signature checks are skipped if skip_signature is set
+func (a *API) simulateTransaction(ctx context.Context, ins struct {
+	TxTemplate    *txbuilder.Template `json:"transaction_template"`
+	Tx            *types.Tx           `json:"raw_transaction"`
+	SkipSignature bool                `json:"skip_signature"`
+}) Response {
+	rawTx := ins.Tx
+	if ins.TxTemplate != nil {
+		if ins.TxTemplate.Transaction == nil {
+			return NewErrorResponse(txbuilder.ErrMissingRawTx)
+		}
+
+		if ins.SkipSignature {
+			if err := txbuilder.MaterializeSimulationWitnesses(ins.TxTemplate); err != nil {
+				return NewErrorResponse(err)
+			}
+		}
+		rawTx = ins.TxTemplate.Transaction
+	}
+
+	if rawTx == nil {
+		return NewErrorResponse(txbuilder.ErrMissingRawTx)
+	}
+
+	// remap the transaction since the arguments of the inputs may be changed
+	tx := types.NewTx(rawTx.TxData)
+	gasStatus, results, err := a.chain.SimulateTx(tx, ins.SkipSignature)
+	// the spent outputs are not available, no program has been run
+	if results == nil {
+		return NewErrorResponse(err)
+	}
+
+	resp := &simulateTxResp{TxID: tx.ID, Valid: err == nil, Fee: tx.Fee(), Inputs: []*simulateInputResp{}, Outputs: []*simulateOutputResp{}}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	if gasStatus != nil {
+		resp.GasUsed, resp.StorageGas = gasStatus.GasUsed, gasStatus.StorageGas
+	}
+
+	for i, inputID := range tx.InputIDs {
+		input := &simulateInputResp{InputIndex: i, InputID: inputID}
+		if result, ok := results[inputID]; ok {
+			input.GasUsed, input.Valid = result.GasUsed, result.Err == nil
+			if result.Err != nil {
+				input.Error = result.Err.Error()
+				resp.Valid = false
+			}
+		} else {
+			input.Error = "program of the input is not run"
+			resp.Valid = false
+		}
+		resp.Inputs = append(resp.Inputs, input)
+	}
+
+	for i, output := range tx.Outputs {
+		out := &simulateOutputResp{
+			OutputID:       *tx.OutputID(i),
+			Position:       i,
+			AssetID:        *output.AssetId,
+			Amount:         output.Amount,
+			ControlProgram: output.ControlProgram,
+		}
+		for _, data := range output.StateData {
+			out.StateData = append(out.StateData, data)
+		}
+		if vote, ok := output.TypedOutput.(*types.VoteOutput); ok {
+			out.Vote = vote.Vote
+		}
+		resp.Outputs = append(resp.Outputs, out)
+	}
+	return NewSuccessResponse(resp)
+}
@@ -6,13 +6,18 @@ import (
 	"github.com/bytom/bytom/account"
 	"github.com/bytom/bytom/asset"
 	"github.com/bytom/bytom/blockchain/pseudohsm"
+	"github.com/bytom/bytom/blockchain/query"
 	"github.com/bytom/bytom/blockchain/rpc"
 	"github.com/bytom/bytom/blockchain/signers"
 	"github.com/bytom/bytom/blockchain/txbuilder"
+	"github.com/bytom/bytom/blockchain/txfeed"
 	"github.com/bytom/bytom/contract"
+	"github.com/bytom/bytom/database"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/net/http/httperror"
 	"github.com/bytom/bytom/net/http/httpjson"
+	"github.com/bytom/bytom/protocol"
+	"github.com/bytom/bytom/protocol/casper"
 	"github.com/bytom/bytom/protocol/validation"
 	"github.com/bytom/bytom/protocol/vm"
 )
@@ -55,6 +60,25 @@ var respErrFormatter = map[error]httperror.Info{
 	contract.ErrContractDuplicated: {400, "BTM302", "Contract is duplicated"},
 	contract.ErrContractNotFound:   {400, "BTM303", "Contract not found"},
 
+	// Txfeed and transaction query error namespace (4xx)
+	txfeed.ErrDuplicateAlias: {400, "BTM400", "Transaction feed alias already exists"},
+	txfeed.ErrBadAlias:       {400, "BTM401", "Invalid transaction feed alias"},
+	txfeed.ErrFeedNotFound:   {400, "BTM402", "Transaction feed not found"},
+	txfeed.ErrNumExceedLimit: {400, "BTM403", "Transaction feed number exceeds the limit"},
+	query.ErrBadFilter:       {400, "BTM405", "Invalid transaction filter"},
+	ErrBadTxQuery:            {400, "BTM406", "Filter and after can't be used with id, unconfirmed or from"},
+
+	// Chain index error namespace (41x)
+	protocol.ErrAddressIndexDisabled:  {400, "BTM410", "Address index is not enabled on the node"},
+	database.ErrBadAddressCursor:      {400, "BTM411", "Invalid address history cursor"},
+	ErrBadAddress:                     {400, "BTM412", "Invalid address or control program"},
+	database.ErrTxIndexNotFound:       {400, "BTM413", "Transaction not found in the main chain"},
+	protocol.ErrSpendIndexDisabled:    {400, "BTM414", "Spend index is not enabled on the node"},
+	database.ErrOutputSpenderNotFound: {400, "BTM415", "Output is not spent in the main chain"},
+
+	// Consensus error namespace (42x)
+	casper.ErrBadParamProposal: {400, "BTM420", "Invalid consensus parameter proposal"},
+
 	// Transaction error namespace (7xx)
 	// Build transaction error namespace (70x ~ 72x)
 	account.ErrInsufficient:         {400, "BTM700", "Funds of account are insufficient"},
@@ -72,6 +96,10 @@ var respErrFormatter = map[error]httperror.Info{
 	txbuilder.ErrOrphanTx:           {400, "BTM712", "Transaction input UTXO not found"},
 	txbuilder.ErrExtTxFee:           {400, "BTM713", "Transaction fee exceeded max limit"},
 	txbuilder.ErrNoGasInput:         {400, "BTM714", "Transaction has no gas input"},
+	account.ErrBumpFeeInput:         {400, "BTM715", "Transaction input is not spent from the wallet accounts"},
+	account.ErrBumpFeeChange:        {400, "BTM716", "Transaction has no BTM change output to pay the bumped fee"},
+	account.ErrBumpFeeLow:           {400, "BTM717", "Bumped fee must be higher than the origin fee"},
+	protocol.ErrMissingUtxo:         {400, "BTM718", "Transaction input UTXO not found in the chain or the mempool"},
 
 	// Submit transaction error namespace (73x ~ 79x)
 	// Validation error (73x ~ 75x)
@@ -113,6 +141,12 @@ var respErrFormatter = map[error]httperror.Info{
 	vm.ErrUnsupportedVM:      {400, "BTM774", "Unsupported VM because the version of VM is mismatched"},
 	vm.ErrVerifyFailed:       {400, "BTM775", "VERIFY failed"},
 
+	// Mempool error (79x)
+	protocol.ErrLowFeeRate:           {400, "BTM790", "Transaction fee rate is too low for the full mempool"},
+	protocol.ErrReplaceFeeTooLow:     {400, "BTM791", "Replacement transaction fee is not higher than the replaced ones"},
+	protocol.ErrReplaceSpendConflict: {400, "BTM792", "Replacement transaction spends the outputs of the replaced ones"},
+	protocol.ErrBadFeeEstimateTarget: {400, "BTM793", "Fee estimate target is out of range"},
+
 	// Mock HSM error namespace (8xx)
 	pseudohsm.ErrDuplicateKeyAlias: {400, "BTM800", "Key Alias already exists"},
 	pseudohsm.ErrLoadKey:           {400, "BTM801", "Key not found or wrong password"},
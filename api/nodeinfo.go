@@ -4,7 +4,6 @@ import (
 	"context"
 	"net"
 
-	"github.com/bytom/bytom/config"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/netsync/peers"
 	"github.com/bytom/bytom/p2p"
@@ -41,7 +40,7 @@ func (a *API) getNetInfo() Response {
 		Listening:     a.sync.IsListening(),
 		Syncing:       !a.sync.IsCaughtUp(),
 		Mining:        a.blockProposer.IsProposing(),
-		NodeXPub:      config.CommonConfig.PrivateKey().XPub().String(),
+		NodeXPub:      a.chain.XPub().String(),
 		PeerCount:     a.sync.PeerCount(),
 		HighestHeight: highestBlockHeight,
 		NetWorkID:     a.sync.GetNetwork(),
@@ -126,6 +126,17 @@ func (a *API) getTransaction(ctx context.Context, txInfo struct {
 	return NewSuccessResponse(annotatedTx)
 }
 
+const defaultQueryTxsCount = 100
+
+// ErrBadTxQuery is returned when the filter query of list-transactions is mixed with
+// the options it doesn't support
+var ErrBadTxQuery = errors.New("filter and after can't be used with id, unconfirmed or from")
+
+type listTxsResp struct {
+	Transactions interface{} `json:"transactions"`
+	After        string      `json:"after"`
+}
+
 // POST /list-transactions
 func (a *API) listTransactions(ctx context.Context, filter struct {
 	ID          string `json:"id"`
@@ -134,11 +145,20 @@ func (a *API) listTransactions(ctx context.Context, filter struct {
 	Unconfirmed bool   `json:"unconfirmed"`
 	From        uint   `json:"from"`
 	Count       uint   `json:"count"`
+	Filter      string `json:"filter"`
+	After       string `json:"after"`
 }) Response {
 	transactions := []*query.AnnotatedTx{}
 	var err error
 	var transaction *query.AnnotatedTx
 
+	if filter.Filter != "" || filter.After != "" {
+		if filter.ID != "" || filter.Unconfirmed || filter.From != 0 {
+			return NewErrorResponse(ErrBadTxQuery)
+		}
+		return a.queryTransactions(filter.Filter, filter.AccountID, filter.After, filter.Count, filter.Detail)
+	}
+
 	if filter.ID != "" {
 		transaction, err = a.wallet.GetTransactionByTxID(filter.ID)
 		if err != nil && filter.Unconfirmed {
@@ -173,6 +193,36 @@ func (a *API) listTransactions(ctx context.Context, filter struct {
 	return NewSuccessResponse(transactions[start:end])
 }
 
+// queryTransactions page through the confirmed wallet transactions matching the filter
+// expression in the order of block height and position, the returned cursor is passed
+// as after to get the next page
+func (a *API) queryTransactions(expression, accountID, after string, count uint, detail bool) Response {
+	txFilter, err := query.ParseFilter(expression)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	if accountID != "" {
+		if txFilter, err = txFilter.And("account_id", accountID); err != nil {
+			return NewErrorResponse(err)
+		}
+	}
+
+	if count == 0 {
+		count = defaultQueryTxsCount
+	}
+
+	transactions, after, err := a.wallet.QueryTransactions(txFilter, after, int(count))
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	if !detail {
+		return NewSuccessResponse(&listTxsResp{Transactions: a.wallet.GetTransactionsSummary(transactions), After: after})
+	}
+	return NewSuccessResponse(&listTxsResp{Transactions: transactions, After: after})
+}
+
 // POST /get-unconfirmed-transaction
 func (a *API) getUnconfirmedTx(ctx context.Context, filter struct {
 	TxID chainjson.HexBytes `json:"tx_id"`
@@ -236,6 +286,67 @@ func (a *API) listUnconfirmedTxs(ctx context.Context) Response {
 	})
 }
 
+// POST /get-mempool-info
+func (a *API) getMempoolInfo(ctx context.Context) Response {
+	return NewSuccessResponse(a.chain.GetTxPool().GetInfo())
+}
+
+// estimateFeeRateResp is the recommended fee per unit of gas for the target blocks
+type estimateFeeRateResp struct {
+	Target  uint64  `json:"target"`
+	FeeRate float64 `json:"fee_rate"`
+}
+
+// POST /estimate-fee-rate
+func (a *API) estimateFeeRate(ctx context.Context, ins struct {
+	Target uint64 `json:"target"`
+}) Response {
+	feeRate, err := a.chain.GetTxPool().EstimateFeeRate(ins.Target)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(&estimateFeeRateResp{Target: ins.Target, FeeRate: feeRate})
+}
+
+type getRawTransactionResp struct {
+	RawTransaction *types.Tx `json:"raw_transaction"`
+	TxID           bc.Hash   `json:"tx_id"`
+	BlockHash      bc.Hash   `json:"block_hash"`
+	BlockHeight    uint64    `json:"block_height"`
+	Position       uint64    `json:"position"`
+}
+
+// POST /get-raw-transaction
+func (a *API) getRawTransaction(ctx context.Context, ins struct {
+	TxID bc.Hash `json:"tx_id"`
+}) Response {
+	tx, blockHeader, position, err := a.chain.GetTransactionByID(&ins.TxID)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(&getRawTransactionResp{
+		RawTransaction: tx,
+		TxID:           tx.ID,
+		BlockHash:      blockHeader.Hash(),
+		BlockHeight:    blockHeader.Height,
+		Position:       position,
+	})
+}
+
+// POST /get-output-spender
+func (a *API) getOutputSpender(ctx context.Context, ins struct {
+	OutputID bc.Hash `json:"output_id"`
+}) Response {
+	spender, err := a.chain.GetOutputSpender(&ins.OutputID)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	return NewSuccessResponse(spender)
+}
+
 // RawTx is the tx struct for getRawTransaction
 type RawTx struct {
 	ID        bc.Hash                  `json:"tx_id"`
@@ -10,6 +10,7 @@ import (
 
 	"github.com/bytom/bytom/account"
 	"github.com/bytom/bytom/blockchain/txbuilder"
+	chainjson "github.com/bytom/bytom/encoding/json"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/net/http/reqid"
 	"github.com/bytom/bytom/protocol/bc"
@@ -221,6 +222,61 @@ func (a *API) submitTxs(ctx context.Context, ins struct {
 	return NewSuccessResponse(&submitTxsResp{TxID: txHashs})
 }
 
+type bumpTxFeeResp struct {
+	TxID         *bc.Hash            `json:"tx_id,omitempty"`
+	Tx           *txbuilder.Template `json:"transaction"`
+	SignComplete bool                `json:"sign_complete"`
+}
+
+// POST /bump-transaction-fee
+func (a *API) bumpTxFee(ctx context.Context, ins struct {
+	TxID     chainjson.HexBytes `json:"tx_id"`
+	Fee      uint64             `json:"fee"`
+	Password string             `json:"password"`
+	CPFP     bool               `json:"cpfp"`
+	TTL      chainjson.Duration `json:"ttl"`
+}) Response {
+	var tmpTxID [32]byte
+	copy(tmpTxID[:], ins.TxID[:])
+
+	txHash := bc.NewHash(tmpTxID)
+	txDesc, err := a.chain.GetTxPool().GetTransaction(&txHash)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	if ins.TTL.Duration == 0 {
+		ins.TTL.Duration = defaultTxTTL
+	}
+
+	maxTime := time.Now().Add(ins.TTL.Duration)
+	var tpl *txbuilder.Template
+	if ins.CPFP {
+		tpl, err = a.wallet.AccountMgr.BuildChildPaysTx(txDesc.Tx, ins.Fee, maxTime)
+	} else {
+		tpl, err = a.wallet.AccountMgr.BuildReplaceTx(txDesc.Tx, ins.Fee, maxTime)
+	}
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	if err := txbuilder.Sign(ctx, tpl, ins.Password, a.pseudohsmSignTemplate); err != nil {
+		log.WithField("build err", err).Error("fail on sign bump fee transaction.")
+		return NewErrorResponse(err)
+	}
+
+	if !txbuilder.SignProgress(tpl) {
+		return NewSuccessResponse(&bumpTxFeeResp{Tx: tpl})
+	}
+
+	if err := txbuilder.FinalizeTx(ctx, a.chain, tpl.Transaction); err != nil {
+		return NewErrorResponse(err)
+	}
+
+	log.WithFields(log.Fields{"tx_id": tpl.Transaction.ID.String(), "origin_tx_id": txHash.String(), "cpfp": ins.CPFP}).Info("submit bump fee tx")
+	return NewSuccessResponse(&bumpTxFeeResp{TxID: &tpl.Transaction.ID, Tx: tpl, SignComplete: true})
+}
+
 // POST /estimate-transaction-gas
 func (a *API) estimateTxGas(ctx context.Context, in struct {
 	TxTemplate txbuilder.Template `json:"transaction_template"`
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bytom/bytom/blockchain/query"
+)
+
+const defaultTxFeedPageSize = 100
+
+// POST /create-transaction-feed
+func (a *API) createTxFeed(ctx context.Context, in struct {
+	Alias  string `json:"alias"`
+	Filter string `json:"filter"`
+}) Response {
+	feed, err := a.txFeedTracker.Create(in.Alias, in.Filter)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+	return NewSuccessResponse(feed)
+}
+
+// POST /get-transaction-feed
+func (a *API) getTxFeed(ctx context.Context, in struct {
+	Alias string `json:"alias"`
+}) Response {
+	feed, err := a.txFeedTracker.Get(in.Alias)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+	return NewSuccessResponse(feed)
+}
+
+// POST /list-transaction-feeds
+func (a *API) listTxFeeds(ctx context.Context) Response {
+	feeds, err := a.txFeedTracker.List()
+	if err != nil {
+		log.Errorf("listTxFeeds: %v", err)
+		return NewErrorResponse(err)
+	}
+	return NewSuccessResponse(feeds)
+}
+
+// POST /update-transaction-feed
+func (a *API) updateTxFeed(ctx context.Context, in struct {
+	Alias  string `json:"alias"`
+	Filter string `json:"filter"`
+}) Response {
+	feed, err := a.txFeedTracker.Update(in.Alias, in.Filter)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+	return NewSuccessResponse(feed)
+}
+
+// POST /delete-transaction-feed
+func (a *API) deleteTxFeed(ctx context.Context, in struct {
+	Alias string `json:"alias"`
+}) Response {
+	if err := a.txFeedTracker.Delete(in.Alias); err != nil {
+		return NewErrorResponse(err)
+	}
+	return NewSuccessResponse(nil)
+}
+
+type txFeedTxsResp struct {
+	Transactions []*query.AnnotatedTx `json:"transactions"`
+	After        string               `json:"after"`
+}
+
+// POST /list-transaction-feed-transactions
+// return the next page of the confirmed transactions matching the feed filter, the
+// feed cursor is used and moved to the end of the page when the after is empty, an
+// explicit after only pages through the feed without touching its cursor
+func (a *API) listTxFeedTxs(ctx context.Context, in struct {
+	Alias string `json:"alias"`
+	After string `json:"after"`
+	Count int    `json:"count"`
+}) Response {
+	feed, err := a.txFeedTracker.Get(in.Alias)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	useCursor := in.After == ""
+	if useCursor {
+		in.After = feed.After
+	}
+
+	if in.Count <= 0 {
+		in.Count = defaultTxFeedPageSize
+	}
+
+	txs, after, err := a.wallet.GetTransactionsAfter(in.After, in.Count, feed.Match)
+	if err != nil {
+		return NewErrorResponse(err)
+	}
+
+	if useCursor {
+		if err := a.txFeedTracker.SetCursor(in.Alias, after); err != nil {
+			return NewErrorResponse(err)
+		}
+	}
+	return NewSuccessResponse(&txFeedTxsResp{Transactions: txs, After: after})
+}
@@ -0,0 +1,532 @@
+package query
+
+import (
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bytom/bytom/errors"
+)
+
+// ErrBadFilter is returned when the filter expression can't be parsed
+var ErrBadFilter = errors.New("invalid transaction filter")
+
+// fields evaluated against the transaction
+var txFields = map[string]bool{
+	"tx_id":        false,
+	"block_height": true,
+	"block_time":   true,
+}
+
+// fields evaluated against the transaction inputs and outputs, a condition out of the
+// inputs(...) or outputs(...) scope matches when any input or output satisfies it
+var ioFields = map[string]bool{
+	"type":            false,
+	"asset_id":        false,
+	"asset_alias":     false,
+	"amount":          true,
+	"account_id":      false,
+	"account_alias":   false,
+	"address":         false,
+	"control_program": false,
+	"arbitrary":       false,
+}
+
+// Filter is the parsed filter expression over the annotated transactions, the grammar is:
+//
+//	expr  := and { "OR" and }
+//	and   := unary { "AND" unary }
+//	unary := "(" expr ")" | ("inputs" | "outputs") "(" expr ")" | field op value
+//	op    := "=" | "!=" | "<" | "<=" | ">" | ">="
+//
+// string values are quoted by single quotes and only support "=" and "!=", for example:
+// asset_alias='BTM' AND outputs(account_alias='alice' AND amount>=100) AND block_height>1000
+type Filter struct {
+	expr filterExpr
+}
+
+// ParseFilter parse the filter expression, the empty expression matches all transactions
+func ParseFilter(expression string) (*Filter, error) {
+	tokens, err := lexFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return &Filter{}, nil
+	}
+
+	p := &filterParser{tokens: tokens}
+	expr, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, errors.WithDetailf(ErrBadFilter, "unexpected %q", p.tokens[p.pos].text)
+	}
+	return &Filter{expr: expr}, nil
+}
+
+// Match check whether the annotated transaction satisfies the filter
+func (f *Filter) Match(tx *AnnotatedTx) bool {
+	return f.expr == nil || f.expr.eval(tx, nil)
+}
+
+// And return a new filter matching the transactions satisfying both the filter and the
+// condition field='value', the value is compared as is so it never changes the structure
+// of the expression like the concatenated filter text does
+func (f *Filter) And(field, value string) (*Filter, error) {
+	numeric, ok := txFields[field]
+	if !ok {
+		numeric, ok = ioFields[field]
+	}
+
+	if !ok || numeric {
+		return nil, errors.WithDetailf(ErrBadFilter, "field %q is not a string field", field)
+	}
+
+	if field == "control_program" || field == "arbitrary" {
+		value = strings.ToLower(value)
+	}
+
+	var expr filterExpr = &condExpr{field: field, op: "=", str: value}
+	if f.expr != nil {
+		expr = &andExpr{left: f.expr, right: expr}
+	}
+	return &Filter{expr: expr}, nil
+}
+
+// HeightRange return the block height range required by the filter, it's used to bound
+// the scan of the transactions indexed by block height
+func (f *Filter) HeightRange() (uint64, uint64) {
+	min, max := uint64(0), uint64(math.MaxUint64)
+	var walk func(expr filterExpr)
+	walk = func(expr filterExpr) {
+		switch e := expr.(type) {
+		case *andExpr:
+			walk(e.left)
+			walk(e.right)
+		case *condExpr:
+			if e.field != "block_height" {
+				return
+			}
+
+			switch e.op {
+			case "=":
+				min, max = maxUint64(min, e.num), minUint64(max, e.num)
+			case ">":
+				if e.num == math.MaxUint64 {
+					min = e.num
+				} else {
+					min = maxUint64(min, e.num+1)
+				}
+			case ">=":
+				min = maxUint64(min, e.num)
+			case "<":
+				if e.num == 0 {
+					max = 0
+				} else {
+					max = minUint64(max, e.num-1)
+				}
+			case "<=":
+				max = minUint64(max, e.num)
+			}
+		}
+	}
+
+	walk(f.expr)
+	return min, max
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// ioRecord is the common fields of the annotated input and output
+type ioRecord struct {
+	fields map[string]string
+	amount uint64
+}
+
+func inputRecord(input *AnnotatedInput) *ioRecord {
+	return &ioRecord{
+		fields: map[string]string{
+			"type":            input.Type,
+			"asset_id":        input.AssetID.String(),
+			"asset_alias":     input.AssetAlias,
+			"account_id":      input.AccountID,
+			"account_alias":   input.AccountAlias,
+			"address":         input.Address,
+			"control_program": hex.EncodeToString(input.ControlProgram),
+			"arbitrary":       hex.EncodeToString(input.Arbitrary),
+		},
+		amount: input.Amount,
+	}
+}
+
+func outputRecord(output *AnnotatedOutput) *ioRecord {
+	return &ioRecord{
+		fields: map[string]string{
+			"type":            output.Type,
+			"asset_id":        output.AssetID.String(),
+			"asset_alias":     output.AssetAlias,
+			"account_id":      output.AccountID,
+			"account_alias":   output.AccountAlias,
+			"address":         output.Address,
+			"control_program": hex.EncodeToString(output.ControlProgram),
+		},
+		amount: output.Amount,
+	}
+}
+
+func txRecords(tx *AnnotatedTx, scope string) []*ioRecord {
+	records := []*ioRecord{}
+	if scope != "outputs" {
+		for _, input := range tx.Inputs {
+			records = append(records, inputRecord(input))
+		}
+	}
+
+	if scope != "inputs" {
+		for _, output := range tx.Outputs {
+			records = append(records, outputRecord(output))
+		}
+	}
+	return records
+}
+
+type filterExpr interface {
+	eval(tx *AnnotatedTx, record *ioRecord) bool
+}
+
+type andExpr struct {
+	left, right filterExpr
+}
+
+func (e *andExpr) eval(tx *AnnotatedTx, record *ioRecord) bool {
+	return e.left.eval(tx, record) && e.right.eval(tx, record)
+}
+
+type orExpr struct {
+	left, right filterExpr
+}
+
+func (e *orExpr) eval(tx *AnnotatedTx, record *ioRecord) bool {
+	return e.left.eval(tx, record) || e.right.eval(tx, record)
+}
+
+// scopeExpr matches when any input or output in the scope satisfies all the inner conditions
+type scopeExpr struct {
+	scope string
+	inner filterExpr
+}
+
+func (e *scopeExpr) eval(tx *AnnotatedTx, _ *ioRecord) bool {
+	for _, record := range txRecords(tx, e.scope) {
+		if e.inner.eval(tx, record) {
+			return true
+		}
+	}
+	return false
+}
+
+type condExpr struct {
+	field string
+	op    string
+	str   string
+	num   uint64
+}
+
+func (e *condExpr) eval(tx *AnnotatedTx, record *ioRecord) bool {
+	switch e.field {
+	case "tx_id":
+		return e.compareString(tx.ID.String())
+	case "block_height":
+		return e.compareNum(tx.BlockHeight)
+	case "block_time":
+		return e.compareNum(tx.Timestamp)
+	}
+
+	if record != nil {
+		return e.evalRecord(record)
+	}
+
+	for _, record := range txRecords(tx, "") {
+		if e.evalRecord(record) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *condExpr) evalRecord(record *ioRecord) bool {
+	if e.field == "amount" {
+		return e.compareNum(record.amount)
+	}
+	return e.compareString(record.fields[e.field])
+}
+
+func (e *condExpr) compareString(value string) bool {
+	if e.op == "=" {
+		return value == e.str
+	}
+	return value != e.str
+}
+
+func (e *condExpr) compareNum(value uint64) bool {
+	switch e.op {
+	case "=":
+		return value == e.num
+	case "!=":
+		return value != e.num
+	case "<":
+		return value < e.num
+	case "<=":
+		return value <= e.num
+	case ">":
+		return value > e.num
+	default:
+		return value >= e.num
+	}
+}
+
+const (
+	tokIdent = iota
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type filterToken struct {
+	typ  int
+	text string
+}
+
+func lexFilter(expression string) ([]*filterToken, error) {
+	tokens := []*filterToken{}
+	for i := 0; i < len(expression); {
+		c := rune(expression[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, &filterToken{typ: tokLParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, &filterToken{typ: tokRParen, text: ")"})
+			i++
+		case c == '\'':
+			end := strings.IndexByte(expression[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.WithDetail(ErrBadFilter, "unterminated string")
+			}
+
+			tokens = append(tokens, &filterToken{typ: tokString, text: expression[i+1 : i+1+end]})
+			i += end + 2
+		case strings.ContainsRune("=!<>", c):
+			op := string(c)
+			if i+1 < len(expression) && expression[i+1] == '=' {
+				op += "="
+			}
+
+			if op == "!" {
+				return nil, errors.WithDetail(ErrBadFilter, "unexpected \"!\"")
+			}
+
+			tokens = append(tokens, &filterToken{typ: tokOp, text: op})
+			i += len(op)
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(expression) && unicode.IsDigit(rune(expression[i])) {
+				i++
+			}
+			tokens = append(tokens, &filterToken{typ: tokNumber, text: expression[start:i]})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(expression) && (unicode.IsLetter(rune(expression[i])) || unicode.IsDigit(rune(expression[i])) || expression[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, &filterToken{typ: tokIdent, text: expression[start:i]})
+		default:
+			return nil, errors.WithDetailf(ErrBadFilter, "unexpected %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []*filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() (*filterToken, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, errors.WithDetail(ErrBadFilter, "unexpected end of filter")
+	}
+
+	p.pos++
+	return tok, nil
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok != nil && tok.typ == tokIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *filterParser) parseOr(scope string) (filterExpr, error) {
+	left, err := p.parseAnd(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(scope string) (filterExpr, error) {
+	left, err := p.parseUnary(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("AND") {
+		p.pos++
+		right, err := p.parseUnary(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseParen(scope string) (filterExpr, error) {
+	if tok, err := p.next(); err != nil {
+		return nil, err
+	} else if tok.typ != tokLParen {
+		return nil, errors.WithDetailf(ErrBadFilter, "expect \"(\" but got %q", tok.text)
+	}
+
+	expr, err := p.parseOr(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if tok, err := p.next(); err != nil {
+		return nil, err
+	} else if tok.typ != tokRParen {
+		return nil, errors.WithDetailf(ErrBadFilter, "expect \")\" but got %q", tok.text)
+	}
+	return expr, nil
+}
+
+func (p *filterParser) parseUnary(scope string) (filterExpr, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, errors.WithDetail(ErrBadFilter, "unexpected end of filter")
+	}
+
+	if tok.typ == tokLParen {
+		return p.parseParen(scope)
+	}
+
+	if p.peekKeyword("inputs") || p.peekKeyword("outputs") {
+		if scope != "" {
+			return nil, errors.WithDetailf(ErrBadFilter, "nested %s scope", tok.text)
+		}
+
+		p.pos++
+		newScope := strings.ToLower(tok.text)
+		inner, err := p.parseParen(newScope)
+		if err != nil {
+			return nil, err
+		}
+		return &scopeExpr{scope: newScope, inner: inner}, nil
+	}
+	return p.parseCond()
+}
+
+func (p *filterParser) parseCond() (filterExpr, error) {
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	numeric, isTxField := txFields[field.text]
+	if !isTxField {
+		var isIOField bool
+		if numeric, isIOField = ioFields[field.text]; !isIOField {
+			return nil, errors.WithDetailf(ErrBadFilter, "unknown field %q", field.text)
+		}
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	} else if op.typ != tokOp {
+		return nil, errors.WithDetailf(ErrBadFilter, "expect operator but got %q", op.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	cond := &condExpr{field: field.text, op: op.text}
+	if numeric {
+		if value.typ != tokNumber {
+			return nil, errors.WithDetailf(ErrBadFilter, "field %q expect number but got %q", field.text, value.text)
+		}
+
+		if cond.num, err = strconv.ParseUint(value.text, 10, 64); err != nil {
+			return nil, errors.WithDetailf(ErrBadFilter, "invalid number %q", value.text)
+		}
+		return cond, nil
+	}
+
+	if value.typ != tokString {
+		return nil, errors.WithDetailf(ErrBadFilter, "field %q expect quoted string but got %q", field.text, value.text)
+	}
+
+	if op.text != "=" && op.text != "!=" {
+		return nil, errors.WithDetailf(ErrBadFilter, "field %q doesn't support operator %q", field.text, op.text)
+	}
+
+	cond.str = value.text
+	if field.text == "control_program" || field.text == "arbitrary" {
+		cond.str = strings.ToLower(cond.str)
+	}
+	return cond, nil
+}
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+)
+
+func TestParseFilterError(t *testing.T) {
+	cases := []string{
+		"asset_alias",
+		"asset_alias=",
+		"asset_alias='BTM",
+		"asset_alias=BTM",
+		"asset_alias>'BTM'",
+		"amount='100'",
+		"unknown_field='1'",
+		"inputs(outputs(amount>1))",
+		"(amount>1",
+		"amount>1 amount<2",
+		"amount>1 AND",
+		"amount!1",
+	}
+
+	for i, c := range cases {
+		if _, err := ParseFilter(c); errors.Root(err) != ErrBadFilter {
+			t.Errorf("case %d(%s): got err %v want err %v", i, c, err, ErrBadFilter)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	txID := bc.Hash{V0: 1}
+	tx := &AnnotatedTx{
+		ID:          txID,
+		BlockHeight: 100,
+		Timestamp:   1600000000,
+		Inputs: []*AnnotatedInput{
+			{Type: "spend", AssetAlias: "BTM", AccountAlias: "alice", Amount: 1000, Arbitrary: []byte{0xab}},
+		},
+		Outputs: []*AnnotatedOutput{
+			{Type: "control", AssetAlias: "BTM", AccountAlias: "bob", Amount: 300},
+			{Type: "control", AssetAlias: "BTM", AccountAlias: "alice", Amount: 600},
+		},
+	}
+
+	cases := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "", want: true},
+		{filter: "asset_alias='BTM'", want: true},
+		{filter: "asset_alias!='BTM'", want: false},
+		{filter: "account_alias='bob' AND amount>=1000", want: true},
+		{filter: "outputs(account_alias='bob' AND amount>=1000)", want: false},
+		{filter: "outputs(account_alias='bob' AND amount<1000)", want: true},
+		{filter: "inputs(account_alias='bob')", want: false},
+		{filter: "inputs(account_alias='bob') OR outputs(account_alias='bob')", want: true},
+		{filter: "block_height>=100 AND block_height<101", want: true},
+		{filter: "block_height>100", want: false},
+		{filter: "block_time<1600000000 OR (arbitrary='AB' and amount=1000)", want: true},
+		{filter: "tx_id='" + txID.String() + "'", want: true},
+	}
+
+	for i, c := range cases {
+		filter, err := ParseFilter(c.filter)
+		if err != nil {
+			t.Fatalf("case %d(%s): %v", i, c.filter, err)
+		}
+
+		if got := filter.Match(tx); got != c.want {
+			t.Errorf("case %d(%s): got %v want %v", i, c.filter, got, c.want)
+		}
+	}
+}
+
+func TestFilterHeightRange(t *testing.T) {
+	cases := []struct {
+		filter  string
+		wantMin uint64
+		wantMax uint64
+	}{
+		{filter: "", wantMin: 0, wantMax: math.MaxUint64},
+		{filter: "block_height>10 AND block_height<=20", wantMin: 11, wantMax: 20},
+		{filter: "block_height=15 AND amount>1", wantMin: 15, wantMax: 15},
+		{filter: "block_height>=10 OR block_height<5", wantMin: 0, wantMax: math.MaxUint64},
+		{filter: "(block_height>=10 AND block_height<5) AND amount>1", wantMin: 10, wantMax: 4},
+		{filter: "outputs(amount>1) AND block_height<1", wantMin: 0, wantMax: 0},
+	}
+
+	for i, c := range cases {
+		filter, err := ParseFilter(c.filter)
+		if err != nil {
+			t.Fatalf("case %d(%s): %v", i, c.filter, err)
+		}
+
+		if min, max := filter.HeightRange(); min != c.wantMin || max != c.wantMax {
+			t.Errorf("case %d(%s): got range [%d, %d] want [%d, %d]", i, c.filter, min, max, c.wantMin, c.wantMax)
+		}
+	}
+}
+
+func TestFilterAnd(t *testing.T) {
+	tx := &AnnotatedTx{
+		Inputs: []*AnnotatedInput{
+			{AccountID: "acc1", AccountAlias: "alice", Amount: 1000},
+		},
+		Outputs: []*AnnotatedOutput{
+			{AccountID: "acc2", AccountAlias: "bob", Amount: 300},
+		},
+	}
+
+	cases := []struct {
+		filter    string
+		accountID string
+		want      bool
+	}{
+		{filter: "", accountID: "acc1", want: true},
+		{filter: "", accountID: "acc3", want: false},
+		{filter: "account_alias='bob'", accountID: "acc1", want: true},
+		{filter: "account_alias='carol' OR amount>0", accountID: "acc3", want: false},
+		{filter: "amount>0", accountID: "acc3' OR account_id!='", want: false},
+	}
+
+	for i, c := range cases {
+		filter, err := ParseFilter(c.filter)
+		if err != nil {
+			t.Fatalf("case %d(%s): %v", i, c.filter, err)
+		}
+
+		if filter, err = filter.And("account_id", c.accountID); err != nil {
+			t.Fatalf("case %d(%s): %v", i, c.filter, err)
+		}
+
+		if got := filter.Match(tx); got != c.want {
+			t.Errorf("case %d(%s): got %v want %v", i, c.filter, got, c.want)
+		}
+	}
+
+	if _, err := (&Filter{}).And("amount", "1"); errors.Root(err) != ErrBadFilter {
+		t.Errorf("got err %v want err %v", err, ErrBadFilter)
+	}
+}
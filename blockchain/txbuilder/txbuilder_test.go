@@ -181,6 +181,59 @@ func TestSignatureWitnessMaterialize(t *testing.T) {
 	}
 }
 
+func TestMaterializeSimulationWitnesses(t *testing.T) {
+	privkey, pubkey, err := chainkd.NewXKeys(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, pubkey2, err := chainkd.NewXKeys(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuanceProg, _ := vmutil.P2SPMultiSigProgram([]ed25519.PublicKey{pubkey.PublicKey(), pubkey2.PublicKey()}, 2)
+	assetID := bc.ComputeAssetID(issuanceProg, 1, &bc.EmptyStringHash)
+	tpl := &Template{
+		Transaction: types.NewTx(types.TxData{
+			Version: 1,
+			Inputs: []*types.TxInput{
+				types.NewIssuanceInput([]byte{1}, 100, issuanceProg, nil, nil),
+			},
+			Outputs: []*types.TxOutput{
+				types.NewOriginalTxOutput(assetID, 100, []byte{byte(vm.OP_TRUE)}, nil),
+			},
+		}),
+	}
+
+	h := tpl.Hash(0)
+	prog, _ := vmutil.NewBuilder().AddData(h.Bytes()).AddOp(vm.OP_TXSIGHASH).AddOp(vm.OP_EQUAL).Build()
+	msg := sha3.Sum256(prog)
+	sig := privkey.Sign(msg[:])
+
+	component := &SignatureWitness{
+		Quorum: 2,
+		Keys: []keyID{
+			{XPub: pubkey, DerivationPath: []chainjson.HexBytes{{0, 0, 0, 0}}},
+			{XPub: pubkey2, DerivationPath: []chainjson.HexBytes{{0, 0, 0, 0}}},
+		},
+		Sigs: []chainjson.HexBytes{sig},
+	}
+	tpl.SigningInstructions = []*SigningInstruction{{WitnessComponents: []witnessComponent{component}}}
+
+	if err := MaterializeSimulationWitnesses(tpl); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]byte{vm.Uint64Bytes(0), sig, placeholderSig, prog}
+	if got := tpl.Transaction.Inputs[0].Arguments(); !testutil.DeepEqual(got, want) {
+		t.Errorf("got input witness %x, want input witness %x", got, want)
+	}
+
+	if len(component.Program) != 0 || len(component.Sigs) != 1 {
+		t.Errorf("witness component of the template is changed by the simulation")
+	}
+}
+
 func mustDecodeHex(str string) []byte {
 	data, err := hex.DecodeString(str)
 	if err != nil {
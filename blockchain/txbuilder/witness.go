@@ -71,3 +71,52 @@ func SignProgress(txTemplate *Template) bool {
 	}
 	return true
 }
+
+// placeholderSig stands for the missing signature when the template is simulated, it only
+// passes the signature checks skipped by the simulation
+var placeholderSig = make(chainjson.HexBytes, 64)
+
+func fillPlaceholderSigs(sigs []chainjson.HexBytes, numKeys, quorum int) []chainjson.HexBytes {
+	filled := make([]chainjson.HexBytes, numKeys)
+	copy(filled, sigs)
+	for i, nsigs := 0, signedCount(filled); i < len(filled) && nsigs < quorum; i++ {
+		if len(filled[i]) == 0 {
+			filled[i] = placeholderSig
+			nsigs++
+		}
+	}
+	return filled
+}
+
+// MaterializeSimulationWitnesses materializes the witnesses of the template as if it's fully
+// signed, the missing signatures are filled with placeholders and the missing signature
+// programs are built, the witness components of the template are left unchanged
+func MaterializeSimulationWitnesses(txTemplate *Template) error {
+	simTemplate := *txTemplate
+	simTemplate.SigningInstructions = make([]*SigningInstruction, 0, len(txTemplate.SigningInstructions))
+	for _, sigInst := range txTemplate.SigningInstructions {
+		simInst := &SigningInstruction{Position: sigInst.Position}
+		for _, wc := range sigInst.WitnessComponents {
+			switch sw := wc.(type) {
+			case *SignatureWitness:
+				simWitness := *sw
+				if len(simWitness.Program) == 0 && txTemplate.Transaction != nil {
+					program, err := buildSigProgram(txTemplate, sigInst.Position)
+					if err != nil {
+						return err
+					}
+					simWitness.Program = program
+				}
+				simWitness.Sigs = fillPlaceholderSigs(sw.Sigs, len(sw.Keys), sw.Quorum)
+				wc = &simWitness
+			case *RawTxSigWitness:
+				simWitness := *sw
+				simWitness.Sigs = fillPlaceholderSigs(sw.Sigs, len(sw.Keys), sw.Quorum)
+				wc = &simWitness
+			}
+			simInst.WitnessComponents = append(simInst.WitnessComponents, wc)
+		}
+		simTemplate.SigningInstructions = append(simTemplate.SigningInstructions, simInst)
+	}
+	return materializeWitnesses(&simTemplate)
+}
@@ -0,0 +1,215 @@
+// Package txfeed provides the named and persisted transaction filters, each of
+// them holds a cursor so the client could page through the matching confirmed
+// transactions and resume after restart.
+package txfeed
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/bytom/bytom/blockchain/query"
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+)
+
+const (
+	// FilterNumMax is the max number of the transaction feeds
+	FilterNumMax = 1024
+
+	txFeedPrefix = "TXF:"
+)
+
+var (
+	// ErrDuplicateAlias is returned when Create is called on an existing alias
+	ErrDuplicateAlias = errors.New("duplicate transaction feed alias")
+	// ErrBadAlias is returned when the alias is empty or contains invalid characters
+	ErrBadAlias = errors.New("invalid transaction feed alias")
+	// ErrFeedNotFound is returned when the alias is not existed
+	ErrFeedNotFound = errors.New("transaction feed not found")
+	// ErrNumExceedLimit is returned when the number of feeds reach FilterNumMax
+	ErrNumExceedLimit = errors.New("transaction feed number exceed limit")
+
+	// validAliasRegexp checks that all characters are alphumeric, _ or -.
+	validAliasRegexp = regexp.MustCompile(`^[\w-]+$`)
+)
+
+// TxFeed describe a named filter over the annotated transactions, After is the
+// cursor of the last transaction returned to the client
+type TxFeed struct {
+	Alias  string `json:"alias"`
+	Filter string `json:"filter"`
+	After  string `json:"after,omitempty"`
+
+	txFilter *query.Filter
+}
+
+// Match check whether the annotated transaction satisfies the feed filter
+func (f *TxFeed) Match(tx *query.AnnotatedTx) bool {
+	return f.txFilter.Match(tx)
+}
+
+func calcTxFeedKey(alias string) []byte {
+	return []byte(txFeedPrefix + alias)
+}
+
+// Tracker store the transaction feeds
+type Tracker struct {
+	mtx sync.Mutex
+	db  dbm.DB
+}
+
+// NewTracker creates and returns a new Tracker object.
+func NewTracker(db dbm.DB) *Tracker {
+	return &Tracker{db: db}
+}
+
+// decodeTxFeed unmarshal the saved feed and parse its filter expression
+func decodeTxFeed(rawFeed []byte) (*TxFeed, error) {
+	feed := &TxFeed{}
+	if err := json.Unmarshal(rawFeed, feed); err != nil {
+		return nil, err
+	}
+
+	txFilter, err := query.ParseFilter(feed.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.txFilter = txFilter
+	return feed, nil
+}
+
+func (t *Tracker) get(alias string) (*TxFeed, error) {
+	rawFeed := t.db.Get(calcTxFeedKey(alias))
+	if rawFeed == nil {
+		return nil, errors.WithDetailf(ErrFeedNotFound, "alias %q", alias)
+	}
+	return decodeTxFeed(rawFeed)
+}
+
+func (t *Tracker) save(feed *TxFeed) error {
+	rawFeed, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+
+	t.db.Set(calcTxFeedKey(feed.Alias), rawFeed)
+	return nil
+}
+
+func (t *Tracker) list() ([]*TxFeed, error) {
+	feeds := []*TxFeed{}
+	iter := t.db.IteratorPrefix([]byte(txFeedPrefix))
+	defer iter.Release()
+
+	for iter.Next() {
+		feed, err := decodeTxFeed(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		feeds = append(feeds, feed)
+	}
+	return feeds, nil
+}
+
+// Create save a new transaction feed with the alias and filter
+func (t *Tracker) Create(alias, filter string) (*TxFeed, error) {
+	if !validAliasRegexp.MatchString(alias) {
+		return nil, errors.WithDetailf(ErrBadAlias, "invalid alias %q", alias)
+	}
+
+	txFilter, err := query.ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.db.Get(calcTxFeedKey(alias)) != nil {
+		return nil, errors.WithDetailf(ErrDuplicateAlias, "alias %q already in use", alias)
+	}
+
+	feeds, err := t.list()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(feeds) >= FilterNumMax {
+		return nil, ErrNumExceedLimit
+	}
+
+	feed := &TxFeed{Alias: alias, Filter: filter, txFilter: txFilter}
+	return feed, t.save(feed)
+}
+
+// Get return the transaction feed by alias
+func (t *Tracker) Get(alias string) (*TxFeed, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.get(alias)
+}
+
+// List return all the transaction feeds sorted by alias
+func (t *Tracker) List() ([]*TxFeed, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	feeds, err := t.list()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(feeds, func(i, j int) bool { return feeds[i].Alias < feeds[j].Alias })
+	return feeds, nil
+}
+
+// Update replace the filter of the transaction feed, the cursor is kept
+func (t *Tracker) Update(alias, filter string) (*TxFeed, error) {
+	txFilter, err := query.ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	feed, err := t.get(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.Filter, feed.txFilter = filter, txFilter
+	return feed, t.save(feed)
+}
+
+// SetCursor save the cursor of the transaction feed
+func (t *Tracker) SetCursor(alias, after string) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	feed, err := t.get(alias)
+	if err != nil {
+		return err
+	}
+
+	feed.After = after
+	return t.save(feed)
+}
+
+// Delete remove the transaction feed by alias
+func (t *Tracker) Delete(alias string) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.db.Get(calcTxFeedKey(alias)) == nil {
+		return errors.WithDetailf(ErrFeedNotFound, "alias %q", alias)
+	}
+
+	t.db.Delete(calcTxFeedKey(alias))
+	return nil
+}
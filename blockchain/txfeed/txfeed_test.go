@@ -0,0 +1,125 @@
+package txfeed
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bytom/bytom/blockchain/query"
+	"github.com/bytom/bytom/consensus"
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestMatch(t *testing.T) {
+	tx := &query.AnnotatedTx{
+		Inputs: []*query.AnnotatedInput{
+			{AccountAlias: "alice", AssetID: *consensus.BTMAssetID, Amount: 1000, ControlProgram: []byte{0x00, 0x14}},
+		},
+		Outputs: []*query.AnnotatedOutput{
+			{AccountAlias: "bob", AssetID: *consensus.BTMAssetID, Amount: 300, ControlProgram: []byte{0x00, 0x15}},
+			{AccountAlias: "alice", AssetID: *consensus.BTMAssetID, Amount: 600, ControlProgram: []byte{0x00, 0x14}},
+		},
+	}
+
+	cases := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "", want: true},
+		{filter: "account_alias='bob'", want: true},
+		{filter: "outputs(account_alias='bob' AND amount>=500)", want: false},
+		{filter: "outputs(account_alias='alice' AND amount<=700)", want: true},
+		{filter: "outputs(control_program='0015' AND amount<=200)", want: false},
+		{filter: "account_alias='carol'", want: false},
+	}
+
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	defer os.RemoveAll("temp")
+	tracker := NewTracker(testDB)
+	for i, c := range cases {
+		if _, err := tracker.Create("feed", c.filter); err != nil {
+			t.Fatal(err)
+		}
+
+		feed, err := tracker.Get("feed")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tracker.Delete("feed"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := feed.Match(tx); got != c.want {
+			t.Errorf("case %d: got %v want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestTracker(t *testing.T) {
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	defer os.RemoveAll("temp")
+	tracker := NewTracker(testDB)
+
+	if _, err := tracker.Create("feed-b", "account_alias='bob'"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tracker.Create("feed-a", "account_alias='alice'"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tracker.Create("feed-a", ""); errors.Root(err) != ErrDuplicateAlias {
+		t.Errorf("got err %v want err %v", err, ErrDuplicateAlias)
+	}
+
+	if _, err := tracker.Create("bad:alias", ""); errors.Root(err) != ErrBadAlias {
+		t.Errorf("got err %v want err %v", err, ErrBadAlias)
+	}
+
+	if _, err := tracker.Create("feed-c", "amount_lower_limit=100"); errors.Root(err) != query.ErrBadFilter {
+		t.Errorf("got err %v want err %v", err, query.ErrBadFilter)
+	}
+
+	if err := tracker.SetCursor("feed-a", "00000000000000010000001"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tracker.Update("feed-a", "account_alias='carol'"); err != nil {
+		t.Fatal(err)
+	}
+
+	// reload from the db to check the feed is persisted
+	feed, err := NewTracker(testDB).Get("feed-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txFilter, err := query.ParseFilter("account_alias='carol'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &TxFeed{Alias: "feed-a", Filter: "account_alias='carol'", After: "00000000000000010000001", txFilter: txFilter}
+	if !testutil.DeepEqual(feed, want) {
+		t.Errorf("got %v want %v", feed, want)
+	}
+
+	if err := tracker.Delete("feed-b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.Delete("feed-b"); errors.Root(err) != ErrFeedNotFound {
+		t.Errorf("got err %v want err %v", err, ErrFeedNotFound)
+	}
+
+	feeds, err := tracker.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feeds) != 1 || feeds[0].Alias != "feed-a" {
+		t.Errorf("got feeds %v want only feed-a", feeds)
+	}
+}
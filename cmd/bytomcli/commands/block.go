@@ -146,3 +146,82 @@ var getBlockHeaderCmd = &cobra.Command{
 		printJSON(data)
 	},
 }
+
+var listCheckpointsCmd = &cobra.Command{
+	Use:   "list-checkpoints [height]",
+	Short: "List the unfinalized checkpoints, or the checkpoints of all forks at the given height",
+	Args:  cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ins := &struct {
+			Height *uint64 `json:"height,omitempty"`
+		}{}
+
+		if len(args) == 1 {
+			height, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				jww.ERROR.Printf("Invalid height value")
+				os.Exit(util.ErrLocalExe)
+			}
+
+			ins.Height = &height
+		}
+
+		data, exitCode := util.ClientCall("/list-checkpoints", ins)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSONList(data)
+	},
+}
+
+var getCheckpointCmd = &cobra.Command{
+	Use:   "get-checkpoint <hash>",
+	Short: "Get the checkpoint ended with the block of the given hash",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ins := &struct {
+			Hash string `json:"hash"`
+		}{Hash: args[0]}
+
+		data, exitCode := util.ClientCall("/get-checkpoint", ins)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
+var getValidatorStatsCmd = &cobra.Command{
+	Use:   "get-validator-stats [pub key] [epochs]",
+	Short: "Get the proposed blocks, missed slots, verifications and rewards of the validators in the recent epochs",
+	Args:  cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ins := &struct {
+			PubKey string `json:"pub_key,omitempty"`
+			Epochs int    `json:"epochs,omitempty"`
+		}{}
+
+		if len(args) > 0 {
+			ins.PubKey = args[0]
+		}
+
+		if len(args) > 1 {
+			epochs, err := strconv.Atoi(args[1])
+			if err != nil {
+				jww.ERROR.Printf("Invalid epochs value")
+				os.Exit(util.ErrLocalExe)
+			}
+
+			ins.Epochs = epochs
+		}
+
+		data, exitCode := util.ClientCall("/get-validator-stats", ins)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSONList(data)
+	},
+}
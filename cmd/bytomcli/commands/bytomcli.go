@@ -130,7 +130,12 @@ func AddCommands() {
 
 	BytomcliCmd.AddCommand(getUnconfirmedTransactionCmd)
 	BytomcliCmd.AddCommand(listUnconfirmedTransactionsCmd)
+	BytomcliCmd.AddCommand(getMempoolInfoCmd)
+	BytomcliCmd.AddCommand(estimateFeeRateCmd)
 	BytomcliCmd.AddCommand(decodeRawTransactionCmd)
+	BytomcliCmd.AddCommand(debugTransactionCmd)
+	BytomcliCmd.AddCommand(getRawTransactionCmd)
+	BytomcliCmd.AddCommand(getOutputSpenderCmd)
 
 	BytomcliCmd.AddCommand(listUnspentOutputsCmd)
 	BytomcliCmd.AddCommand(listBalancesCmd)
@@ -141,12 +146,17 @@ func AddCommands() {
 	BytomcliCmd.AddCommand(buildTransactionCmd)
 	BytomcliCmd.AddCommand(signTransactionCmd)
 	BytomcliCmd.AddCommand(submitTransactionCmd)
+	BytomcliCmd.AddCommand(bumpFeeCmd)
 	BytomcliCmd.AddCommand(estimateTransactionGasCmd)
+	BytomcliCmd.AddCommand(simulateTransactionCmd)
 
 	BytomcliCmd.AddCommand(getBlockCountCmd)
 	BytomcliCmd.AddCommand(getBlockHashCmd)
 	BytomcliCmd.AddCommand(getBlockCmd)
 	BytomcliCmd.AddCommand(getBlockHeaderCmd)
+	BytomcliCmd.AddCommand(listCheckpointsCmd)
+	BytomcliCmd.AddCommand(getCheckpointCmd)
+	BytomcliCmd.AddCommand(getValidatorStatsCmd)
 
 	BytomcliCmd.AddCommand(createKeyCmd)
 	BytomcliCmd.AddCommand(deleteKeyCmd)
@@ -164,6 +174,7 @@ func AddCommands() {
 	BytomcliCmd.AddCommand(deleteTransactionFeedCmd)
 	BytomcliCmd.AddCommand(getTransactionFeedCmd)
 	BytomcliCmd.AddCommand(updateTransactionFeedCmd)
+	BytomcliCmd.AddCommand(listTransactionFeedTransactionsCmd)
 
 	BytomcliCmd.AddCommand(netInfoCmd)
 	BytomcliCmd.AddCommand(gasRateCmd)
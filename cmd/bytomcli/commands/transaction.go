@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	jww "github.com/spf13/jwalterweatherman"
@@ -32,10 +33,17 @@ func init() {
 	signTransactionCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "password of the account which sign these transaction(s)")
 	signTransactionCmd.PersistentFlags().BoolVar(&pretty, "pretty", false, "pretty print json result")
 
+	bumpFeeCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "password of the account which sign the bump fee transaction")
+	bumpFeeCmd.PersistentFlags().BoolVar(&cpfp, "cpfp", false, "spend the change output by a child transaction instead of replacing the origin one")
+
+	simulateTransactionCmd.PersistentFlags().BoolVar(&skipSignature, "skip-signature", false, "fill the missing signatures with placeholders and skip the signature checks")
+
 	listTransactionsCmd.PersistentFlags().StringVar(&txID, "id", "", "transaction id")
 	listTransactionsCmd.PersistentFlags().StringVar(&account, "account_id", "", "account id")
 	listTransactionsCmd.PersistentFlags().BoolVar(&detail, "detail", false, "list transactions details")
 	listTransactionsCmd.PersistentFlags().BoolVar(&unconfirmed, "unconfirmed", false, "list unconfirmed transactions")
+	listTransactionsCmd.PersistentFlags().StringVar(&txFilter, "filter", "", "filter expression of the confirmed transactions, e.g. \"outputs(asset_alias='BTM' AND amount>=100) AND block_height>1000\"")
+	listTransactionsCmd.PersistentFlags().StringVar(&txAfter, "after", "", "list the confirmed transactions after the cursor")
 }
 
 var (
@@ -53,6 +61,10 @@ var (
 	arbitrary       = ""
 	program         = ""
 	contractName    = ""
+	cpfp            = false
+	txFilter        = ""
+	txAfter         = ""
+	skipSignature   = false
 )
 
 var buildIssueReqFmt = `
@@ -284,6 +296,42 @@ var submitTransactionCmd = &cobra.Command{
 	},
 }
 
+var bumpFeeCmd = &cobra.Command{
+	Use:   "bump-fee <tx_id> <fee>",
+	Short: "Raise the fee of the unconfirmed wallet transaction and resubmit it",
+	Args:  cobra.ExactArgs(2),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmd.MarkFlagRequired("password")
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		txID, err := hex.DecodeString(args[0])
+		if err != nil {
+			jww.ERROR.Println(err)
+			os.Exit(util.ErrLocalExe)
+		}
+
+		fee, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			jww.ERROR.Println(err)
+			os.Exit(util.ErrLocalExe)
+		}
+
+		var req = struct {
+			TxID     chainjson.HexBytes `json:"tx_id"`
+			Fee      uint64             `json:"fee"`
+			Password string             `json:"password"`
+			CPFP     bool               `json:"cpfp"`
+		}{TxID: txID, Fee: fee, Password: password, CPFP: cpfp}
+
+		data, exitCode := util.ClientCall("/bump-transaction-fee", &req)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
 var estimateTransactionGasCmd = &cobra.Command{
 	Use:   "estimate-transaction-gas  <json templates>",
 	Short: "estimate gas for build transaction",
@@ -310,6 +358,33 @@ var estimateTransactionGasCmd = &cobra.Command{
 	},
 }
 
+var simulateTransactionCmd = &cobra.Command{
+	Use:   "simulate-transaction <json templates>",
+	Short: "run the transaction template against the current chain state without submitting it",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		template := txbuilder.Template{}
+
+		err := json.Unmarshal([]byte(args[0]), &template)
+		if err != nil {
+			jww.ERROR.Println(err)
+			os.Exit(util.ErrLocalExe)
+		}
+
+		var req = struct {
+			TxTemplate    txbuilder.Template `json:"transaction_template"`
+			SkipSignature bool               `json:"skip_signature"`
+		}{TxTemplate: template, SkipSignature: skipSignature}
+
+		data, exitCode := util.ClientCall("/simulate-transaction", &req)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
 var decodeRawTransactionCmd = &cobra.Command{
 	Use:   "decode-raw-transaction <raw_transaction>",
 	Short: "decode the raw transaction",
@@ -334,6 +409,30 @@ var decodeRawTransactionCmd = &cobra.Command{
 	},
 }
 
+var debugTransactionCmd = &cobra.Command{
+	Use:   "debug-transaction <raw_transaction>",
+	Short: "replay the input programs of the raw transaction and print the steps",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var ins = struct {
+			Tx types.Tx `json:"raw_transaction"`
+		}{}
+
+		err := ins.Tx.UnmarshalText([]byte(args[0]))
+		if err != nil {
+			jww.ERROR.Println(err)
+			os.Exit(util.ErrLocalExe)
+		}
+
+		data, exitCode := util.ClientCall("/debug-transaction", &ins)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
 var getTransactionCmd = &cobra.Command{
 	Use:   "get-transaction <hash>",
 	Short: "get the transaction by matching the given transaction hash",
@@ -352,6 +451,42 @@ var getTransactionCmd = &cobra.Command{
 	},
 }
 
+var getRawTransactionCmd = &cobra.Command{
+	Use:   "get-raw-transaction <tx_id>",
+	Short: "get the main chain transaction by tx id without the wallet",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		txInfo := &struct {
+			TxID string `json:"tx_id"`
+		}{TxID: args[0]}
+
+		data, exitCode := util.ClientCall("/get-raw-transaction", txInfo)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
+var getOutputSpenderCmd = &cobra.Command{
+	Use:   "get-output-spender <output_id>",
+	Short: "get the main chain transaction input which spends the output",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ins := &struct {
+			OutputID string `json:"output_id"`
+		}{OutputID: args[0]}
+
+		data, exitCode := util.ClientCall("/get-output-spender", ins)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
 var listTransactionsCmd = &cobra.Command{
 	Use:   "list-transactions",
 	Short: "List the transactions",
@@ -362,13 +497,19 @@ var listTransactionsCmd = &cobra.Command{
 			AccountID   string `json:"account_id"`
 			Detail      bool   `json:"detail"`
 			Unconfirmed bool   `json:"unconfirmed"`
-		}{ID: txID, AccountID: account, Detail: detail, Unconfirmed: unconfirmed}
+			Filter      string `json:"filter"`
+			After       string `json:"after"`
+		}{ID: txID, AccountID: account, Detail: detail, Unconfirmed: unconfirmed, Filter: txFilter, After: txAfter}
 
 		data, exitCode := util.ClientCall("/list-transactions", &filter)
 		if exitCode != util.Success {
 			os.Exit(exitCode)
 		}
 
+		if txFilter != "" || txAfter != "" {
+			printJSON(data)
+			return
+		}
 		printJSONList(data)
 	},
 }
@@ -411,6 +552,44 @@ var listUnconfirmedTransactionsCmd = &cobra.Command{
 	},
 }
 
+var getMempoolInfoCmd = &cobra.Command{
+	Use:   "get-mempool-info",
+	Short: "Print the summary of the mempool transactions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		data, exitCode := util.ClientCall("/get-mempool-info")
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
+var estimateFeeRateCmd = &cobra.Command{
+	Use:   "estimate-fee-rate <target>",
+	Short: "Estimate the fee per gas for the transaction to be confirmed in the target blocks",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			jww.ERROR.Println(err)
+			os.Exit(util.ErrLocalExe)
+		}
+
+		var req = struct {
+			Target uint64 `json:"target"`
+		}{Target: target}
+
+		data, exitCode := util.ClientCall("/estimate-fee-rate", &req)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+
+		printJSON(data)
+	},
+}
+
 var gasRateCmd = &cobra.Command{
 	Use:   "gas-rate",
 	Short: "Print the current gas rate",
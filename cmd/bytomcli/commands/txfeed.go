@@ -9,6 +9,16 @@ import (
 	"github.com/bytom/bytom/util"
 )
 
+var (
+	feedAfter = ""
+	feedCount = 0
+)
+
+func init() {
+	listTransactionFeedTransactionsCmd.PersistentFlags().StringVar(&feedAfter, "after", "", "list transactions after the cursor instead of the feed cursor")
+	listTransactionFeedTransactionsCmd.PersistentFlags().IntVar(&feedCount, "count", 0, "max number of transactions listed")
+}
+
 var createTransactionFeedCmd = &cobra.Command{
 	Use:   "create-transaction-feed <alias> <filter>",
 	Short: "Create a transaction feed filter",
@@ -88,3 +98,22 @@ var updateTransactionFeedCmd = &cobra.Command{
 		jww.FEEDBACK.Println("Successfully updated transaction feed")
 	},
 }
+
+var listTransactionFeedTransactionsCmd = &cobra.Command{
+	Use:   "list-transaction-feed-transactions <alias>",
+	Short: "list the next page of transactions matching the transaction feed",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var req = struct {
+			Alias string `json:"alias"`
+			After string `json:"after"`
+			Count int    `json:"count"`
+		}{Alias: args[0], After: feedAfter, Count: feedCount}
+
+		data, exitCode := util.ClientCall("/list-transaction-feed-transactions", &req)
+		if exitCode != util.Success {
+			os.Exit(exitCode)
+		}
+		printJSON(data)
+	},
+}
@@ -19,8 +19,11 @@ var initFilesCmd = &cobra.Command{
 	Run:   initFiles,
 }
 
+var genesisFile string
+
 func init() {
 	initFilesCmd.Flags().String("chain_id", config.ChainID, "Select [mainnet] or [testnet] or [solonet]")
+	initFilesCmd.Flags().StringVar(&genesisFile, "genesis", "", "Create the custom network defined by the genesis spec file")
 
 	RootCmd.AddCommand(initFilesCmd)
 }
@@ -32,8 +35,19 @@ func initFiles(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	switch config.ChainID {
-	case "mainnet", "testnet":
+	switch {
+	case genesisFile != "":
+		spec, err := cfg.LoadGenesisSpec(genesisFile)
+		if err != nil {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Fatal("fail on load genesis spec")
+		}
+
+		if err := cfg.EnsureGenesisRoot(config.RootDir, spec); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Fatal("fail on save genesis spec")
+		}
+
+		log.WithFields(log.Fields{"module": logModule, "chain_id": spec.ChainID}).Info("Initialized custom network")
+	case config.ChainID == "mainnet" || config.ChainID == "testnet":
 		cfg.EnsureRoot(config.RootDir, config.ChainID)
 	default:
 		cfg.EnsureRoot(config.RootDir, "solonet")
@@ -53,6 +53,20 @@ func init() {
 	runNodeCmd.Flags().Int("ws.max_num_websockets", config.Websocket.MaxNumWebsockets, "Max number of websocket connections")
 	runNodeCmd.Flags().Int("ws.max_num_concurrent_reqs", config.Websocket.MaxNumConcurrentReqs, "Max number of concurrent websocket requests that may be processed concurrently")
 
+	// mempool flags
+	runNodeCmd.Flags().Int("mempool.max_num_txs", config.Mempool.MaxNumTxs, "Max number of transactions kept in the mempool, the lowest fee rate ones are evicted first")
+	runNodeCmd.Flags().Bool("mempool.persist", config.Mempool.Persist, "Dump the mempool transactions on stop and reload them on start")
+	runNodeCmd.Flags().Int("mempool.expiration", config.Mempool.Expiration, "Hours the dumped mempool transactions are kept before dropped on reload")
+
+	// signer flags
+	runNodeCmd.Flags().String("signer_addr", config.SignerAddress, "Address of the remote signer of the blocks and verifications, tcp://host:port or unix:///path")
+	runNodeCmd.Flags().String("signer_secret", config.SignerSecret, "Secret shared with the remote signer to authenticate the signing requests, required for the tcp address")
+	runNodeCmd.Flags().String("signer_state_file", config.SignerStateFile, "File to record the last signed block and verification")
+
+	// index flags
+	runNodeCmd.Flags().Bool("index.address", config.Index.Address, "Index all the chain transactions and utxos by control program")
+	runNodeCmd.Flags().Bool("index.spend", config.Index.Spend, "Index the spending transaction of every spent output")
+
 	RootCmd.AddCommand(runNodeCmd)
 }
 
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	"github.com/bytom/bytom/signer"
+)
+
+var runSignerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Run the signer of the blocks and verifications with the private key in the root dir",
+	RunE:  runSigner,
+}
+
+func init() {
+	runSignerCmd.Flags().String("signer_addr", config.SignerAddress, "Address the signer listens on, tcp://host:port or unix:///path")
+	runSignerCmd.Flags().String("signer_secret", config.SignerSecret, "Secret shared with the node to authenticate the signing requests, required for the tcp address")
+	runSignerCmd.Flags().String("signer_state_file", config.SignerStateFile, "File to record the last signed block and verification")
+	runSignerCmd.Flags().String("log_level", config.LogLevel, "Select log level(debug, info, warn, error or fatal)")
+
+	RootCmd.AddCommand(runSignerCmd)
+}
+
+func runSigner(cmd *cobra.Command, args []string) error {
+	setLogLevel(config.LogLevel)
+
+	localSigner, err := signer.NewLocalSigner(*config.PrivateKey(), config.SignerStatePath())
+	if err != nil {
+		cmn.Exit(cmn.Fmt("Failed to create signer: %v", err))
+	}
+
+	return signer.NewServer(localSigner, config.SignerSecret).ListenAndServe(config.SignerAddress)
+}
@@ -27,6 +27,8 @@ type Config struct {
 	Auth      *RPCAuthConfig   `mapstructure:"auth"`
 	Web       *WebConfig       `mapstructure:"web"`
 	Websocket *WebsocketConfig `mapstructure:"ws"`
+	Mempool   *MempoolConfig   `mapstructure:"mempool"`
+	Index     *IndexConfig     `mapstructure:"index"`
 }
 
 // Default configurable parameters.
@@ -38,6 +40,8 @@ func DefaultConfig() *Config {
 		Auth:       DefaultRPCAuthConfig(),
 		Web:        DefaultWebConfig(),
 		Websocket:  DefaultWebsocketConfig(),
+		Mempool:    DefaultMempoolConfig(),
+		Index:      DefaultIndexConfig(),
 	}
 }
 
@@ -118,6 +122,17 @@ type BaseConfig struct {
 	// log file name
 	LogFile string `mapstructure:"log_file"`
 
+	// TCP or UNIX socket address of the remote signer of the blocks and verifications,
+	// e.g. unix:///var/run/bytom_signer.sock, the private key of the node signs if it's empty
+	SignerAddress string `mapstructure:"signer_addr"`
+
+	// Secret shared by the node and the remote signer to authenticate the signing requests,
+	// it's required if the signer address is TCP
+	SignerSecret string `mapstructure:"signer_secret"`
+
+	// File to record the last signed block and verification to prevent the double sign
+	SignerStateFile string `mapstructure:"signer_state_file"`
+
 	PrivateKeyFile string `mapstructure:"private_key_file"`
 	XPrv           *chainkd.XPrv
 	XPub           *chainkd.XPub
@@ -135,6 +150,7 @@ func DefaultBaseConfig() BaseConfig {
 		NodeAlias:         "",
 		LogFile:           "log",
 		PrivateKeyFile:    "node_key.txt",
+		SignerStateFile:   "signer_state.json",
 	}
 }
 
@@ -150,6 +166,10 @@ func (b BaseConfig) KeysDir() string {
 	return rootify(b.KeysPath, b.RootDir)
 }
 
+func (b BaseConfig) SignerStatePath() string {
+	return rootify(b.SignerStateFile, b.RootDir)
+}
+
 // P2PConfig
 type P2PConfig struct {
 	ListenAddress    string `mapstructure:"laddr"`
@@ -201,6 +221,19 @@ type WebsocketConfig struct {
 	MaxNumConcurrentReqs int `mapstructure:"max_num_concurrent_reqs"`
 }
 
+type MempoolConfig struct {
+	MaxNumTxs  int  `mapstructure:"max_num_txs"`
+	Persist    bool `mapstructure:"persist"`
+	Expiration int  `mapstructure:"expiration"`
+}
+
+// IndexConfig select the optional chain-wide indexes maintained by the core store,
+// they should be enabled before the node start syncing
+type IndexConfig struct {
+	Address bool `mapstructure:"address"`
+	Spend   bool `mapstructure:"spend"`
+}
+
 // Default configurable rpc's auth parameters.
 func DefaultRPCAuthConfig() *RPCAuthConfig {
 	return &RPCAuthConfig{
@@ -232,6 +265,23 @@ func DefaultWebsocketConfig() *WebsocketConfig {
 	}
 }
 
+// Default configurable mempool parameters.
+func DefaultMempoolConfig() *MempoolConfig {
+	return &MempoolConfig{
+		MaxNumTxs:  10000,
+		Persist:    true,
+		Expiration: 24,
+	}
+}
+
+// Default configurable index parameters.
+func DefaultIndexConfig() *IndexConfig {
+	return &IndexConfig{
+		Address: false,
+		Spend:   false,
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Utils
 
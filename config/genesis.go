@@ -79,11 +79,15 @@ func soloNetGenesisBlock() *types.Block {
 	return block
 }
 
+// genesisBlocks is the correspondence between the network name and the genesis block,
+// the custom networks are registered by the genesis spec
+var genesisBlocks = map[string]func() *types.Block{
+	"main": mainNetGenesisBlock,
+	"test": testNetGenesisBlock,
+	"solo": soloNetGenesisBlock,
+}
+
 // GenesisBlock will return genesis block
 func GenesisBlock() *types.Block {
-	return map[string]func() *types.Block{
-		"main": mainNetGenesisBlock,
-		"test": testNetGenesisBlock,
-		"solo": soloNetGenesisBlock,
-	}[consensus.ActiveNetParams.Name]()
+	return genesisBlocks[consensus.ActiveNetParams.Name]()
 }
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+
+	"github.com/bytom/bytom/common"
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/vm/vmutil"
+)
+
+// GenesisFile is the name of the genesis spec file of the custom network in the root dir
+const GenesisFile = "genesis.json"
+
+const (
+	defaultGenesisPort                = "46659"
+	defaultGenesisMaxTimeOffsetMs     = uint64(3000)
+	defaultGenesisVotePendingBlockNum = uint64(10)
+	genesisCoinbaseArbitrary          = "Custom network genesis block"
+)
+
+var errBadGenesisSpec = errors.New("invalid genesis spec")
+
+// GenesisAllocation is the BTM allocated to the address or control program in the genesis block
+type GenesisAllocation struct {
+	Address        string             `json:"address,omitempty"`
+	ControlProgram chainjson.HexBytes `json:"control_program,omitempty"`
+	Amount         uint64             `json:"amount"`
+}
+
+// GenesisSpec is the definition of the custom network, all the deployments of the network
+// are active from the genesis block
+type GenesisSpec struct {
+	ChainID             string               `json:"chain_id"`
+	Bech32HRPSegwit     string               `json:"bech32_hrp"`
+	DefaultPort         string               `json:"default_port,omitempty"`
+	Seeds               string               `json:"seeds,omitempty"`
+	Timestamp           uint64               `json:"timestamp"`
+	BlockTimeInterval   uint64               `json:"block_time_interval"`
+	MaxTimeOffsetMs     uint64               `json:"max_time_offset_ms,omitempty"`
+	BlocksOfEpoch       uint64               `json:"blocks_of_epoch"`
+	MinValidatorVoteNum uint64               `json:"min_validator_vote_num"`
+	VotePendingBlockNum uint64               `json:"vote_pending_block_num,omitempty"`
+	FederationXpubs     []chainkd.XPub       `json:"federation_xpubs"`
+	Allocations         []*GenesisAllocation `json:"allocations"`
+}
+
+// LoadGenesisSpec read and check the genesis spec file
+func LoadGenesisSpec(filePath string) (*GenesisSpec, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &GenesisSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+
+	if spec.DefaultPort == "" {
+		spec.DefaultPort = defaultGenesisPort
+	}
+	if spec.MaxTimeOffsetMs == 0 {
+		spec.MaxTimeOffsetMs = defaultGenesisMaxTimeOffsetMs
+	}
+	if spec.VotePendingBlockNum == 0 {
+		spec.VotePendingBlockNum = defaultGenesisVotePendingBlockNum
+	}
+	return spec, spec.validate()
+}
+
+func (s *GenesisSpec) validate() error {
+	if _, ok := consensus.NetParams[s.ChainID]; ok || s.ChainID == "" {
+		return fmt.Errorf("%v: chain id %q is empty or used by the builtin network", errBadGenesisSpec, s.ChainID)
+	}
+
+	switch {
+	case s.Bech32HRPSegwit == "":
+		return fmt.Errorf("%v: bech32 hrp is empty", errBadGenesisSpec)
+	case s.BlockTimeInterval == 0 || s.BlocksOfEpoch == 0:
+		return fmt.Errorf("%v: block time interval and blocks of epoch must be positive", errBadGenesisSpec)
+	case len(s.FederationXpubs) == 0 || len(s.FederationXpubs) > consensus.MaxNumOfValidators:
+		return fmt.Errorf("%v: the number of federation xpubs must be in [1, %d]", errBadGenesisSpec, consensus.MaxNumOfValidators)
+	case len(s.Allocations) == 0:
+		return fmt.Errorf("%v: no allocation", errBadGenesisSpec)
+	}
+
+	params := s.Params()
+	for i, allocation := range s.Allocations {
+		if allocation.Amount == 0 {
+			return fmt.Errorf("%v: amount of allocation %d is zero", errBadGenesisSpec, i)
+		}
+
+		if _, err := allocation.program(&params); err != nil {
+			return fmt.Errorf("%v: allocation %d: %v", errBadGenesisSpec, i, err)
+		}
+	}
+	return nil
+}
+
+func (a *GenesisAllocation) program(params *consensus.Params) ([]byte, error) {
+	if a.Address == "" {
+		if len(a.ControlProgram) == 0 {
+			return nil, errors.New("neither address nor control program is specified")
+		}
+		return a.ControlProgram, nil
+	}
+
+	address, err := common.DecodeAddress(a.Address, params)
+	if err != nil {
+		return nil, err
+	}
+
+	switch address.(type) {
+	case *common.AddressWitnessPubKeyHash:
+		return vmutil.P2WPKHProgram(address.ScriptAddress())
+	case *common.AddressWitnessScriptHash:
+		return vmutil.P2WSHProgram(address.ScriptAddress())
+	default:
+		return nil, errors.New("unsupport address type")
+	}
+}
+
+// Params return the consensus params of the custom network, the name of the network is the chain id
+func (s *GenesisSpec) Params() consensus.Params {
+	var deployments []consensus.Deployment
+	for _, deployment := range consensus.SoloNetParams.Deployments {
+		deployments = append(deployments, consensus.Deployment{
+			Name:          deployment.Name,
+			Bit:           deployment.Bit,
+			StartHeight:   math.MaxUint64,
+			TimeoutHeight: math.MaxUint64,
+		})
+	}
+
+	return consensus.Params{
+		Name:            s.ChainID,
+		Bech32HRPSegwit: s.Bech32HRPSegwit,
+		DefaultPort:     s.DefaultPort,
+		DNSSeeds:        []string{},
+		CasperConfig: consensus.CasperConfig{
+			BlockTimeInterval:   s.BlockTimeInterval,
+			MaxTimeOffsetMs:     s.MaxTimeOffsetMs,
+			BlocksOfEpoch:       s.BlocksOfEpoch,
+			MinValidatorVoteNum: s.MinValidatorVoteNum,
+			VotePendingBlockNum: s.VotePendingBlockNum,
+			FederationXpubs:     s.FederationXpubs,
+		},
+		Deployments: deployments,
+	}
+}
+
+// GenesisBlock build the genesis block which allocates the BTM by the spec
+func (s *GenesisSpec) GenesisBlock() (*types.Block, error) {
+	params := s.Params()
+	var outputs []*types.TxOutput
+	for _, allocation := range s.Allocations {
+		program, err := allocation.program(&params)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, types.NewOriginalTxOutput(*consensus.BTMAssetID, allocation.Amount, program, nil))
+	}
+
+	txs := []*types.Tx{types.NewTx(types.TxData{
+		Version: 1,
+		Inputs:  []*types.TxInput{types.NewCoinbaseInput([]byte(genesisCoinbaseArbitrary))},
+		Outputs: outputs,
+	})}
+
+	merkleRoot, err := types.TxMerkleRoot(toBCTxs(txs))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Block{
+		BlockHeader: types.BlockHeader{
+			Version:   1,
+			Height:    0,
+			Timestamp: s.Timestamp,
+			BlockCommitment: types.BlockCommitment{
+				TransactionsMerkleRoot: merkleRoot,
+			},
+		},
+		Transactions: txs,
+	}, nil
+}
+
+// Register add the custom network to the consensus params and the genesis blocks
+func (s *GenesisSpec) Register() error {
+	block, err := s.GenesisBlock()
+	if err != nil {
+		return err
+	}
+
+	consensus.NetParams[s.ChainID] = s.Params()
+	genesisBlocks[s.ChainID] = func() *types.Block { return block }
+	return nil
+}
+
+// RegisterGenesisNetwork register the custom network if the genesis spec file of the
+// chain id is in the root dir, it does nothing for the builtin networks
+func RegisterGenesisNetwork(rootDir, chainID string) error {
+	if _, ok := consensus.NetParams[chainID]; ok {
+		return nil
+	}
+
+	filePath := path.Join(rootDir, GenesisFile)
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	spec, err := LoadGenesisSpec(filePath)
+	if err != nil {
+		return err
+	}
+
+	if spec.ChainID != chainID {
+		return fmt.Errorf("%v: chain id %q of the genesis file mismatches %q", errBadGenesisSpec, spec.ChainID, chainID)
+	}
+	return spec.Register()
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytom/bytom/common"
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+)
+
+func TestGenesisSpec(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "genesis-test")
+	require.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	xPrv, err := chainkd.NewXPrv(nil)
+	require.Nil(err)
+
+	spec := &GenesisSpec{
+		ChainID:             "devnet",
+		Bech32HRPSegwit:     "dn",
+		Timestamp:           1600000000000,
+		BlockTimeInterval:   1000,
+		BlocksOfEpoch:       10,
+		MinValidatorVoteNum: 1e8,
+		FederationXpubs:     []chainkd.XPub{xPrv.XPub()},
+	}
+	params := spec.Params()
+	address, err := common.NewAddressWitnessPubKeyHash(bytes.Repeat([]byte{1}, 20), &params)
+	require.Nil(err)
+
+	spec.Allocations = []*GenesisAllocation{
+		{Address: address.EncodeAddress(), Amount: 100},
+		{ControlProgram: []byte{0x51}, Amount: 200},
+	}
+	require.Nil(EnsureGenesisRoot(tmpDir, spec))
+	ensureFiles(t, tmpDir, "config.toml", GenesisFile, "data")
+
+	defer delete(consensus.NetParams, spec.ChainID)
+	defer delete(genesisBlocks, spec.ChainID)
+	require.Nil(RegisterGenesisNetwork(tmpDir, spec.ChainID))
+
+	registered, ok := consensus.NetParams[spec.ChainID]
+	require.True(ok)
+	assert.Equal(uint64(10), registered.BlocksOfEpoch)
+	assert.True(registered.IsActive(consensus.DeploymentSlashing, 0))
+
+	block := genesisBlocks[spec.ChainID]()
+	outputs := block.Transactions[0].Outputs
+	require.Equal(2, len(outputs))
+	assert.Equal(uint64(100), outputs[0].Amount)
+	assert.Equal([]byte{0x51}, outputs[1].ControlProgram)
+	assert.Equal(spec.Timestamp, block.Timestamp)
+
+	// the spec can't redefine the registered network
+	_, err = LoadGenesisSpec(filepath.Join(tmpDir, GenesisFile))
+	assert.NotNil(err)
+
+	spec.ChainID, spec.Allocations = "devnet2", []*GenesisAllocation{{Address: "bn1invalid", Amount: 100}}
+	assert.NotNil(spec.validate())
+}
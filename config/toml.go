@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"path"
 
 	cmn "github.com/tendermint/tmlibs/common"
@@ -45,6 +47,32 @@ laddr = "tcp://0.0.0.0:46658"
 seeds = ""
 `
 
+var genesisNetConfigTmpl = `chain_id = "%s"
+[p2p]
+laddr = "tcp://0.0.0.0:%s"
+seeds = "%s"
+`
+
+// EnsureGenesisRoot create the root dir of the custom network, the genesis spec is
+// saved to the root dir so that the network is registered when the node starts
+func EnsureGenesisRoot(rootDir string, spec *GenesisSpec) error {
+	cmn.EnsureDir(rootDir, 0700)
+	cmn.EnsureDir(rootDir+"/data", 0700)
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	cmn.MustWriteFile(path.Join(rootDir, GenesisFile), data, 0644)
+	configFilePath := path.Join(rootDir, "config.toml")
+	if !cmn.FileExists(configFilePath) {
+		config := defaultConfigTmpl + fmt.Sprintf(genesisNetConfigTmpl, spec.ChainID, spec.DefaultPort, spec.Seeds)
+		cmn.MustWriteFile(configFilePath, []byte(config), 0644)
+	}
+	return nil
+}
+
 // Select network seeds to merge a new string.
 func selectNetwork(network string) string {
 	switch network {
@@ -0,0 +1,104 @@
+package consensus
+
+import (
+	"math"
+)
+
+// the names of the rule changes deployed by the soft forks, the block height restriction
+// of the asset issuance program is not one of them, it's the condition chosen by the
+// issuer and checked by the program itself like any other control program
+const (
+	DeploymentSlashing   = "slashing"
+	DeploymentGovernance = "governance"
+
+	// DeploymentRelativeTimelock activates the INPUTHEIGHT opcode, it must activate after the
+	// nodes record the heights of the utxos spent by the blocks, since the outputs spent by
+	// the blocks saved before can't be restored with their heights
+	DeploymentRelativeTimelock = "relative_timelock"
+
+	// DeploymentSigFromStack activates the CHECKSIGFROMSTACK opcode
+	DeploymentSigFromStack = "sig_from_stack"
+
+	// DeploymentIntrospection activates the opcodes reading the outputs and the state data
+	DeploymentIntrospection = "introspection"
+
+	// DeploymentVoteLock changes the locked block number of the vote utxo from the
+	// LegacyVotePendingBlockNum to the VotePendingBlockNum of the network
+	DeploymentVoteLock = "vote_lock"
+)
+
+// the layout of the block header version, the low byte is the base version and the
+// higher bits are used by the validators to signal the deployments
+const (
+	BaseBlockVersion = uint64(1)
+	MaxVersionBits   = uint8(32)
+
+	baseVersionMask  = uint64(0xff)
+	versionBitsShift = 8
+)
+
+// Deployment is a consensus rule change, it activates at the ActivationHeight, or one epoch
+// after the epoch in [StartHeight, TimeoutHeight) in which more than 2/3 blocks signal the Bit
+type Deployment struct {
+	Name          string
+	Bit           uint8
+	StartHeight   uint64
+	TimeoutHeight uint64
+
+	// ActivationHeight is the height the deployment activates regardless of the signals
+	ActivationHeight uint64
+}
+
+// InSignalWindow return whether the blocks at the height can signal the deployment
+func (d *Deployment) InSignalWindow(height uint64) bool {
+	return height >= d.StartHeight && height < d.TimeoutHeight
+}
+
+// noDeployment is the deployment never activated unless it's changed for the network
+func noDeployment(name string, bit uint8) Deployment {
+	return Deployment{Name: name, Bit: bit, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: math.MaxUint64}
+}
+
+// Deployment return the deployment by name, it's nil if the network doesn't define it
+func (p *Params) Deployment(name string) *Deployment {
+	for i := range p.Deployments {
+		if p.Deployments[i].Name == name {
+			return &p.Deployments[i]
+		}
+	}
+	return nil
+}
+
+// IsActive return whether the deployment activates at the height regardless of the signals,
+// the deployment activated by the signals is checked by the checkpoint state
+func (p *Params) IsActive(name string, height uint64) bool {
+	deployment := p.Deployment(name)
+	return deployment != nil && height >= deployment.ActivationHeight
+}
+
+// BlockVersion return the version of the block at the height, which signals all the
+// deployments in their signal windows
+func (p *Params) BlockVersion(height uint64) uint64 {
+	version := BaseBlockVersion
+	for _, deployment := range p.Deployments {
+		if deployment.InSignalWindow(height) {
+			version |= 1 << (versionBitsShift + uint(deployment.Bit))
+		}
+	}
+	return version
+}
+
+// BaseVersion return the base version of the block header version
+func BaseVersion(version uint64) uint64 {
+	return version & baseVersionMask
+}
+
+// VersionBits return the signal bits of the block header version
+func VersionBits(version uint64) uint64 {
+	return version >> versionBitsShift
+}
+
+// SignalsBit return whether the block header version signals the bit
+func SignalsBit(version uint64, bit uint8) bool {
+	return VersionBits(version)&(1<<uint(bit)) != 0
+}
@@ -22,6 +22,7 @@ const (
 
 	// These configs need add to casper config in elegant way
 	MaxNumOfValidators = int(10)
+	MaxSlashEvidences  = int(10)
 	InitBTMSupply      = 169290721678579170 + 50000000000
 	RewardThreshold    = 0.5
 	BlockReward        = uint64(570776255)
@@ -38,7 +39,6 @@ const (
 	BCRPRequiredBTMAmount = uint64(100000000)
 
 	BTMAlias = "BTM"
-	defaultVotePendingNum = 302400
 )
 
 type CasperConfig struct {
@@ -54,16 +54,13 @@ type CasperConfig struct {
 	// MinValidatorVoteNum is the minimum vote number of become validator
 	MinValidatorVoteNum uint64
 
-	// VotePendingBlockNumber is the locked block number of vote utxo
-	VotePendingBlockNums []VotePendingBlockNum
+	// VotePendingBlockNum is the locked block number of vote utxo
+	VotePendingBlockNum uint64
 
-	FederationXpubs []chainkd.XPub
-}
+	// LegacyVotePendingBlockNum is the locked block number of vote utxo before the vote lock deployment activates
+	LegacyVotePendingBlockNum uint64
 
-type VotePendingBlockNum struct {
-	BeginBlock uint64
-	EndBlock   uint64
-	Num        uint64
+	FederationXpubs []chainkd.XPub
 }
 
 // BTMAssetID is BTM's asset id, the soul asset of Bytom
@@ -104,6 +101,11 @@ type Params struct {
 
 	// CasperConfig defines the casper consensus parameters
 	CasperConfig
+
+	// Deployments defines the consensus rule changes and their activation, the blocks
+	// can include the slash evidences since the slashing deployment, and include the
+	// consensus parameter proposals since the governance deployment
+	Deployments []Deployment
 }
 
 // ActiveNetParams is ...
@@ -123,14 +125,12 @@ var MainNetParams = Params{
 	DefaultPort:     "46657",
 	DNSSeeds:        []string{},
 	CasperConfig: CasperConfig{
-		BlockTimeInterval:      6000,
-		MaxTimeOffsetMs:        3000,
-		BlocksOfEpoch:          100,
-		MinValidatorVoteNum:    1e14,
-		VotePendingBlockNums:   []VotePendingBlockNum{
-			{BeginBlock: 0, EndBlock: 432000, Num: 14400},
-			{BeginBlock: 432000, EndBlock: math.MaxUint64, Num: defaultVotePendingNum},
-		},
+		BlockTimeInterval:         6000,
+		MaxTimeOffsetMs:           3000,
+		BlocksOfEpoch:             100,
+		MinValidatorVoteNum:       1e14,
+		VotePendingBlockNum:       302400,
+		LegacyVotePendingBlockNum: 14400,
 		FederationXpubs: []chainkd.XPub{
 			xpub("f9003633ccbd8cc37e034f4dbe70d9fae980d437948d8cb908d0cab7909780d74a324b4decb5dfcd43fbc6b896ac066b7e02c733a1537360e933278a101a850c"),
 			xpub("d301fee5d4ba7eb5b9d41ca13ec56c19daceb5f6b752d91d49777fd1fc7c45891e5773cafb3b6d6ab764ef2794e8ba953c8bdb9dc77a3af51e979f96885f96b2"),
@@ -138,6 +138,14 @@ var MainNetParams = Params{
 			xpub("1313379b05c38ff2d171d512f23f199f0f068a67d77b9d5b6db040f2da1edc0c35c68a21b068956f448fed6441b9c27294f1ca6aaedc2c580de322f3f0260c1f"),
 		},
 	},
+	Deployments: []Deployment{
+		noDeployment(DeploymentSlashing, 0),
+		noDeployment(DeploymentGovernance, 1),
+		noDeployment(DeploymentRelativeTimelock, 2),
+		noDeployment(DeploymentSigFromStack, 3),
+		noDeployment(DeploymentIntrospection, 4),
+		{Name: DeploymentVoteLock, Bit: 5, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 432000},
+	},
 }
 
 // TestNetParams is the config for test-net
@@ -147,11 +155,11 @@ var TestNetParams = Params{
 	DefaultPort:     "46656",
 	DNSSeeds:        []string{},
 	CasperConfig: CasperConfig{
-		BlockTimeInterval:      6000,
-		MaxTimeOffsetMs:        3000,
-		BlocksOfEpoch:          100,
-		MinValidatorVoteNum:    1e8,
-		VotePendingBlockNums:   []VotePendingBlockNum{{BeginBlock: 0, EndBlock: math.MaxUint64, Num: 10}},
+		BlockTimeInterval:   6000,
+		MaxTimeOffsetMs:     3000,
+		BlocksOfEpoch:       100,
+		MinValidatorVoteNum: 1e8,
+		VotePendingBlockNum: 10,
 		FederationXpubs: []chainkd.XPub{
 			xpub("7732fac62320799ff5e4eec1dc4ba7b07dc0e5a647850bf0bc34cb9aca195a05a1118b57d377947d7936156c831c87b700ed945a82cae63aff14905beb39d001"),
 			xpub("08543fef8c3ca27483954f80eee6d461c307b6aa564aafaf235a4bd2740debbc71b14af78715c94cbc1d16fa84da97a3eabc5b21f003ab49882e4af7f9f00bbd"),
@@ -159,6 +167,14 @@ var TestNetParams = Params{
 			xpub("b0584ecaefc02d3c367f280e128ec310c9f9198d44cd76b6726cd6c06c002770a1a7dc069ddd06f7a821a176931573d40e63b015ce88b6de01a61205d719567f"),
 		},
 	},
+	Deployments: []Deployment{
+		noDeployment(DeploymentSlashing, 0),
+		noDeployment(DeploymentGovernance, 1),
+		noDeployment(DeploymentRelativeTimelock, 2),
+		noDeployment(DeploymentSigFromStack, 3),
+		noDeployment(DeploymentIntrospection, 4),
+		{Name: DeploymentVoteLock, Bit: 5, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
+	},
 }
 
 // SoloNetParams is the config for test-net
@@ -166,22 +182,30 @@ var SoloNetParams = Params{
 	Name:            "solo",
 	Bech32HRPSegwit: "sn",
 	CasperConfig: CasperConfig{
-		BlockTimeInterval:      6000,
-		MaxTimeOffsetMs:        24000,
-		BlocksOfEpoch:          100,
-		MinValidatorVoteNum:    1e8,
-		VotePendingBlockNums:   []VotePendingBlockNum{{BeginBlock: 0, EndBlock: math.MaxUint64, Num: 10}},
-		FederationXpubs:        []chainkd.XPub{},
+		BlockTimeInterval:   6000,
+		MaxTimeOffsetMs:     24000,
+		BlocksOfEpoch:       100,
+		MinValidatorVoteNum: 1e8,
+		VotePendingBlockNum: 10,
+		FederationXpubs:     []chainkd.XPub{},
+	},
+	Deployments: []Deployment{
+		{Name: DeploymentSlashing, Bit: 0, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
+		{Name: DeploymentGovernance, Bit: 1, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
+		{Name: DeploymentRelativeTimelock, Bit: 2, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
+		{Name: DeploymentSigFromStack, Bit: 3, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
+		{Name: DeploymentIntrospection, Bit: 4, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
+		{Name: DeploymentVoteLock, Bit: 5, StartHeight: math.MaxUint64, TimeoutHeight: math.MaxUint64, ActivationHeight: 0},
 	},
 }
 
+// VotePendingBlockNums return the locked block number of vote utxo at the height, the vote
+// lock deployment is checked by height only since it's the default of the governable param
 func VotePendingBlockNums(height uint64) uint64 {
-	for _, pendingNum := range ActiveNetParams.VotePendingBlockNums {
-		if height >= pendingNum.BeginBlock && height < pendingNum.EndBlock {
-			return pendingNum.Num
-		}
+	if ActiveNetParams.IsActive(DeploymentVoteLock, height) {
+		return ActiveNetParams.VotePendingBlockNum
 	}
-	return defaultVotePendingNum
+	return ActiveNetParams.LegacyVotePendingBlockNum
 }
 
 // InitActiveNetParams load the config by chain ID
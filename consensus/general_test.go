@@ -0,0 +1,31 @@
+package consensus
+
+import (
+	"testing"
+)
+
+func TestVotePendingBlockNums(t *testing.T) {
+	defer func(params Params) { ActiveNetParams = params }(ActiveNetParams)
+	ActiveNetParams = MainNetParams
+
+	cases := []struct {
+		height uint64
+		want   uint64
+	}{
+		{height: 0, want: 14400},
+		{height: 431999, want: 14400},
+		{height: 432000, want: 302400},
+		{height: 1000000, want: 302400},
+	}
+
+	for i, c := range cases {
+		if got := VotePendingBlockNums(c.height); got != c.want {
+			t.Errorf("case %d: got vote pending block num %d want %d", i, got, c.want)
+		}
+	}
+
+	ActiveNetParams = SoloNetParams
+	if got := VotePendingBlockNums(0); got != 10 {
+		t.Errorf("got vote pending block num %d of solonet want 10", got)
+	}
+}
@@ -0,0 +1,69 @@
+package consensus
+
+import (
+	"fmt"
+)
+
+// the consensus parameters which can be changed by the validators on chain, the change of
+// the blocks of epoch takes effect from the epoch boundary it activates at, and the vote
+// pending block num is read from the checkpoint by the block spending the vote utxo
+const (
+	ParamMaxBlockGas         = "max_block_gas"
+	ParamMinValidatorVoteNum = "min_validator_vote_num"
+	ParamMaxNumOfValidators  = "max_num_of_validators"
+	ParamBlocksOfEpoch       = "blocks_of_epoch"
+	ParamVotePendingBlockNum = "vote_pending_block_num"
+)
+
+var governableParams = []string{ParamMaxBlockGas, ParamMinValidatorVoteNum, ParamMaxNumOfValidators, ParamBlocksOfEpoch, ParamVotePendingBlockNum}
+
+// MaxParamProposals is the max number of the parameter proposals one block can include
+const MaxParamProposals = int(10)
+
+// DefaultParam return the hardcoded value of the governable consensus parameter at the height
+func DefaultParam(key string, height uint64) (uint64, error) {
+	switch key {
+	case ParamMaxBlockGas:
+		return MaxBlockGas, nil
+	case ParamMinValidatorVoteNum:
+		return ActiveNetParams.MinValidatorVoteNum, nil
+	case ParamMaxNumOfValidators:
+		return uint64(MaxNumOfValidators), nil
+	case ParamBlocksOfEpoch:
+		return ActiveNetParams.BlocksOfEpoch, nil
+	case ParamVotePendingBlockNum:
+		return VotePendingBlockNums(height), nil
+	default:
+		return 0, fmt.Errorf("consensus parameter %s is not governable", key)
+	}
+}
+
+// DefaultParams return all the governable consensus parameters with the hardcoded value at the height
+func DefaultParams(height uint64) map[string]uint64 {
+	params := map[string]uint64{}
+	for _, key := range governableParams {
+		params[key], _ = DefaultParam(key, height)
+	}
+	return params
+}
+
+// ValidateParam check the parameter is governable and the value is in the valid range,
+// the number of validators can't exceed MaxNumOfValidators since the sup link reserves
+// the signature slots by it
+func ValidateParam(key string, value uint64) error {
+	if _, err := DefaultParam(key, 0); err != nil {
+		return err
+	}
+
+	switch {
+	case key == ParamMaxBlockGas && value < uint64(MaxGasAmount):
+		return fmt.Errorf("max block gas %d is less than the max gas of one transaction", value)
+	case key == ParamMinValidatorVoteNum && value < MinVoteOutputAmount:
+		return fmt.Errorf("min validator vote num %d is less than the min vote output amount", value)
+	case key == ParamMaxNumOfValidators && (value == 0 || value > uint64(MaxNumOfValidators)):
+		return fmt.Errorf("max num of validators %d is out of range [1, %d]", value, MaxNumOfValidators)
+	case key == ParamBlocksOfEpoch && value == 0:
+		return fmt.Errorf("blocks of epoch can't be zero")
+	}
+	return nil
+}
@@ -167,7 +167,7 @@ func (db *MemDB) IteratorPrefixWithStart(Prefix, start []byte, isReverse bool) I
 	db.mtx.Lock()
 	defer db.mtx.Unlock()
 
-	keys := db.getSortedKeys(start, isReverse)
+	keys := db.getSortedKeys(Prefix, start, isReverse)
 	return newMemDBIteratorWithArgs(db, keys, start)
 }
 
@@ -175,10 +175,10 @@ func (db *MemDB) NewBatch() Batch {
 	return &memDBBatch{db, nil}
 }
 
-func (db *MemDB) getSortedKeys(start []byte, reverse bool) []string {
+func (db *MemDB) getSortedKeys(prefix, start []byte, reverse bool) []string {
 	keys := []string{}
 	for key := range db.db {
-		if bytes.Compare([]byte(key), start) < 0 {
+		if !strings.HasPrefix(key, string(prefix)) || bytes.Compare([]byte(key), start) < 0 {
 			continue
 		}
 		keys = append(keys, key)
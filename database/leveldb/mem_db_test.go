@@ -28,6 +28,21 @@ func TestMemDbIterator(t *testing.T) {
 	assert.Equal(t, i, len(db.db), "iterator didnt cover whole db")
 }
 
+func TestMemDBIteratorPrefixWithStart(t *testing.T) {
+	db := NewMemDB()
+	for _, key := range []string{"a1", "a2", "a3", "b1", "b2"} {
+		db.Set([]byte(key), []byte(key))
+	}
+
+	var keys []string
+	iter := db.IteratorPrefixWithStart([]byte("a"), []byte("a2"), false)
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	assert.NotContains(t, keys, "b1", "iterator should not cover the keys out of the prefix")
+	assert.NotContains(t, keys, "b2", "iterator should not cover the keys out of the prefix")
+}
+
 func TestMemDBClose(t *testing.T) {
 	db := NewMemDB()
 	copyDB := func(orig map[string][]byte) map[string][]byte {
@@ -37,8 +37,10 @@ func loadBlockStoreStateJSON(db dbm.DB) *state.BlockStoreState {
 // It satisfies the interface protocol.Store, and provides additional
 // methods for querying current data.
 type Store struct {
-	db    dbm.DB
-	cache cache
+	db           dbm.DB
+	cache        cache
+	addressIndex bool
+	spendIndex   bool
 }
 
 // NewStore creates and returns a new Store object.
@@ -190,12 +192,29 @@ func (s *Store) GetStoreStatus() *state.BlockStoreState {
 }
 
 // SaveChainStatus save the core's newest status && delete old status
-func (s *Store) SaveChainStatus(blockHeader *types.BlockHeader, mainBlockHeaders []*types.BlockHeader, view *state.UtxoViewpoint, contractView *state.ContractViewpoint, finalizedHeight uint64, finalizedHash *bc.Hash) error {
+func (s *Store) SaveChainStatus(blockHeader *types.BlockHeader, mainBlockHeaders []*types.BlockHeader, view *state.UtxoViewpoint, contractView *state.ContractViewpoint, indexView *state.IndexViewpoint, finalizedHeight uint64, finalizedHash *bc.Hash) error {
 	batch := s.db.NewBatch()
 	if err := saveUtxoView(batch, view); err != nil {
 		return err
 	}
 
+	s.saveIndexStart(batch, txIndexName, indexView)
+	saveTxIndex(batch, indexView)
+	saveSpentUtxos(batch, view, indexView)
+	if s.addressIndex {
+		s.saveIndexStart(batch, addressIndexName, indexView)
+		if err := saveAddressIndex(batch, indexView); err != nil {
+			return err
+		}
+	}
+
+	if s.spendIndex {
+		s.saveIndexStart(batch, spendIndexName, indexView)
+		if err := saveSpendIndex(batch, indexView); err != nil {
+			return err
+		}
+	}
+
 	if err := deleteContractView(s.db, batch, contractView); err != nil {
 		return err
 	}
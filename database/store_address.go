@@ -0,0 +1,284 @@
+package database
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/bytom/bytom/crypto/sha3pool"
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// addressTxCursorLen is the byte length of the height and position suffix of the address tx key
+const addressTxCursorLen = 12
+
+// ErrBadAddressCursor is returned when the address history cursor can't be decoded
+var ErrBadAddressCursor = errors.New("invalid address history cursor")
+
+func calcProgramHash(program []byte) []byte {
+	var hash [32]byte
+	sha3pool.Sum256(hash[:], program)
+	return hash[:]
+}
+
+func calcAddressTxPrefix(program []byte) []byte {
+	return append(append([]byte{}, addressTxKeyPrefix...), calcProgramHash(program)...)
+}
+
+// calcAddressTxKey make up address tx key with prefix + sha3(program) + height + tx position
+func calcAddressTxKey(program []byte, height uint64, position uint32) []byte {
+	buf := [addressTxCursorLen]byte{}
+	binary.BigEndian.PutUint64(buf[:8], height)
+	binary.BigEndian.PutUint32(buf[8:], position)
+	return append(calcAddressTxPrefix(program), buf[:]...)
+}
+
+func calcAddressUtxoPrefix(program []byte, assetID *bc.AssetID) []byte {
+	prefix := append(append([]byte{}, addressUtxoKeyPrefix...), calcProgramHash(program)...)
+	if assetID != nil {
+		prefix = append(prefix, assetID.Bytes()...)
+	}
+	return prefix
+}
+
+// calcAddressUtxoKey make up address utxo key with prefix + sha3(program) + asset id + output id
+func calcAddressUtxoKey(utxo *state.AddressUtxo) []byte {
+	return append(calcAddressUtxoPrefix(utxo.ControlProgram, &utxo.AssetID), utxo.OutputID.Bytes()...)
+}
+
+// txPrograms return the control programs of the spent outputs and the new outputs of the tx
+func txPrograms(tx *types.Tx) ([][]byte, error) {
+	programs, exist := [][]byte{}, map[string]bool{}
+	appendProgram := func(program []byte) {
+		if len(program) == 0 || exist[string(program)] {
+			return
+		}
+
+		exist[string(program)] = true
+		programs = append(programs, program)
+	}
+
+	inputUtxos, err := txInputUtxos(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, utxo := range append(inputUtxos, txOutputUtxos(tx)...) {
+		appendProgram(utxo.ControlProgram)
+	}
+	return programs, nil
+}
+
+// txInputUtxos return the outputs spent by the spend and veto inputs of the tx
+func txInputUtxos(tx *types.Tx) ([]*state.AddressUtxo, error) {
+	utxos := []*state.AddressUtxo{}
+	for _, input := range tx.Inputs {
+		var sc *types.SpendCommitment
+		var vote []byte
+		switch inp := input.TypedInput.(type) {
+		case *types.SpendInput:
+			sc = &inp.SpendCommitment
+		case *types.VetoInput:
+			sc, vote = &inp.SpendCommitment, inp.Vote
+		default:
+			continue
+		}
+
+		outputID, err := input.SpentOutputID()
+		if err != nil {
+			return nil, err
+		}
+
+		utxos = append(utxos, &state.AddressUtxo{
+			OutputID:       outputID,
+			AssetID:        *sc.AssetId,
+			Amount:         sc.Amount,
+			ControlProgram: sc.ControlProgram,
+			SourceID:       sc.SourceID,
+			SourcePos:      sc.SourcePosition,
+			Vote:           vote,
+		})
+	}
+	return utxos, nil
+}
+
+// txOutputUtxos return the spendable outputs created by the tx, retirements are excluded
+func txOutputUtxos(tx *types.Tx) []*state.AddressUtxo {
+	utxos := []*state.AddressUtxo{}
+	for i, output := range tx.Outputs {
+		utxo := &state.AddressUtxo{
+			OutputID:       *tx.ResultIds[i],
+			AssetID:        *output.AssetId,
+			Amount:         output.Amount,
+			ControlProgram: output.ControlProgram,
+		}
+
+		switch entry := tx.Entries[*tx.ResultIds[i]].(type) {
+		case *bc.OriginalOutput:
+			utxo.SourceID, utxo.SourcePos = *entry.Source.Ref, entry.Source.Position
+		case *bc.VoteOutput:
+			utxo.SourceID, utxo.SourcePos, utxo.Vote = *entry.Source.Ref, entry.Source.Position, entry.Vote
+		default:
+			continue
+		}
+
+		utxos = append(utxos, utxo)
+	}
+	return utxos
+}
+
+func saveAddressUtxo(batch dbm.Batch, utxo *state.AddressUtxo) error {
+	data, err := json.Marshal(utxo)
+	if err != nil {
+		return errors.Wrap(err, "marshal address utxo")
+	}
+
+	batch.Set(calcAddressUtxoKey(utxo), data)
+	return nil
+}
+
+func attachAddressIndex(batch dbm.Batch, block *types.Block) error {
+	for i, tx := range block.Transactions {
+		programs, err := txPrograms(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, program := range programs {
+			batch.Set(calcAddressTxKey(program, block.Height, uint32(i)), tx.ID.Bytes())
+		}
+
+		inputUtxos, err := txInputUtxos(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, utxo := range inputUtxos {
+			batch.Delete(calcAddressUtxoKey(utxo))
+		}
+
+		for _, utxo := range txOutputUtxos(tx) {
+			if err := saveAddressUtxo(batch, utxo); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func detachAddressIndex(batch dbm.Batch, block *types.Block) error {
+	for i := len(block.Transactions) - 1; i >= 0; i-- {
+		tx := block.Transactions[i]
+		programs, err := txPrograms(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, program := range programs {
+			batch.Delete(calcAddressTxKey(program, block.Height, uint32(i)))
+		}
+
+		for _, utxo := range txOutputUtxos(tx) {
+			batch.Delete(calcAddressUtxoKey(utxo))
+		}
+
+		inputUtxos, err := txInputUtxos(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, utxo := range inputUtxos {
+			if err := saveAddressUtxo(batch, utxo); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// saveAddressIndex update the address index by the detached blocks first then the attached blocks
+func saveAddressIndex(batch dbm.Batch, view *state.IndexViewpoint) error {
+	for _, block := range view.DetachBlocks {
+		if err := detachAddressIndex(batch, block); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range view.AttachBlocks {
+		if err := attachAddressIndex(batch, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddressIndexEnabled return whether the store maintains the address index
+func (s *Store) AddressIndexEnabled() bool {
+	return s.addressIndex
+}
+
+// EnableAddressIndex make the store index the transactions and utxos by control program,
+// the blocks saved before it's called are not indexed
+func (s *Store) EnableAddressIndex() {
+	s.addressIndex = true
+}
+
+// AddressIndexStart return the height of the first block indexed by control program, the
+// transactions and utxos of the lower blocks are missing in the address index
+func (s *Store) AddressIndexStart() uint64 {
+	return s.indexStart(addressIndexName)
+}
+
+// GetAddressHistory return at most count main chain transactions related to the control program
+// after the cursor in the order of block height, and the cursor of the last returned one
+func (s *Store) GetAddressHistory(program []byte, after string, count int) ([]*state.AddressTx, string, error) {
+	prefix := calcAddressTxPrefix(program)
+	var startKey []byte
+	if after != "" {
+		cursor, err := hex.DecodeString(after)
+		if err != nil || len(cursor) != addressTxCursorLen {
+			return nil, "", errors.WithDetailf(ErrBadAddressCursor, "cursor %q", after)
+		}
+
+		startKey = append(append([]byte{}, prefix...), cursor...)
+	}
+
+	itr := s.db.IteratorPrefixWithStart(prefix, startKey, false)
+	defer itr.Release()
+
+	addressTxs := []*state.AddressTx{}
+	for len(addressTxs) < count && itr.Next() {
+		key := itr.Key()[len(prefix):]
+		var txID [32]byte
+		copy(txID[:], itr.Value())
+		addressTxs = append(addressTxs, &state.AddressTx{
+			TxID:        bc.NewHash(txID),
+			BlockHeight: binary.BigEndian.Uint64(key[:8]),
+			Position:    binary.BigEndian.Uint32(key[8:]),
+		})
+		after = hex.EncodeToString(key)
+	}
+	return addressTxs, after, nil
+}
+
+// GetAddressUtxos return the unspent outputs locked by the control program, all
+// the assets are returned when the asset id is nil
+func (s *Store) GetAddressUtxos(program []byte, assetID *bc.AssetID) ([]*state.AddressUtxo, error) {
+	itr := s.db.IteratorPrefix(calcAddressUtxoPrefix(program, assetID))
+	defer itr.Release()
+
+	utxos := []*state.AddressUtxo{}
+	for itr.Next() {
+		utxo := &state.AddressUtxo{}
+		if err := json.Unmarshal(itr.Value(), utxo); err != nil {
+			return nil, errors.Wrap(err, "unmarshal address utxo")
+		}
+
+		utxos = append(utxos, utxo)
+	}
+	return utxos, nil
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestAddressIndex(t *testing.T) {
+	defer os.RemoveAll("temp")
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	store := NewStore(testDB)
+	store.EnableAddressIndex()
+
+	programA, programB := []byte{0x00, 0x14, 0x0a}, []byte{0x00, 0x14, 0x0b}
+	assetID := bc.AssetID{V0: 1}
+	tx1 := types.NewTx(types.TxData{
+		Inputs:  []*types.TxInput{types.NewSpendInput(nil, bc.Hash{V0: 1}, assetID, 100, 0, []byte{0x51}, nil)},
+		Outputs: []*types.TxOutput{types.NewOriginalTxOutput(assetID, 100, programA, nil)},
+	})
+	source := tx1.Entries[*tx1.ResultIds[0]].(*bc.OriginalOutput).Source
+	tx2 := types.NewTx(types.TxData{
+		Inputs: []*types.TxInput{types.NewSpendInput(nil, *source.Ref, assetID, 100, source.Position, programA, nil)},
+		Outputs: []*types.TxOutput{
+			types.NewOriginalTxOutput(assetID, 60, programB, nil),
+			types.NewOriginalTxOutput(assetID, 40, programA, nil),
+		},
+	})
+	block1 := &types.Block{BlockHeader: types.BlockHeader{Height: 1}, Transactions: []*types.Tx{tx1}}
+	block2 := &types.Block{BlockHeader: types.BlockHeader{Height: 2}, Transactions: []*types.Tx{tx2}}
+
+	saveIndex := func(attachBlocks, detachBlocks []*types.Block, height uint64) {
+		blockHeader := &types.BlockHeader{Height: height}
+		indexView := &state.IndexViewpoint{AttachBlocks: attachBlocks, DetachBlocks: detachBlocks}
+		if err := store.SaveChainStatus(blockHeader, []*types.BlockHeader{blockHeader}, state.NewUtxoViewpoint(), state.NewContractViewpoint(), indexView, 0, &bc.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saveIndex([]*types.Block{block1, block2}, nil, 2)
+	history, after, err := store.GetAddressHistory(programA, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []*state.AddressTx{{TxID: tx1.ID, BlockHeight: 1, Position: 0}}; !testutil.DeepEqual(history, want) {
+		t.Errorf("got history %v, want %v", history, want)
+	}
+
+	if history, _, err = store.GetAddressHistory(programA, after, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []*state.AddressTx{{TxID: tx2.ID, BlockHeight: 2, Position: 0}}; !testutil.DeepEqual(history, want) {
+		t.Errorf("got history %v, want %v", history, want)
+	}
+
+	utxos, err := store.GetAddressUtxos(programA, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(utxos) != 1 || utxos[0].OutputID != *tx2.ResultIds[1] || utxos[0].Amount != 40 {
+		t.Errorf("got utxos %v, want the change output of tx2", utxos)
+	}
+
+	if utxos, err = store.GetAddressUtxos(programB, &assetID); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(utxos) != 1 || utxos[0].OutputID != *tx2.ResultIds[0] || utxos[0].Amount != 60 {
+		t.Errorf("got utxos %v, want the first output of tx2", utxos)
+	}
+
+	saveIndex(nil, []*types.Block{block2}, 1)
+	if history, _, err = store.GetAddressHistory(programB, "", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(history) != 0 {
+		t.Errorf("got history %v after detach, want empty", history)
+	}
+
+	if utxos, err = store.GetAddressUtxos(programA, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(utxos) != 1 || utxos[0].OutputID != *tx1.ResultIds[0] || utxos[0].Amount != 100 {
+		t.Errorf("got utxos %v after detach, want the output of tx1", utxos)
+	}
+}
+
+func TestAddressIndexStart(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	saveIndex := func(height uint64) {
+		block := &types.Block{BlockHeader: types.BlockHeader{Height: height}}
+		indexView := &state.IndexViewpoint{AttachBlocks: []*types.Block{block}}
+		if err := store.SaveChainStatus(&block.BlockHeader, []*types.BlockHeader{&block.BlockHeader}, state.NewUtxoViewpoint(), state.NewContractViewpoint(), indexView, 0, &bc.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saveIndex(1)
+	store.EnableAddressIndex()
+	saveIndex(2)
+	saveIndex(3)
+	if got := store.AddressIndexStart(); got != 2 {
+		t.Errorf("got address index start %d, want 2", got)
+	}
+}
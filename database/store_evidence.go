@@ -0,0 +1,65 @@
+package database
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ErrEvidenceNotFound is returned when the casper evidence is not in the store
+var ErrEvidenceNotFound = errors.New("casper evidence not found")
+
+// calcEvidenceKey make up evidence key with prefix + evidence hash
+func calcEvidenceKey(hash *bc.Hash) []byte {
+	return append(evidenceKeyPrefix, hash.Bytes()...)
+}
+
+// GetEvidence return the casper evidence by given evidence hash
+func (s *Store) GetEvidence(hash *bc.Hash) (*state.Evidence, error) {
+	data := s.db.Get(calcEvidenceKey(hash))
+	if data == nil {
+		return nil, errors.WithDetailf(ErrEvidenceNotFound, "evidence hash %s", hash.String())
+	}
+
+	evidence := &state.Evidence{}
+	if err := json.Unmarshal(data, evidence); err != nil {
+		return nil, errors.Wrap(err, "unmarshal casper evidence")
+	}
+	return evidence, nil
+}
+
+// ListEvidences return all the casper evidences sorted by the target height of the first verification
+func (s *Store) ListEvidences() ([]*state.Evidence, error) {
+	iter := s.db.IteratorPrefix(evidenceKeyPrefix)
+	defer iter.Release()
+
+	evidences := []*state.Evidence{}
+	for iter.Next() {
+		evidence := &state.Evidence{}
+		if err := json.Unmarshal(iter.Value(), evidence); err != nil {
+			return nil, errors.Wrap(err, "unmarshal casper evidence")
+		}
+
+		evidences = append(evidences, evidence)
+	}
+
+	sort.SliceStable(evidences, func(i, j int) bool {
+		return evidences[i].First.TargetHeight < evidences[j].First.TargetHeight
+	})
+	return evidences, nil
+}
+
+// SaveEvidence persists the casper evidence
+func (s *Store) SaveEvidence(evidence *state.Evidence) error {
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		return errors.Wrap(err, "marshal casper evidence")
+	}
+
+	hash := evidence.Hash()
+	s.db.Set(calcEvidenceKey(&hash), data)
+	return nil
+}
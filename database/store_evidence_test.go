@@ -0,0 +1,53 @@
+package database
+
+import (
+	"testing"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestSaveEvidence(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	evidences := []*state.Evidence{
+		{
+			Type:   state.SurroundVoteEvidence,
+			PubKey: "pubkey",
+			First:  state.SignedVerification{SourceHeight: 100, TargetHeight: 200, Signature: []byte{0x01}},
+			Second: state.SignedVerification{SourceHeight: 0, TargetHeight: 300, Signature: []byte{0x02}},
+		},
+		{
+			Type:   state.DoubleVoteEvidence,
+			PubKey: "pubkey",
+			First:  state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 1}, Signature: []byte{0x03}},
+			Second: state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 2}, Signature: []byte{0x04}},
+		},
+	}
+
+	for _, evidence := range evidences {
+		if err := store.SaveEvidence(evidence); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hash := evidences[0].Hash()
+	got, err := store.GetEvidence(&hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !testutil.DeepEqual(got, evidences[0]) {
+		t.Errorf("got evidence %v, want %v", got, evidences[0])
+	}
+
+	list, err := store.ListEvidences()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []*state.Evidence{evidences[1], evidences[0]}; !testutil.DeepEqual(list, want) {
+		t.Errorf("got evidences %v, want %v", list, want)
+	}
+}
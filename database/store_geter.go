@@ -20,6 +20,13 @@ const (
 	checkpoint
 	utxo
 	contract
+	addressTx
+	addressUtxo
+	txIndex
+	spendIndex
+	evidence
+	spentUtxo
+	indexStart
 )
 
 var (
@@ -31,6 +38,13 @@ var (
 	checkpointKeyPrefix     = []byte{checkpoint, colon}
 	UtxoKeyPrefix           = []byte{utxo, colon}
 	ContractPrefix          = []byte{contract, colon}
+	addressTxKeyPrefix      = []byte{addressTx, colon}
+	addressUtxoKeyPrefix    = []byte{addressUtxo, colon}
+	txIndexKeyPrefix        = []byte{txIndex, colon}
+	spendIndexKeyPrefix     = []byte{spendIndex, colon}
+	evidenceKeyPrefix       = []byte{evidence, colon}
+	spentUtxoKeyPrefix      = []byte{spentUtxo, colon}
+	indexStartKeyPrefix     = []byte{indexStart, colon}
 )
 
 func calcMainChainIndexPrefix(height uint64) []byte {
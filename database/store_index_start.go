@@ -0,0 +1,44 @@
+package database
+
+import (
+	"github.com/bytom/bytom/common"
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// the names of the indexes recorded with the heights they start from
+const (
+	txIndexName      = "tx"
+	addressIndexName = "address"
+	spendIndexName   = "spend"
+)
+
+func calcIndexStartKey(name string) []byte {
+	return append(append([]byte{}, indexStartKeyPrefix...), name...)
+}
+
+// saveIndexStart record the height of the first block attached to the index, the blocks
+// saved before the index is maintained by the store are never indexed
+func (s *Store) saveIndexStart(batch dbm.Batch, name string, view *state.IndexViewpoint) {
+	if len(view.AttachBlocks) == 0 || s.db.Get(calcIndexStartKey(name)) != nil {
+		return
+	}
+
+	height := view.AttachBlocks[0].Height
+	for _, block := range view.AttachBlocks {
+		if block.Height < height {
+			height = block.Height
+		}
+	}
+	batch.Set(calcIndexStartKey(name), common.Unit64ToBytes(height))
+}
+
+// indexStart return the height of the first block attached to the index, the blocks lower
+// than it are not indexed
+func (s *Store) indexStart(name string) uint64 {
+	data := s.db.Get(calcIndexStartKey(name))
+	if len(data) != 8 {
+		return 0
+	}
+	return common.BytesToUnit64(data)
+}
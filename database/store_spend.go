@@ -0,0 +1,99 @@
+package database
+
+import (
+	"encoding/binary"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ErrOutputSpenderNotFound is returned when the output is not spent in the main chain
+var ErrOutputSpenderNotFound = errors.New("output is not spent in the main chain")
+
+// calcSpendIndexKey make up spend index key with prefix + spent output id
+func calcSpendIndexKey(outputID *bc.Hash) []byte {
+	return append(spendIndexKeyPrefix, outputID.Bytes()...)
+}
+
+// calcSpendIndex make up the spend index value with tx id + input index + block height
+func calcSpendIndex(txID *bc.Hash, inputIndex uint32, height uint64) []byte {
+	spendIndex := make([]byte, 44)
+	copy(spendIndex[:32], txID.Bytes())
+	binary.BigEndian.PutUint32(spendIndex[32:36], inputIndex)
+	binary.BigEndian.PutUint64(spendIndex[36:], height)
+	return spendIndex
+}
+
+// blockSpends call fn with every output spent by the spend and veto inputs of the block
+func blockSpends(block *types.Block, fn func(outputID *bc.Hash, tx *types.Tx, inputIndex uint32)) error {
+	for _, tx := range block.Transactions {
+		for i, input := range tx.Inputs {
+			if input.InputType() != types.SpendInputType && input.InputType() != types.VetoInputType {
+				continue
+			}
+
+			spentOutputID, err := input.SpentOutputID()
+			if err != nil {
+				return err
+			}
+
+			fn(&spentOutputID, tx, uint32(i))
+		}
+	}
+	return nil
+}
+
+// saveSpendIndex delete the spend index of the detached blocks and then set the attached ones
+func saveSpendIndex(batch dbm.Batch, view *state.IndexViewpoint) error {
+	for _, block := range view.DetachBlocks {
+		if err := blockSpends(block, func(outputID *bc.Hash, tx *types.Tx, inputIndex uint32) {
+			batch.Delete(calcSpendIndexKey(outputID))
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range view.AttachBlocks {
+		height := block.Height
+		if err := blockSpends(block, func(outputID *bc.Hash, tx *types.Tx, inputIndex uint32) {
+			batch.Set(calcSpendIndexKey(outputID), calcSpendIndex(&tx.ID, inputIndex, height))
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SpendIndexEnabled return whether the store maintains the spend index
+func (s *Store) SpendIndexEnabled() bool {
+	return s.spendIndex
+}
+
+// EnableSpendIndex make the store index the spending input of every spent output,
+// the outputs spent by the blocks saved before it's called are not indexed
+func (s *Store) EnableSpendIndex() {
+	s.spendIndex = true
+}
+
+// GetOutputSpender return the main chain transaction input which spends the output
+func (s *Store) GetOutputSpender(outputID *bc.Hash) (*state.OutputSpender, error) {
+	spendIndex := s.db.Get(calcSpendIndexKey(outputID))
+	if len(spendIndex) != 44 {
+		if startHeight := s.indexStart(spendIndexName); startHeight > 0 {
+			return nil, errors.WithDetailf(ErrOutputSpenderNotFound, "output id %s, the outputs spent before height %d are not indexed", outputID.String(), startHeight)
+		}
+		return nil, errors.WithDetailf(ErrOutputSpenderNotFound, "output id %s", outputID.String())
+	}
+
+	var txID [32]byte
+	copy(txID[:], spendIndex[:32])
+	return &state.OutputSpender{
+		OutputID:    *outputID,
+		TxID:        bc.NewHash(txID),
+		InputIndex:  binary.BigEndian.Uint32(spendIndex[32:36]),
+		BlockHeight: binary.BigEndian.Uint64(spendIndex[36:]),
+	}, nil
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestSpendIndex(t *testing.T) {
+	defer os.RemoveAll("temp")
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	store := NewStore(testDB)
+	store.EnableSpendIndex()
+	assetID := bc.AssetID{V0: 1}
+	tx := types.NewTx(types.TxData{
+		Inputs: []*types.TxInput{
+			types.NewSpendInput(nil, bc.Hash{V0: 1}, assetID, 100, 0, []byte{0x51}, nil),
+			types.NewSpendInput(nil, bc.Hash{V0: 2}, assetID, 100, 1, []byte{0x51}, nil),
+		},
+		Outputs: []*types.TxOutput{types.NewOriginalTxOutput(assetID, 200, []byte{0x51}, nil)},
+	})
+	block := &types.Block{BlockHeader: types.BlockHeader{Height: 5}, Transactions: []*types.Tx{tx}}
+
+	saveIndex := func(indexView *state.IndexViewpoint) {
+		blockHeader := &types.BlockHeader{Height: 5}
+		if err := store.SaveChainStatus(blockHeader, []*types.BlockHeader{blockHeader}, state.NewUtxoViewpoint(), state.NewContractViewpoint(), indexView, 0, &bc.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saveIndex(&state.IndexViewpoint{AttachBlocks: []*types.Block{block}})
+	outputID, err := tx.Inputs[1].SpentOutputID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spender, err := store.GetOutputSpender(&outputID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &state.OutputSpender{OutputID: outputID, TxID: tx.ID, InputIndex: 1, BlockHeight: 5}
+	if !testutil.DeepEqual(spender, want) {
+		t.Errorf("got spender %v, want %v", spender, want)
+	}
+
+	saveIndex(&state.IndexViewpoint{DetachBlocks: []*types.Block{block}})
+	_, err = store.GetOutputSpender(&outputID)
+	if errors.Root(err) != ErrOutputSpenderNotFound {
+		t.Fatalf("got error %v after detach, want %v", err, ErrOutputSpenderNotFound)
+	}
+
+	if detail := errors.Detail(err); !strings.Contains(detail, "height 5") {
+		t.Errorf("got error detail %q, want the index start height reported", detail)
+	}
+}
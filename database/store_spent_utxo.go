@@ -0,0 +1,64 @@
+package database
+
+import (
+	"encoding/binary"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/database/storage"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+const spentUtxoSize = 44
+
+// calcSpentUtxoKey make up spent utxo key with prefix + block hash
+func calcSpentUtxoKey(blockHash *bc.Hash) []byte {
+	return append(spentUtxoKeyPrefix, blockHash.Bytes()...)
+}
+
+// saveSpentUtxos record the utxos spent by every attached block with the type and the
+// height they are confirmed at, each of them is encoded as output id + type + height.
+// The utxos are deleted once they are spent, so the records are the only way to restore
+// them with the origin heights when the block is detached
+func saveSpentUtxos(batch dbm.Batch, view *state.UtxoViewpoint, indexView *state.IndexViewpoint) {
+	for _, block := range indexView.AttachBlocks {
+		var spentUtxos []byte
+		for _, tx := range block.Transactions {
+			for _, outputID := range tx.SpentOutputIDs {
+				entry, ok := view.Entries[outputID]
+				if !ok {
+					continue
+				}
+
+				spentUtxo := make([]byte, spentUtxoSize)
+				copy(spentUtxo[:32], outputID.Bytes())
+				binary.BigEndian.PutUint32(spentUtxo[32:36], entry.Type)
+				binary.BigEndian.PutUint64(spentUtxo[36:], entry.BlockHeight)
+				spentUtxos = append(spentUtxos, spentUtxo...)
+			}
+		}
+
+		if len(spentUtxos) > 0 {
+			blockHash := block.Hash()
+			batch.Set(calcSpentUtxoKey(&blockHash), spentUtxos)
+		}
+	}
+}
+
+// GetBlockSpentUtxos load the utxos spent by the main chain block into the view as spent
+// entries with the heights they are confirmed at, the entries already in the view are kept
+func (s *Store) GetBlockSpentUtxos(view *state.UtxoViewpoint, blockHash *bc.Hash) error {
+	spentUtxos := s.db.Get(calcSpentUtxoKey(blockHash))
+	for ; len(spentUtxos) >= spentUtxoSize; spentUtxos = spentUtxos[spentUtxoSize:] {
+		var outputID [32]byte
+		copy(outputID[:], spentUtxos[:32])
+		hash := bc.NewHash(outputID)
+		if view.HasUtxo(&hash) {
+			continue
+		}
+
+		utxoType := binary.BigEndian.Uint32(spentUtxos[32:36])
+		view.Entries[hash] = storage.NewUtxoEntry(utxoType, binary.BigEndian.Uint64(spentUtxos[36:spentUtxoSize]), true)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/database/storage"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestBlockSpentUtxos(t *testing.T) {
+	defer os.RemoveAll("temp")
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	store := NewStore(testDB)
+
+	assetID := bc.AssetID{V0: 1}
+	spendTx := types.NewTx(types.TxData{
+		Inputs: []*types.TxInput{
+			types.NewSpendInput(nil, bc.Hash{V0: 1}, assetID, 100, 0, []byte{0x51}, nil),
+			types.NewSpendInput(nil, bc.Hash{V0: 2}, assetID, 200, 1, []byte{0x51}, nil),
+		},
+		Outputs: []*types.TxOutput{types.NewOriginalTxOutput(assetID, 300, []byte{0x51}, nil)},
+	})
+	block := &types.Block{BlockHeader: types.BlockHeader{Height: 10}, Transactions: []*types.Tx{spendTx}}
+
+	view := state.NewUtxoViewpoint()
+	view.Entries[spendTx.SpentOutputIDs[0]] = storage.NewUtxoEntry(storage.NormalUTXOType, 3, true)
+	view.Entries[spendTx.SpentOutputIDs[1]] = storage.NewUtxoEntry(storage.CoinbaseUTXOType, 5, true)
+	blockHeader := &block.BlockHeader
+	if err := store.SaveChainStatus(blockHeader, []*types.BlockHeader{blockHeader}, view, state.NewContractViewpoint(), &state.IndexViewpoint{AttachBlocks: []*types.Block{block}}, 0, &bc.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.GetUtxo(&spendTx.SpentOutputIDs[0]); err == nil {
+		t.Fatal("the spent utxo should be deleted")
+	}
+
+	gotView := state.NewUtxoViewpoint()
+	blockHash := block.Hash()
+	if err := store.GetBlockSpentUtxos(gotView, &blockHash); err != nil {
+		t.Fatal(err)
+	}
+
+	if !testutil.DeepEqual(gotView.Entries, view.Entries) {
+		t.Errorf("got spent utxos %v, want %v", gotView.Entries, view.Entries)
+	}
+
+	if err := gotView.DetachBlock(types.MapBlock(block)); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry := gotView.Entries[spendTx.SpentOutputIDs[0]]; entry.Spent || entry.BlockHeight != 3 {
+		t.Errorf("got detached utxo %v, want unspent utxo confirmed at height 3", entry)
+	}
+}
@@ -29,7 +29,7 @@ func TestSaveChainStatus(t *testing.T) {
 	}
 
 	contractView := state.NewContractViewpoint()
-	if err := store.SaveChainStatus(blockHeader, []*types.BlockHeader{blockHeader}, view, contractView, 0, &bc.Hash{}); err != nil {
+	if err := store.SaveChainStatus(blockHeader, []*types.BlockHeader{blockHeader}, view, contractView, state.NewIndexViewpoint(), 0, &bc.Hash{}); err != nil {
 		t.Fatal(err)
 	}
 
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"encoding/binary"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ErrTxIndexNotFound is returned when the transaction is not in the main chain tx index
+var ErrTxIndexNotFound = errors.New("transaction not found in the main chain")
+
+// CalcTxIndexKey make up tx index key with prefix + tx id
+func CalcTxIndexKey(txID *bc.Hash) []byte {
+	return append(txIndexKeyPrefix, txID.Bytes()...)
+}
+
+// calcTxIndex make up the tx index value with block hash + tx position
+func calcTxIndex(blockHash *bc.Hash, position uint64) []byte {
+	txIndex := make([]byte, 40)
+	copy(txIndex[:32], blockHash.Bytes())
+	binary.BigEndian.PutUint64(txIndex[32:], position)
+	return txIndex
+}
+
+// saveTxIndex delete the index of the detached block txs and then set the index of
+// the attached ones, so a tx packed by both sides of a reorg points to the new block
+func saveTxIndex(batch dbm.Batch, view *state.IndexViewpoint) {
+	for _, block := range view.DetachBlocks {
+		for _, tx := range block.Transactions {
+			batch.Delete(CalcTxIndexKey(&tx.ID))
+		}
+	}
+
+	for _, block := range view.AttachBlocks {
+		blockHash := block.Hash()
+		for i, tx := range block.Transactions {
+			batch.Set(CalcTxIndexKey(&tx.ID), calcTxIndex(&blockHash, uint64(i)))
+		}
+	}
+}
+
+// GetTransactionIndex return the hash of the main chain block which packs the tx
+// and the position of the tx in the block
+func (s *Store) GetTransactionIndex(txID *bc.Hash) (*bc.Hash, uint64, error) {
+	txIndex := s.db.Get(CalcTxIndexKey(txID))
+	if len(txIndex) != 40 {
+		if startHeight := s.indexStart(txIndexName); startHeight > 0 {
+			return nil, 0, errors.WithDetailf(ErrTxIndexNotFound, "tx id %s, the txs before height %d are not indexed", txID.String(), startHeight)
+		}
+		return nil, 0, errors.WithDetailf(ErrTxIndexNotFound, "tx id %s", txID.String())
+	}
+
+	var hashBytes [32]byte
+	copy(hashBytes[:], txIndex[:32])
+	blockHash := bc.NewHash(hashBytes)
+	return &blockHash, binary.BigEndian.Uint64(txIndex[32:]), nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+func TestTxIndex(t *testing.T) {
+	defer os.RemoveAll("temp")
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	store := NewStore(testDB)
+
+	tx1 := types.NewTx(types.TxData{TimeRange: 1, Inputs: []*types.TxInput{types.NewCoinbaseInput(nil)}})
+	tx2 := types.NewTx(types.TxData{TimeRange: 2, Inputs: []*types.TxInput{types.NewCoinbaseInput(nil)}})
+	tx3 := types.NewTx(types.TxData{TimeRange: 3, Inputs: []*types.TxInput{types.NewCoinbaseInput(nil)}})
+	oldBlock := &types.Block{BlockHeader: types.BlockHeader{Height: 1, Timestamp: 1}, Transactions: []*types.Tx{tx1, tx2}}
+	newBlock := &types.Block{BlockHeader: types.BlockHeader{Height: 1, Timestamp: 2}, Transactions: []*types.Tx{tx3, tx2}}
+
+	saveIndex := func(indexView *state.IndexViewpoint) {
+		blockHeader := &types.BlockHeader{Height: 1}
+		if err := store.SaveChainStatus(blockHeader, []*types.BlockHeader{blockHeader}, state.NewUtxoViewpoint(), state.NewContractViewpoint(), indexView, 0, &bc.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkIndex := func(txID bc.Hash, wantBlock *types.Block, wantPosition uint64) {
+		blockHash, position, err := store.GetTransactionIndex(&txID)
+		if wantBlock == nil {
+			if errors.Root(err) != ErrTxIndexNotFound {
+				t.Errorf("tx %s got error %v, want %v", txID.String(), err, ErrTxIndexNotFound)
+			}
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if wantHash := wantBlock.Hash(); *blockHash != wantHash || position != wantPosition {
+			t.Errorf("tx %s got index %s:%d, want %s:%d", txID.String(), blockHash.String(), position, wantHash.String(), wantPosition)
+		}
+	}
+
+	saveIndex(&state.IndexViewpoint{AttachBlocks: []*types.Block{oldBlock}})
+	checkIndex(tx1.ID, oldBlock, 0)
+	checkIndex(tx2.ID, oldBlock, 1)
+	checkIndex(tx3.ID, nil, 0)
+
+	saveIndex(&state.IndexViewpoint{AttachBlocks: []*types.Block{newBlock}, DetachBlocks: []*types.Block{oldBlock}})
+	checkIndex(tx1.ID, nil, 0)
+	checkIndex(tx2.ID, newBlock, 1)
+	checkIndex(tx3.ID, newBlock, 0)
+}
+
+func TestTxIndexStart(t *testing.T) {
+	defer os.RemoveAll("temp")
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	store := NewStore(testDB)
+
+	tx := types.NewTx(types.TxData{TimeRange: 1, Inputs: []*types.TxInput{types.NewCoinbaseInput(nil)}})
+	saveIndex := func(block *types.Block) {
+		if err := store.SaveChainStatus(&block.BlockHeader, []*types.BlockHeader{&block.BlockHeader}, state.NewUtxoViewpoint(), state.NewContractViewpoint(), &state.IndexViewpoint{AttachBlocks: []*types.Block{block}}, 0, &bc.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saveIndex(&types.Block{BlockHeader: types.BlockHeader{Height: 5}})
+	saveIndex(&types.Block{BlockHeader: types.BlockHeader{Height: 6}})
+	if got := store.indexStart(txIndexName); got != 5 {
+		t.Fatalf("got index start %d, want 5", got)
+	}
+
+	_, _, err := store.GetTransactionIndex(&tx.ID)
+	if errors.Root(err) != ErrTxIndexNotFound {
+		t.Fatalf("got error %v, want %v", err, ErrTxIndexNotFound)
+	}
+
+	if detail := errors.Detail(err); !strings.Contains(detail, "height 5") {
+		t.Errorf("got error detail %q, want the index start height reported", detail)
+	}
+}
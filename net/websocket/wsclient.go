@@ -57,6 +57,10 @@ var wsHandlers = map[string]wsTopicHandler{
 	"notify_new_transactions":      handleNotifyNewTransactions,
 	"stop_notify_raw_blocks":       handleStopNotifyBlocks,
 	"stop_notify_new_transactions": handleStopNotifyNewTransactions,
+	"notify_justified":             handleNotifyJustified,
+	"stop_notify_justified":        handleStopNotifyJustified,
+	"notify_finalized":             handleNotifyFinalized,
+	"stop_notify_finalized":        handleStopNotifyFinalized,
 }
 
 // responseMessage houses a message to send to a connected websocket client as
@@ -370,3 +374,23 @@ func handleNotifyNewTransactions(wsc *WSClient) {
 func handleStopNotifyNewTransactions(wsc *WSClient) {
 	wsc.notificationMgr.UnregisterNewMempoolTxsUpdates(wsc)
 }
+
+// handleNotifyJustified implements the notifyjustified topic extension for websocket connections.
+func handleNotifyJustified(wsc *WSClient) {
+	wsc.notificationMgr.RegisterJustifiedUpdates(wsc)
+}
+
+// handleStopNotifyJustified implements the stopnotifyjustified topic extension for websocket connections.
+func handleStopNotifyJustified(wsc *WSClient) {
+	wsc.notificationMgr.UnregisterJustifiedUpdates(wsc)
+}
+
+// handleNotifyFinalized implements the notifyfinalized topic extension for websocket connections.
+func handleNotifyFinalized(wsc *WSClient) {
+	wsc.notificationMgr.RegisterFinalizedUpdates(wsc)
+}
+
+// handleStopNotifyFinalized implements the stopnotifyfinalized topic extension for websocket connections.
+func handleStopNotifyFinalized(wsc *WSClient) {
+	wsc.notificationMgr.UnregisterFinalizedUpdates(wsc)
+}
@@ -11,12 +11,16 @@ import (
 	"github.com/bytom/bytom/protocol"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/casper"
+	"github.com/bytom/bytom/protocol/state"
 )
 
 // Notification types
 type notificationBlockConnected types.Block
 type notificationBlockDisconnected types.Block
 type notificationTxDescAcceptedByMempool protocol.TxDesc
+type notificationCheckpoint casper.CheckpointEvent
+type notificationRollback protocol.RollbackEvent
 
 // Notification control requests
 type notificationRegisterClient WSClient
@@ -25,6 +29,10 @@ type notificationRegisterBlocks WSClient
 type notificationUnregisterBlocks WSClient
 type notificationRegisterNewMempoolTxs WSClient
 type notificationUnregisterNewMempoolTxs WSClient
+type notificationRegisterJustified WSClient
+type notificationUnregisterJustified WSClient
+type notificationRegisterFinalized WSClient
+type notificationUnregisterFinalized WSClient
 
 // NotificationType represents the type of a notification message.
 type NotificationType int
@@ -37,6 +45,12 @@ const (
 	NTRawBlockDisconnected
 	NTNewTransaction
 	NTRequestStatus
+	// NTCheckpointJustified indicates the associated checkpoint was justified.
+	NTCheckpointJustified
+	// NTCheckpointFinalized indicates the associated checkpoint was finalized.
+	NTCheckpointFinalized
+	// NTChainRollback indicates casper rolled the main chain back to another fork.
+	NTChainRollback
 )
 
 // notificationTypeStrings is a map of notification types back to their constant
@@ -46,6 +60,9 @@ var notificationTypeStrings = map[NotificationType]string{
 	NTRawBlockDisconnected: "raw_blocks_disconnected",
 	NTNewTransaction:       "new_transaction",
 	NTRequestStatus:        "request_status",
+	NTCheckpointJustified:  "checkpoint_justified",
+	NTCheckpointFinalized:  "checkpoint_finalized",
+	NTChainRollback:        "chain_rollback",
 }
 
 // String returns the NotificationType in human-readable form.
@@ -56,6 +73,18 @@ func (n NotificationType) String() string {
 	return fmt.Sprintf("Unknown Notification Type (%d)", int(n))
 }
 
+type checkpointInfo struct {
+	Height uint64  `json:"height"`
+	Hash   bc.Hash `json:"hash"`
+}
+
+type rollbackInfo struct {
+	PrevBestHeight uint64  `json:"prev_best_height"`
+	PrevBestHash   bc.Hash `json:"prev_best_hash"`
+	BestHeight     uint64  `json:"best_height"`
+	BestHash       bc.Hash `json:"best_hash"`
+}
+
 type statusInfo struct {
 	BestHeight uint64
 	BestHash   bc.Hash
@@ -90,6 +119,7 @@ type WSNotificationManager struct {
 	chain                *protocol.Chain
 	eventDispatcher      *event.Dispatcher
 	txMsgSub             *event.Subscription
+	chainEventSub        *event.Subscription
 }
 
 // NewWsNotificationManager returns a new notification manager ready for use. See WSNotificationManager for more details.
@@ -250,12 +280,50 @@ out:
 	m.wg.Done()
 }
 
+// chainEventLoop constantly pass the checkpoint status changes and the main chain
+// rollbacks to the notification manager for finality notification processing.
+func (m *WSNotificationManager) chainEventLoop() {
+out:
+	for {
+		select {
+		case obj, ok := <-m.chainEventSub.Chan():
+			if !ok {
+				log.WithFields(log.Fields{"module": logModule}).Warning("chain event subscription channel closed")
+				break out
+			}
+
+			var n interface{}
+			switch ev := obj.Data.(type) {
+			case casper.CheckpointEvent:
+				n = (*notificationCheckpoint)(&ev)
+			case protocol.RollbackEvent:
+				n = (*notificationRollback)(&ev)
+			default:
+				log.WithFields(log.Fields{"module": logModule}).Error("event type error")
+				continue
+			}
+
+			select {
+			case m.queueNotification <- n:
+			case <-m.quit:
+				break out
+			}
+		case <-m.quit:
+			break out
+		}
+	}
+
+	m.wg.Done()
+}
+
 // notificationHandler reads notifications and control messages from the queue handler and processes one at a time.
 func (m *WSNotificationManager) notificationHandler() {
 	// clients is a map of all currently connected websocket clients.
 	clients := make(map[chan struct{}]*WSClient)
 	blockNotifications := make(map[chan struct{}]*WSClient)
 	txNotifications := make(map[chan struct{}]*WSClient)
+	justifiedNotifications := make(map[chan struct{}]*WSClient)
+	finalizedNotifications := make(map[chan struct{}]*WSClient)
 
 out:
 	for {
@@ -283,6 +351,33 @@ out:
 					m.notifyForNewTx(txNotifications, txDesc)
 				}
 
+			case *notificationCheckpoint:
+				switch n.Status {
+				case state.Justified:
+					m.notifyCheckpoint(justifiedNotifications, NTCheckpointJustified, (*casper.CheckpointEvent)(n))
+				case state.Finalized:
+					m.notifyCheckpoint(finalizedNotifications, NTCheckpointFinalized, (*casper.CheckpointEvent)(n))
+				}
+
+			case *notificationRollback:
+				m.notifyRollback([]map[chan struct{}]*WSClient{blockNotifications, justifiedNotifications, finalizedNotifications}, (*protocol.RollbackEvent)(n))
+
+			case *notificationRegisterJustified:
+				wsc := (*WSClient)(n)
+				justifiedNotifications[wsc.quit] = wsc
+
+			case *notificationUnregisterJustified:
+				wsc := (*WSClient)(n)
+				delete(justifiedNotifications, wsc.quit)
+
+			case *notificationRegisterFinalized:
+				wsc := (*WSClient)(n)
+				finalizedNotifications[wsc.quit] = wsc
+
+			case *notificationUnregisterFinalized:
+				wsc := (*WSClient)(n)
+				delete(finalizedNotifications, wsc.quit)
+
 			case *notificationRegisterBlocks:
 				wsc := (*WSClient)(n)
 				blockNotifications[wsc.quit] = wsc
@@ -307,6 +402,8 @@ out:
 				wsc := (*WSClient)(n)
 				delete(blockNotifications, wsc.quit)
 				delete(txNotifications, wsc.quit)
+				delete(justifiedNotifications, wsc.quit)
+				delete(finalizedNotifications, wsc.quit)
 				delete(clients, wsc.quit)
 
 			default:
@@ -406,6 +503,80 @@ func (m *WSNotificationManager) notifyForNewTx(clients map[chan struct{}]*WSClie
 	}
 }
 
+// RegisterJustifiedUpdates requests notifications to the passed websocket client
+// when a checkpoint is justified.
+func (m *WSNotificationManager) RegisterJustifiedUpdates(wsc *WSClient) {
+	m.queueNotification <- (*notificationRegisterJustified)(wsc)
+}
+
+// UnregisterJustifiedUpdates removes justified checkpoint notifications for the passed websocket client.
+func (m *WSNotificationManager) UnregisterJustifiedUpdates(wsc *WSClient) {
+	m.queueNotification <- (*notificationUnregisterJustified)(wsc)
+}
+
+// RegisterFinalizedUpdates requests notifications to the passed websocket client
+// when a checkpoint is finalized.
+func (m *WSNotificationManager) RegisterFinalizedUpdates(wsc *WSClient) {
+	m.queueNotification <- (*notificationRegisterFinalized)(wsc)
+}
+
+// UnregisterFinalizedUpdates removes finalized checkpoint notifications for the passed websocket client.
+func (m *WSNotificationManager) UnregisterFinalizedUpdates(wsc *WSClient) {
+	m.queueNotification <- (*notificationUnregisterFinalized)(wsc)
+}
+
+// notifyCheckpoint notifies websocket clients that have registered for justified
+// or finalized updates when the status of a checkpoint is changed.
+func (*WSNotificationManager) notifyCheckpoint(clients map[chan struct{}]*WSClient, typ NotificationType, ev *casper.CheckpointEvent) {
+	if len(clients) == 0 {
+		return
+	}
+
+	resp := NewWSResponse(typ.String(), &checkpointInfo{Height: ev.Height, Hash: ev.Hash}, nil)
+	marshalledJSON, err := json.Marshal(resp)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "error": err}).Error("Failed to marshal checkpoint notification")
+		return
+	}
+
+	for _, wsc := range clients {
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
+// notifyRollback notifies websocket clients that have registered for block, justified
+// or finalized updates when casper rolls the main chain back to another fork, each
+// client is notified once.
+func (*WSNotificationManager) notifyRollback(clientGroups []map[chan struct{}]*WSClient, ev *protocol.RollbackEvent) {
+	clients := make(map[chan struct{}]*WSClient)
+	for _, group := range clientGroups {
+		for quit, wsc := range group {
+			clients[quit] = wsc
+		}
+	}
+
+	if len(clients) == 0 {
+		return
+	}
+
+	info := &rollbackInfo{
+		PrevBestHeight: ev.PrevBestHeight,
+		PrevBestHash:   ev.PrevBestHash,
+		BestHeight:     ev.BestHeight,
+		BestHash:       ev.BestHash,
+	}
+	resp := NewWSResponse(NTChainRollback.String(), info, nil)
+	marshalledJSON, err := json.Marshal(resp)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "error": err}).Error("Failed to marshal rollback notification")
+		return
+	}
+
+	for _, wsc := range clients {
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
 // AddClient adds the passed websocket client to the notification manager.
 func (m *WSNotificationManager) AddClient(wsc *WSClient) {
 	m.queueNotification <- (*notificationRegisterClient)(wsc)
@@ -474,11 +645,17 @@ func (m *WSNotificationManager) Start() error {
 		return err
 	}
 
-	m.wg.Add(4)
+	m.chainEventSub, err = m.eventDispatcher.Subscribe(casper.CheckpointEvent{}, protocol.RollbackEvent{})
+	if err != nil {
+		return err
+	}
+
+	m.wg.Add(5)
 	go m.blockNotify()
 	go m.queueHandler()
 	go m.notificationHandler()
 	go m.memPoolTxQueryLoop()
+	go m.chainEventLoop()
 	return nil
 }
 
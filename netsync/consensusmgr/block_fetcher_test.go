@@ -8,6 +8,7 @@ import (
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
 	"github.com/bytom/bytom/protocol/casper"
+	"github.com/bytom/bytom/protocol/state"
 )
 
 type peerMgr struct {
@@ -50,6 +51,10 @@ func (c *chain) ProcessBlockVerification(*casper.ValidCasperSignMsg) error {
 	return nil
 }
 
+func (c *chain) ProcessEvidence(*state.Evidence) error {
+	return nil
+}
+
 func TestBlockFetcher(t *testing.T) {
 	peers := peers.NewPeerSet(&peerMgr{})
 	testCase := []struct {
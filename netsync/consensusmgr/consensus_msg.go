@@ -3,6 +3,7 @@ package consensusmgr
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -11,11 +12,13 @@ import (
 	"github.com/bytom/bytom/netsync/peers"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
 )
 
 const (
 	blockSignatureByte = byte(0x10)
 	blockProposeByte   = byte(0x11)
+	evidenceByte       = byte(0x12)
 )
 
 // ConsensusMessage is a generic message for consensus reactor.
@@ -29,6 +32,7 @@ var _ = wire.RegisterInterface(
 	struct{ ConsensusMessage }{},
 	wire.ConcreteType{O: &BlockVerificationMsg{}, Byte: blockSignatureByte},
 	wire.ConcreteType{O: &BlockProposeMsg{}, Byte: blockProposeByte},
+	wire.ConcreteType{O: &EvidenceMsg{}, Byte: evidenceByte},
 )
 
 // decodeMessage decode msg
@@ -134,3 +138,58 @@ func (bp *BlockProposeMsg) BroadcastFilterTargetPeers(ps *peers.PeerSet) []strin
 
 	return ps.PeersWithoutBlock(block.Hash())
 }
+
+// EvidenceMsg casper evidence message transferred between nodes.
+type EvidenceMsg struct {
+	RawEvidence []byte
+}
+
+// NewEvidenceMsg create new casper evidence msg.
+func NewEvidenceMsg(evidence *state.Evidence) (ConsensusMessage, error) {
+	rawEvidence, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+	return &EvidenceMsg{RawEvidence: rawEvidence}, nil
+}
+
+// GetEvidence get casper evidence from msg.
+func (e *EvidenceMsg) GetEvidence() (*state.Evidence, error) {
+	evidence := &state.Evidence{}
+	if err := json.Unmarshal(e.RawEvidence, evidence); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+func (e *EvidenceMsg) String() string {
+	evidence, err := e.GetEvidence()
+	if err != nil {
+		return "{err: wrong message}"
+	}
+	hash := evidence.Hash()
+	return fmt.Sprintf("{type: %s, pub_key: %s, hash: %s}", evidence.Type, evidence.PubKey, hash.String())
+}
+
+// BroadcastMarkSendRecord mark send message record to prevent messages from being sent repeatedly.
+func (e *EvidenceMsg) BroadcastMarkSendRecord(ps *peers.PeerSet, peers []string) {
+	evidence, err := e.GetEvidence()
+	if err != nil {
+		return
+	}
+
+	hash := evidence.Hash()
+	for _, peer := range peers {
+		ps.MarkEvidence(peer, &hash)
+	}
+}
+
+// BroadcastFilterTargetPeers filter target peers to filter the nodes that need to send messages.
+func (e *EvidenceMsg) BroadcastFilterTargetPeers(ps *peers.PeerSet) []string {
+	evidence, err := e.GetEvidence()
+	if err != nil {
+		return nil
+	}
+
+	return ps.PeersWithoutEvidence(evidence.Hash())
+}
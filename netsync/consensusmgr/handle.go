@@ -13,6 +13,7 @@ import (
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
 	"github.com/bytom/bytom/protocol/casper"
+	"github.com/bytom/bytom/protocol/state"
 )
 
 // Switch is the interface for p2p switch.
@@ -26,6 +27,7 @@ type Chain interface {
 	GetHeaderByHash(*bc.Hash) (*types.BlockHeader, error)
 	ProcessBlock(*types.Block) (bool, error)
 	ProcessBlockVerification(*casper.ValidCasperSignMsg) error
+	ProcessEvidence(*state.Evidence) error
 }
 
 type Peers interface {
@@ -34,6 +36,7 @@ type Peers interface {
 	GetPeer(id string) *peers.Peer
 	MarkBlock(peerID string, hash *bc.Hash)
 	MarkBlockVerification(peerID string, signature []byte)
+	MarkEvidence(peerID string, hash *bc.Hash)
 	ProcessIllegal(peerID string, level byte, reason string)
 	RemovePeer(peerID string)
 	SetStatus(peerID string, height uint64, hash *bc.Hash)
@@ -89,6 +92,9 @@ func (m *Manager) processMsg(peerID string, msgType byte, msg ConsensusMessage)
 	case *BlockVerificationMsg:
 		m.handleBlockVerificationMsg(peerID, msg)
 
+	case *EvidenceMsg:
+		m.handleEvidenceMsg(peerID, msg)
+
 	default:
 		logrus.WithFields(logrus.Fields{"module": logModule, "peer": peerID, "message_type": reflect.TypeOf(msg)}).Error("unhandled message type")
 	}
@@ -119,6 +125,20 @@ func (m *Manager) handleBlockVerificationMsg(peerID string, msg *BlockVerificati
 	}
 }
 
+func (m *Manager) handleEvidenceMsg(peerID string, msg *EvidenceMsg) {
+	evidence, err := msg.GetEvidence()
+	if err != nil {
+		m.peers.ProcessIllegal(peerID, security.LevelMsgIllegal, err.Error())
+		return
+	}
+
+	hash := evidence.Hash()
+	m.peers.MarkEvidence(peerID, &hash)
+	if err := m.chain.ProcessEvidence(evidence); err != nil {
+		m.peers.ProcessIllegal(peerID, security.LevelMsgIllegal, err.Error())
+	}
+}
+
 func (m *Manager) blockProposeMsgBroadcastLoop() {
 	m.msgBroadcastLoop(event.NewProposedBlockEvent{}, func(data interface{}) (ConsensusMessage, error) {
 		ev := data.(event.NewProposedBlockEvent)
@@ -138,6 +158,13 @@ func (m *Manager) blockVerificationMsgBroadcastLoop() {
 	})
 }
 
+func (m *Manager) evidenceMsgBroadcastLoop() {
+	m.msgBroadcastLoop(state.Evidence{}, func(data interface{}) (ConsensusMessage, error) {
+		evidence := data.(state.Evidence)
+		return NewEvidenceMsg(&evidence)
+	})
+}
+
 func (m *Manager) msgBroadcastLoop(msgType interface{}, newMsg func(event interface{}) (ConsensusMessage, error)) {
 	subscribeType := reflect.TypeOf(msgType)
 	msgSub, err := m.eventDispatcher.Subscribe(msgType)
@@ -186,6 +213,7 @@ func (m *Manager) Start() error {
 	go m.blockFetcher.blockProcessorLoop()
 	go m.blockProposeMsgBroadcastLoop()
 	go m.blockVerificationMsgBroadcastLoop()
+	go m.evidenceMsgBroadcastLoop()
 	return nil
 }
 
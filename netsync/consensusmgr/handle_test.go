@@ -16,6 +16,7 @@ import (
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
 	"github.com/bytom/bytom/protocol/casper"
+	"github.com/bytom/bytom/protocol/state"
 )
 
 type p2peer struct {
@@ -99,6 +100,10 @@ func (c *mockChain) ProcessBlockVerification(*casper.ValidCasperSignMsg) error {
 	return nil
 }
 
+func (c *mockChain) ProcessEvidence(*state.Evidence) error {
+	return nil
+}
+
 type mockPeers struct {
 	msgCount       *int
 	knownBlock     *bc.Hash
@@ -134,6 +139,10 @@ func (ps *mockPeers) MarkBlockVerification(peerID string, signature []byte) {
 	*ps.knownSignature = append(*ps.knownSignature, signature...)
 }
 
+func (ps *mockPeers) MarkEvidence(peerID string, hash *bc.Hash) {
+
+}
+
 func (ps *mockPeers) ProcessIllegal(peerID string, level byte, reason string) {
 
 }
@@ -21,6 +21,7 @@ const (
 	maxKnownTxs           = 32768 // Maximum transactions hashes to keep in the known list (prevent DOS)
 	maxKnownSignatures    = 1024  // Maximum block signatures to keep in the known list (prevent DOS)
 	maxKnownBlocks        = 1024  // Maximum block hashes to keep in the known list (prevent DOS)
+	maxKnownEvidences     = 1024  // Maximum casper evidence hashes to keep in the known list (prevent DOS)
 	maxFilterAddressSize  = 50
 	maxFilterAddressCount = 1000
 
@@ -86,6 +87,7 @@ type Peer struct {
 	knownTxs        *set.Set // Set of transaction hashes known to be known by this peer
 	knownBlocks     *set.Set // Set of block hashes known to be known by this peer
 	knownSignatures *set.Set // Set of block signatures known to be known by this peer
+	knownEvidences  *set.Set // Set of casper evidence hashes known to be known by this peer
 	knownStatus     uint64   // Set of chain status known to be known by this peer
 	filterAdds      *set.Set // Set of addresses that the spv node cares about.
 }
@@ -97,6 +99,7 @@ func newPeer(basePeer BasePeer) *Peer {
 		knownTxs:        set.New(),
 		knownBlocks:     set.New(),
 		knownSignatures: set.New(),
+		knownEvidences:  set.New(),
 		filterAdds:      set.New(),
 	}
 }
@@ -243,6 +246,16 @@ func (p *Peer) markSign(signature []byte) {
 	p.knownSignatures.Add(hex.EncodeToString(signature))
 }
 
+func (p *Peer) markEvidence(hash *bc.Hash) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for p.knownEvidences.Size() >= maxKnownEvidences {
+		p.knownEvidences.Pop()
+	}
+	p.knownEvidences.Add(hash.String())
+}
+
 func (p *Peer) markTransaction(hash *bc.Hash) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -547,6 +560,14 @@ func (ps *PeerSet) MarkBlockVerification(peerID string, signature []byte) {
 	peer.markSign(signature)
 }
 
+func (ps *PeerSet) MarkEvidence(peerID string, hash *bc.Hash) {
+	peer := ps.GetPeer(peerID)
+	if peer == nil {
+		return
+	}
+	peer.markEvidence(hash)
+}
+
 func (ps *PeerSet) MarkStatus(peerID string, height uint64) {
 	peer := ps.GetPeer(peerID)
 	if peer == nil {
@@ -592,6 +613,19 @@ func (ps *PeerSet) PeersWithoutSignature(signature []byte) []string {
 	return peers
 }
 
+func (ps *PeerSet) PeersWithoutEvidence(hash bc.Hash) []string {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	var peers []string
+	for _, peer := range ps.peers {
+		if !peer.knownEvidences.Has(hash.String()) {
+			peers = append(peers, peer.ID())
+		}
+	}
+	return peers
+}
+
 func (ps *PeerSet) peersWithoutNewStatus(height uint64) []*Peer {
 	ps.mtx.RLock()
 	defer ps.mtx.RUnlock()
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bytom/bytom/protocol"
+)
+
+const mempoolDumpFile = "mempool.dat"
+
+func (n *Node) mempoolDumpPath() string {
+	return filepath.Join(n.config.DBDir(), mempoolDumpFile)
+}
+
+// loadMempool revalidate the transactions dumped on last stop and put them back to the txpool,
+// the dump file is removed after loading so the stale transactions are never reloaded twice
+func (n *Node) loadMempool() {
+	file, err := os.Open(n.mempoolDumpPath())
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on open mempool dump file")
+		return
+	}
+
+	defer os.Remove(n.mempoolDumpPath())
+	defer file.Close()
+
+	expire := time.Now().Add(-time.Duration(n.config.Mempool.Expiration) * time.Hour)
+	dumpTxs, err := protocol.LoadDump(file, expire)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on load mempool dump file")
+		return
+	}
+
+	reloaded := 0
+	for _, dumpTx := range dumpTxs {
+		if _, err := n.chain.ReloadTx(dumpTx.Tx, dumpTx.Added); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "tx_id": dumpTx.Tx.ID.String(), "err": err}).Debug("fail on reload mempool tx")
+			continue
+		}
+
+		reloaded++
+	}
+	log.WithFields(log.Fields{"module": logModule, "dumped": len(dumpTxs), "reloaded": reloaded}).Info("reload mempool transactions")
+}
+
+// dumpMempool write the txpool transactions to the data directory before the node stopped,
+// the dump is written to a temp file first so that the last dump is never left half written
+func (n *Node) dumpMempool() {
+	tmpPath := n.mempoolDumpPath() + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on create mempool dump file")
+		return
+	}
+
+	if err := n.txPool.Dump(file); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on dump mempool")
+		return
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on close mempool dump file")
+		return
+	}
+
+	if err := os.Rename(tmpPath, n.mempoolDumpPath()); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on rename mempool dump file")
+	}
+}
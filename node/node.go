@@ -19,6 +19,7 @@ import (
 	"github.com/bytom/bytom/api"
 	"github.com/bytom/bytom/asset"
 	"github.com/bytom/bytom/blockchain/pseudohsm"
+	"github.com/bytom/bytom/blockchain/txfeed"
 	cfg "github.com/bytom/bytom/config"
 	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/contract"
@@ -30,6 +31,7 @@ import (
 	"github.com/bytom/bytom/net/websocket"
 	"github.com/bytom/bytom/netsync"
 	"github.com/bytom/bytom/protocol"
+	"github.com/bytom/bytom/signer"
 	w "github.com/bytom/bytom/wallet"
 )
 
@@ -48,9 +50,11 @@ type Node struct {
 
 	wallet          *w.Wallet
 	accessTokens    *accesstoken.CredentialStore
+	txFeedTracker   *txfeed.Tracker
 	notificationMgr *websocket.WSNotificationManager
 	api             *api.API
 	chain           *protocol.Chain
+	txPool          *protocol.TxPool
 	traceService    *contract.TraceService
 	blockProposer   *blockproposer.BlockProposer
 	miningEnable    bool
@@ -68,14 +72,26 @@ func NewNode(config *cfg.Config) *Node {
 	}
 	coreDB := dbm.NewDB("core", config.DBBackend, config.DBDir())
 	store := database.NewStore(coreDB)
+	if config.Index.Address {
+		store.EnableAddressIndex()
+	}
+	if config.Index.Spend {
+		store.EnableSpendIndex()
+	}
 
 	tokenDB := dbm.NewDB("accesstoken", config.DBBackend, config.DBDir())
 	accessTokens := accesstoken.NewStore(tokenDB)
 
 	dispatcher := event.NewDispatcher()
 	txPool := protocol.NewTxPool(store, dispatcher)
+	txPool.SetMaxNumTxs(config.Mempool.MaxNumTxs)
+
+	blockSigner, err := newSigner(config)
+	if err != nil {
+		cmn.Exit(cmn.Fmt("Failed to create signer: %v", err))
+	}
 
-	chain, err := protocol.NewChain(store, txPool, dispatcher)
+	chain, err := protocol.NewChainWithSigner(store, txPool, dispatcher, blockSigner)
 	if err != nil {
 		cmn.Exit(cmn.Fmt("Failed to create chain structure: %v", err))
 	}
@@ -85,6 +101,7 @@ func NewNode(config *cfg.Config) *Node {
 	var accounts *account.Manager
 	var assets *asset.Registry
 	var wallet *w.Wallet
+	var txFeedTracker *txfeed.Tracker
 
 	hsm, err := pseudohsm.New(config.KeysDir())
 	if err != nil {
@@ -101,6 +118,9 @@ func NewNode(config *cfg.Config) *Node {
 			log.WithFields(log.Fields{"module": logModule, "error": err}).Error("init NewWallet")
 		}
 
+		txFeedDB := dbm.NewDB("txfeeds", config.DBBackend, config.DBDir())
+		txFeedTracker = txfeed.NewTracker(txFeedDB)
+
 		// trigger rescan wallet
 		if config.Wallet.Rescan {
 			wallet.RescanBlocks()
@@ -132,8 +152,10 @@ func NewNode(config *cfg.Config) *Node {
 		config:          config,
 		syncManager:     syncManager,
 		accessTokens:    accessTokens,
+		txFeedTracker:   txFeedTracker,
 		wallet:          wallet,
 		chain:           chain,
+		txPool:          txPool,
 		traceService:    traceService,
 		miningEnable:    config.Mining,
 		notificationMgr: notificationMgr,
@@ -144,6 +166,16 @@ func NewNode(config *cfg.Config) *Node {
 	return node
 }
 
+// newSigner connect to the remote signer if the signer address is configured, otherwise
+// the blocks and verifications are signed by the private key of the node
+func newSigner(config *cfg.Config) (signer.Signer, error) {
+	if config.SignerAddress != "" {
+		return signer.NewRemoteSigner(config.SignerAddress, config.SignerSecret)
+	}
+
+	return signer.NewLocalSigner(*config.PrivateKey(), config.SignerStatePath())
+}
+
 func startTraceUpdater(chain *protocol.Chain, cfg *cfg.Config) *contract.TraceService {
 	db := dbm.NewDB("trace", cfg.DBBackend, cfg.DBDir())
 	store := contract.NewTraceStore(db)
@@ -177,6 +209,10 @@ func lockDataDirectory(config *cfg.Config) error {
 }
 
 func initActiveNetParams(config *cfg.Config) {
+	if err := cfg.RegisterGenesisNetwork(config.RootDir, config.ChainID); err != nil {
+		cmn.Exit(cmn.Fmt("fail on register the network of genesis file: %v", err))
+	}
+
 	var exist bool
 	consensus.ActiveNetParams, exist = consensus.NetParams[config.ChainID]
 	if !exist {
@@ -199,7 +235,7 @@ func launchWebBrowser(port string) {
 }
 
 func (n *Node) initAndstartAPIServer() {
-	n.api = api.NewAPI(n.syncManager, n.wallet, n.blockProposer, n.chain, n.traceService, n.config, n.accessTokens, n.eventDispatcher, n.notificationMgr)
+	n.api = api.NewAPI(n.syncManager, n.wallet, n.blockProposer, n.chain, n.traceService, n.config, n.accessTokens, n.txFeedTracker, n.eventDispatcher, n.notificationMgr)
 
 	listenAddr := env.String("LISTEN", n.config.ApiAddress)
 	env.Parse()
@@ -207,6 +243,10 @@ func (n *Node) initAndstartAPIServer() {
 }
 
 func (n *Node) OnStart() error {
+	if n.config.Mempool.Persist {
+		n.loadMempool()
+	}
+
 	if n.miningEnable {
 		if _, err := n.wallet.AccountMgr.GetMiningAddress(); err != nil {
 			n.miningEnable = false
@@ -247,9 +287,27 @@ func (n *Node) OnStop() {
 	if !n.config.VaultMode {
 		n.syncManager.Stop()
 	}
+	if n.config.Mempool.Persist {
+		n.dumpMempool()
+	}
 	n.eventDispatcher.Stop()
 }
 
+// Chain return the chain of the node
+func (n *Node) Chain() *protocol.Chain {
+	return n.chain
+}
+
+// SyncManager return the sync manager of the node
+func (n *Node) SyncManager() *netsync.SyncManager {
+	return n.syncManager
+}
+
+// BlockProposer return the block proposer of the node
+func (n *Node) BlockProposer() *blockproposer.BlockProposer {
+	return n.blockProposer
+}
+
 func (n *Node) RunForever() {
 	// Sleep forever and then...
 	cmn.TrapSignal(func() {
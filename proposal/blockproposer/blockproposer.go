@@ -8,11 +8,12 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/bytom/bytom/account"
-	"github.com/bytom/bytom/config"
 	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/event"
 	"github.com/bytom/bytom/proposal"
 	"github.com/bytom/bytom/protocol"
+	"github.com/bytom/bytom/protocol/bc/types"
 )
 
 const (
@@ -23,6 +24,9 @@ const (
 	criticalTimeDenom = 5
 )
 
+// ErrNotBlocker is returned when the node is not the validator to propose the block
+var ErrNotBlocker = errors.New("node is not the blocker of the timestamp")
+
 // BlockProposer propose several block in specified time range
 type BlockProposer struct {
 	sync.Mutex
@@ -41,8 +45,6 @@ type BlockProposer struct {
 //
 // It must be run as a goroutine.
 func (b *BlockProposer) generateBlocks() {
-	xpub := config.CommonConfig.PrivateKey().XPub()
-	xpubStr := hex.EncodeToString(xpub[:])
 	ticker := time.NewTicker(time.Duration(consensus.ActiveNetParams.BlockTimeInterval) * time.Millisecond / 4)
 	defer ticker.Stop()
 
@@ -54,8 +56,6 @@ func (b *BlockProposer) generateBlocks() {
 		}
 
 		bestBlockHeader := b.chain.BestBlockHeader()
-		bestBlockHash := bestBlockHeader.Hash()
-
 		now := uint64(time.Now().UnixNano() / 1e6)
 		base := bestBlockHeader.Timestamp
 		if now > bestBlockHeader.Timestamp+consensus.ActiveNetParams.BlockTimeInterval {
@@ -71,36 +71,45 @@ func (b *BlockProposer) generateBlocks() {
 			continue
 		}
 
-		validator, err := b.chain.GetValidator(&bestBlockHash, nextBlockTime)
-		if err != nil {
-			log.WithFields(log.Fields{"module": logModule, "error": err, "pubKey": xpubStr}).Error("fail on check is next blocker")
-			continue
+		if _, err := b.ProposeBlock(nextBlockTime); err != nil && err != ErrNotBlocker {
+			log.WithFields(log.Fields{"module": logModule, "height": bestBlockHeader.Height + 1, "error": err}).Error("proposer fail on propose block")
 		}
+	}
+}
 
-		if xpubStr != validator.PubKey {
-			continue
-		}
+// ProposeBlock propose the block next to the best block at the timestamp, the block is processed
+// and broadcast, ErrNotBlocker is returned if the node is not the validator of the timestamp
+func (b *BlockProposer) ProposeBlock(timestamp uint64) (*types.Block, error) {
+	xpub := b.chain.XPub()
+	xpubStr := hex.EncodeToString(xpub[:])
+	bestBlockHash := b.chain.BestBlockHeader().Hash()
+	validator, err := b.chain.GetValidator(&bestBlockHash, timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail on check is next blocker")
+	}
 
-		warnDuration := time.Duration(consensus.ActiveNetParams.BlockTimeInterval*warnTimeNum/warnTimeDenom) * time.Millisecond
-		criticalDuration := time.Duration(consensus.ActiveNetParams.BlockTimeInterval*criticalTimeNum/criticalTimeDenom) * time.Millisecond
-		block, err := proposal.NewBlockTemplate(b.chain, validator, b.accountManager, nextBlockTime, warnDuration, criticalDuration)
-		if err != nil {
-			log.WithFields(log.Fields{"module": logModule, "error": err}).Error("failed on create NewBlockTemplate")
-			continue
-		}
+	if xpubStr != validator.PubKey {
+		return nil, ErrNotBlocker
+	}
 
-		isOrphan, err := b.chain.ProcessBlock(block)
-		if err != nil {
-			log.WithFields(log.Fields{"module": logModule, "height": block.BlockHeader.Height, "error": err}).Error("proposer fail on ProcessBlock")
-			continue
-		}
+	warnDuration := time.Duration(consensus.ActiveNetParams.BlockTimeInterval*warnTimeNum/warnTimeDenom) * time.Millisecond
+	criticalDuration := time.Duration(consensus.ActiveNetParams.BlockTimeInterval*criticalTimeNum/criticalTimeDenom) * time.Millisecond
+	block, err := proposal.NewBlockTemplate(b.chain, validator, b.accountManager, timestamp, warnDuration, criticalDuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed on create NewBlockTemplate")
+	}
 
-		log.WithFields(log.Fields{"module": logModule, "height": block.BlockHeader.Height, "isOrphan": isOrphan, "tx": len(block.Transactions)}).Info("proposer processed block")
-		// Broadcast the block and announce chain insertion event
-		if err = b.eventDispatcher.Post(event.NewProposedBlockEvent{Block: *block}); err != nil {
-			log.WithFields(log.Fields{"module": logModule, "height": block.BlockHeader.Height, "error": err}).Error("proposer fail on post block")
-		}
+	isOrphan, err := b.chain.ProcessBlock(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "proposer fail on ProcessBlock")
+	}
+
+	log.WithFields(log.Fields{"module": logModule, "height": block.BlockHeader.Height, "isOrphan": isOrphan, "tx": len(block.Transactions)}).Info("proposer processed block")
+	// Broadcast the block and announce chain insertion event
+	if err = b.eventDispatcher.Post(event.NewProposedBlockEvent{Block: *block}); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "height": block.BlockHeader.Height, "error": err}).Error("proposer fail on post block")
 	}
+	return block, nil
 }
 
 // Start begins the block propose process as well as the speed monitor used to
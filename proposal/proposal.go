@@ -2,7 +2,6 @@ package proposal
 
 import (
 	"encoding/hex"
-	"sort"
 	"strconv"
 	"time"
 
@@ -54,7 +53,7 @@ func newBlockBuilder(chain *protocol.Chain, validator *state.Validator, accountM
 	preBlockHeader := chain.BestBlockHeader()
 	block := &types.Block{
 		BlockHeader: types.BlockHeader{
-			Version:           1,
+			Version:           consensus.ActiveNetParams.BlockVersion(preBlockHeader.Height + 1),
 			Height:            preBlockHeader.Height + 1,
 			PreviousBlockHash: preBlockHeader.Hash(),
 			Timestamp:         timestamp,
@@ -78,6 +77,12 @@ func newBlockBuilder(chain *protocol.Chain, validator *state.Validator, accountM
 
 func (b *blockBuilder) build() (*types.Block, error) {
 	b.block.Transactions = []*types.Tx{nil}
+	if err := b.applyMaxBlockGas(); err != nil {
+		return nil, err
+	}
+
+	b.applySlashEvidences()
+	b.applyParamProposals()
 	if err := b.applyTransactionFromPool(); err != nil {
 		return nil, err
 	}
@@ -90,11 +95,48 @@ func (b *blockBuilder) build() (*types.Block, error) {
 		return nil, err
 	}
 
-	blockHeader := &b.block.BlockHeader
-	b.chain.SignBlockHeader(blockHeader)
+	if err := b.chain.SignBlockHeader(&b.block.BlockHeader); err != nil {
+		return nil, err
+	}
+
 	return b.block, nil
 }
 
+// applySlashEvidences include the known evidences of the validators which signed
+// conflicting verifications, the block is still built without them on failure
+func (b *blockBuilder) applySlashEvidences() {
+	evidences, err := b.chain.SlashEvidences(&b.block.PreviousBlockHash, b.block.Height)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warn("fail on get slash evidences")
+		return
+	}
+
+	b.block.SlashEvidences = evidences
+}
+
+// applyParamProposals include the consensus parameter proposals signed by the supermajority
+// of the validators, the block is still built without them on failure
+func (b *blockBuilder) applyParamProposals() {
+	proposals, err := b.chain.ParamProposals(&b.block.PreviousBlockHash, b.block.Height)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warn("fail on get param proposals")
+		return
+	}
+
+	b.block.ParamProposals = proposals
+}
+
+// applyMaxBlockGas limit the gas of the block by the max block gas effective at its height
+func (b *blockBuilder) applyMaxBlockGas() error {
+	maxBlockGas, err := b.chain.ConsensusParam(&b.block.PreviousBlockHash, b.block.Height, consensus.ParamMaxBlockGas)
+	if err != nil {
+		return err
+	}
+
+	b.gasLeft = int64(maxBlockGas)
+	return nil
+}
+
 func (b *blockBuilder) applyCoinbaseTransaction() error {
 	coinbaseTx, err := b.createCoinbaseTx()
 	if err != nil {
@@ -112,9 +154,8 @@ func (b *blockBuilder) applyCoinbaseTransaction() error {
 }
 
 func (b *blockBuilder) applyTransactionFromPool() error {
-	txDescList := b.chain.GetTxPool().GetTransactions()
-	sort.Sort(byTime(txDescList))
-	return b.applyTransactions(txDescList, timeoutWarn)
+	txPackages := b.chain.GetTxPool().GetTxPackages()
+	return b.applyTransactions(txPackages, timeoutWarn)
 }
 
 func (b *blockBuilder) calculateBlockCommitment() (err error) {
@@ -165,7 +206,7 @@ func (b *blockBuilder) createCoinbaseTx() (tx *types.Tx, err error) {
 		return nil, err
 	}
 
-	if b.block.Height%consensus.ActiveNetParams.BlocksOfEpoch == 1 && b.block.Height != 1 {
+	if b.block.PreviousBlockHash == checkpoint.Hash && b.block.Height != 1 {
 		for controlProgram, amount := range checkpoint.Rewards {
 			if controlProgram == hex.EncodeToString(script) {
 				builder.Outputs()[0].Amount = amount
@@ -201,14 +242,16 @@ func (b *blockBuilder) createCoinbaseTx() (tx *types.Tx, err error) {
 	return tx, nil
 }
 
-func (b *blockBuilder) applyTransactions(txs []*protocol.TxDesc, timeoutStatus uint8) error {
-	batchTxs := []*protocol.TxDesc{}
-	for i := 0; i < len(txs); i++ {
-		if batchTxs = append(batchTxs, txs[i]); len(batchTxs) < batchApplyNum && i != len(txs)-1 {
+func (b *blockBuilder) applyTransactions(packages []*protocol.TxPackage, timeoutStatus uint8) error {
+	batchPackages := []*protocol.TxPackage{}
+	batchTxNum := 0
+	for i := 0; i < len(packages); i++ {
+		batchPackages = append(batchPackages, packages[i])
+		if batchTxNum += len(packages[i].Txs); batchTxNum < batchApplyNum && i != len(packages)-1 {
 			continue
 		}
 
-		results, gasLeft := b.preValidateTxs(batchTxs, b.chain, b.utxoView, b.gasLeft)
+		results, gasLeft := b.preValidateTxs(batchPackages, b.chain, b.utxoView, b.gasLeft)
 		for _, result := range results {
 			if result.err != nil {
 				log.WithFields(log.Fields{"module": logModule, "error": result.err}).Error("propose block generation: skip tx due to")
@@ -220,7 +263,8 @@ func (b *blockBuilder) applyTransactions(txs []*protocol.TxDesc, timeoutStatus u
 		}
 
 		b.gasLeft = gasLeft
-		batchTxs = batchTxs[:0]
+		batchPackages = batchPackages[:0]
+		batchTxNum = 0
 		if b.getTimeoutStatus() >= timeoutStatus || len(b.block.Transactions) > softMaxTxNum {
 			break
 		}
@@ -233,39 +277,82 @@ type validateTxResult struct {
 	err error
 }
 
-func (b *blockBuilder) preValidateTxs(txs []*protocol.TxDesc, chain *protocol.Chain, view *state.UtxoViewpoint, gasLeft int64) ([]*validateTxResult, int64) {
+// preValidateTxs validates the txs package by package, a package is packed into the block only
+// if all its txs are valid and the gas left is enough for the whole package
+func (b *blockBuilder) preValidateTxs(packages []*protocol.TxPackage, chain *protocol.Chain, view *state.UtxoViewpoint, gasLeft int64) ([]*validateTxResult, int64) {
 	var results []*validateTxResult
 	bcBlock := &bc.Block{BlockHeader: &bc.BlockHeader{Height: chain.BestBlockHeight() + 1}}
-	bcTxs := make([]*bc.Tx, len(txs))
-	for i, tx := range txs {
-		bcTxs[i] = tx.Tx.Tx
+	var bcTxs []*bc.Tx
+	for _, pkg := range packages {
+		for _, txD := range pkg.Txs {
+			bcTxs = append(bcTxs, txD.Tx.Tx)
+		}
 	}
 
-	validateResults := validation.ValidateTxs(bcTxs, bcBlock, b.chain.ProgramConverter)
-	for i := 0; i < len(validateResults) && gasLeft > 0; i++ {
-		tx := txs[i].Tx
-		gasStatus := validateResults[i].GetGasState()
-		if err := validateResults[i].GetError(); err != nil {
-			results = append(results, &validateTxResult{tx: tx, err: err})
-			continue
+	checkpoint, err := chain.PrevCheckpointByPrevHash(&b.block.PreviousBlockHash)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "error": err}).Error("propose block generation: fail on get checkpoint")
+		return nil, gasLeft
+	}
+
+	spentView, err := chain.SpentUtxoView(bcTxs, bcBlock.Height, checkpoint)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "error": err}).Error("propose block generation: fail on load spent utxos")
+		return nil, gasLeft
+	}
+
+	validateResults := validation.ValidateTxs(bcTxs, bcBlock, spentView, checkpoint, b.chain.ProgramConverter)
+	for _, pkg := range packages {
+		pkgResults := validateResults[:len(pkg.Txs)]
+		validateResults = validateResults[len(pkg.Txs):]
+		if gasLeft <= 0 {
+			break
 		}
 
-		if err := chain.GetTransactionsUtxo(view, []*bc.Tx{bcTxs[i]}); err != nil {
-			results = append(results, &validateTxResult{tx: tx, err: err})
-			continue
+		var appliedTxs []*bc.Tx
+		var failResult *validateTxResult
+		gasUsed := int64(0)
+		for i, txD := range pkg.Txs {
+			if err := pkgResults[i].GetError(); err != nil {
+				failResult = &validateTxResult{tx: txD.Tx, err: err}
+				break
+			}
+
+			if err := chain.GetTransactionsUtxo(view, []*bc.Tx{txD.Tx.Tx}); err != nil {
+				failResult = &validateTxResult{tx: txD.Tx, err: err}
+				break
+			}
+
+			if err := view.ApplyTransaction(bcBlock, txD.Tx.Tx, checkpoint); err != nil {
+				failResult = &validateTxResult{tx: txD.Tx, err: err}
+				break
+			}
+
+			appliedTxs = append(appliedTxs, txD.Tx.Tx)
+			gasUsed += pkgResults[i].GetGasState().GasUsed
 		}
 
-		if gasLeft-gasStatus.GasUsed < 0 {
-			break
+		if failResult != nil || gasLeft-gasUsed < 0 {
+			for i := len(appliedTxs) - 1; i >= 0; i-- {
+				if err := view.DetachTransaction(appliedTxs[i]); err != nil {
+					log.WithFields(log.Fields{"module": logModule, "error": err}).Error("propose block generation: fail on detach package tx")
+				}
+			}
 		}
 
-		if err := view.ApplyTransaction(bcBlock, bcTxs[i]); err != nil {
-			results = append(results, &validateTxResult{tx: tx, err: err})
+		if failResult != nil {
+			results = append(results, failResult)
 			continue
 		}
 
-		results = append(results, &validateTxResult{tx: tx, err: validateResults[i].GetError()})
-		gasLeft -= gasStatus.GasUsed
+		if gasLeft-gasUsed < 0 {
+			break
+		}
+
+		for _, txD := range pkg.Txs {
+			results = append(results, &validateTxResult{tx: txD.Tx})
+		}
+		gasLeft -= gasUsed
 	}
 	return results, gasLeft
 }
@@ -0,0 +1,49 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ErrAddressIndexDisabled is returned when the chain store doesn't maintain the address index
+var ErrAddressIndexDisabled = errors.New("address index is not enabled on the node")
+
+func (c *Chain) addressIndexStore() (state.AddressIndexStore, error) {
+	store, ok := c.store.(state.AddressIndexStore)
+	if !ok || !store.AddressIndexEnabled() {
+		return nil, ErrAddressIndexDisabled
+	}
+	return store, nil
+}
+
+// AddressIndexStart return the height of the first block in the address index, the
+// transactions and utxos of the lower blocks are not returned by the address queries
+func (c *Chain) AddressIndexStart() (uint64, error) {
+	store, err := c.addressIndexStore()
+	if err != nil {
+		return 0, err
+	}
+	return store.AddressIndexStart(), nil
+}
+
+// GetAddressHistory return at most count main chain transactions related to the control
+// program after the cursor, and the cursor of the last returned one
+func (c *Chain) GetAddressHistory(program []byte, after string, count int) ([]*state.AddressTx, string, error) {
+	store, err := c.addressIndexStore()
+	if err != nil {
+		return nil, "", err
+	}
+	return store.GetAddressHistory(program, after, count)
+}
+
+// GetAddressUtxos return the main chain unspent outputs locked by the control program,
+// only the ones of the asset are returned when the asset id is not nil
+func (c *Chain) GetAddressUtxos(program []byte, assetID *bc.AssetID) ([]*state.AddressUtxo, error) {
+	store, err := c.addressIndexStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.GetAddressUtxos(program, assetID)
+}
@@ -265,11 +265,13 @@ func (m *ValueDestination) GetPosition() uint64 {
 }
 
 type BlockHeader struct {
-	Version          uint64 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
-	Height           uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
-	PreviousBlockId  *Hash  `protobuf:"bytes,3,opt,name=previous_block_id,json=previousBlockId" json:"previous_block_id,omitempty"`
-	Timestamp        uint64 `protobuf:"varint,4,opt,name=timestamp" json:"timestamp,omitempty"`
-	TransactionsRoot *Hash  `protobuf:"bytes,5,opt,name=transactions_root,json=transactionsRoot" json:"transactions_root,omitempty"`
+	Version            uint64 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	Height             uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	PreviousBlockId    *Hash  `protobuf:"bytes,3,opt,name=previous_block_id,json=previousBlockId" json:"previous_block_id,omitempty"`
+	Timestamp          uint64 `protobuf:"varint,4,opt,name=timestamp" json:"timestamp,omitempty"`
+	TransactionsRoot   *Hash  `protobuf:"bytes,5,opt,name=transactions_root,json=transactionsRoot" json:"transactions_root,omitempty"`
+	SlashEvidencesRoot *Hash  `protobuf:"bytes,6,opt,name=slash_evidences_root,json=slashEvidencesRoot" json:"slash_evidences_root,omitempty"`
+	ParamProposalsRoot *Hash  `protobuf:"bytes,7,opt,name=param_proposals_root,json=paramProposalsRoot" json:"param_proposals_root,omitempty"`
 }
 
 func (m *BlockHeader) Reset()                    { *m = BlockHeader{} }
@@ -312,6 +314,20 @@ func (m *BlockHeader) GetTransactionsRoot() *Hash {
 	return nil
 }
 
+func (m *BlockHeader) GetSlashEvidencesRoot() *Hash {
+	if m != nil {
+		return m.SlashEvidencesRoot
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetParamProposalsRoot() *Hash {
+	if m != nil {
+		return m.ParamProposalsRoot
+	}
+	return nil
+}
+
 type TxHeader struct {
 	Version        uint64  `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
 	SerializedSize uint64  `protobuf:"varint,2,opt,name=serialized_size,json=serializedSize" json:"serialized_size,omitempty"`
@@ -687,59 +703,61 @@ func init() {
 func init() { proto.RegisterFile("bc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 852 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xd6, 0xda, 0xeb, 0xbf, 0xe3, 0x10, 0x27, 0x93, 0xaa, 0x5a, 0x55, 0x45, 0xaa, 0x56, 0x2a,
-	0x01, 0x21, 0x45, 0xa9, 0x53, 0xb8, 0xe2, 0x26, 0x10, 0xa0, 0xbe, 0x88, 0x82, 0x26, 0x28, 0xb7,
-	0xab, 0xf1, 0xee, 0xd4, 0x1e, 0x61, 0xcf, 0x2c, 0x33, 0xb3, 0x4b, 0xc9, 0x7b, 0xf0, 0x0e, 0xbc,
-	0x01, 0xbc, 0x01, 0x12, 0x88, 0x77, 0x42, 0x73, 0x76, 0xd6, 0xeb, 0xbf, 0xd2, 0x56, 0x08, 0x21,
-	0xee, 0x7c, 0x7e, 0xf6, 0x9c, 0xef, 0x7c, 0xe7, 0x9b, 0x19, 0x43, 0x7f, 0x9a, 0x9e, 0xe5, 0x5a,
-	0x59, 0x45, 0x5a, 0xd3, 0x34, 0xfe, 0x0a, 0xc2, 0x17, 0xcc, 0xcc, 0xc9, 0x21, 0xb4, 0xca, 0xf3,
-	0x28, 0x78, 0x12, 0x7c, 0xd8, 0xa5, 0xad, 0xf2, 0x1c, 0xed, 0x67, 0x51, 0xcb, 0xdb, 0xcf, 0xd0,
-	0x1e, 0x47, 0x6d, 0x6f, 0x8f, 0xd1, 0xbe, 0x88, 0x42, 0x6f, 0x5f, 0xc4, 0x9f, 0x41, 0xef, 0x1b,
-	0xad, 0x66, 0x9a, 0x2d, 0xc9, 0xfb, 0x00, 0xe5, 0x32, 0x29, 0xb9, 0x36, 0x42, 0x49, 0x2c, 0x19,
-	0xd2, 0x41, 0xb9, 0xbc, 0xab, 0x1c, 0x84, 0x40, 0x98, 0xaa, 0x8c, 0x63, 0xed, 0x03, 0x8a, 0xbf,
-	0xe3, 0x09, 0xf4, 0x2e, 0x8d, 0xe1, 0x76, 0x72, 0xf5, 0x8f, 0x81, 0x5c, 0xc3, 0x10, 0x4b, 0x5d,
-	0x2e, 0x55, 0x21, 0x2d, 0xf9, 0x00, 0xfa, 0xcc, 0x99, 0x89, 0xc8, 0xb0, 0xe8, 0x70, 0x3c, 0x3c,
-	0x9b, 0xa6, 0x67, 0xbe, 0x1b, 0xed, 0x61, 0x70, 0x92, 0x91, 0x87, 0xd0, 0x65, 0xf8, 0x05, 0xb6,
-	0x0a, 0xa9, 0xb7, 0xe2, 0x19, 0x8c, 0x30, 0xf7, 0x8a, 0xbf, 0x14, 0x52, 0x58, 0x37, 0xc0, 0xa7,
-	0x70, 0x24, 0x8c, 0x29, 0x98, 0x4c, 0x79, 0x92, 0x57, 0x33, 0xaf, 0x97, 0xf6, 0x34, 0xd0, 0x51,
-	0x9d, 0x54, 0xf3, 0xf2, 0x18, 0xc2, 0x8c, 0x59, 0x86, 0x0d, 0x86, 0xe3, 0xbe, 0xcb, 0x75, 0xd4,
-	0x53, 0xf4, 0xc6, 0x0b, 0x18, 0xde, 0xb1, 0x45, 0xc1, 0x6f, 0x55, 0xa1, 0x53, 0x4e, 0x1e, 0x41,
-	0x5b, 0xf3, 0x97, 0xbe, 0x6e, 0x93, 0xeb, 0x9c, 0xe4, 0x29, 0x74, 0x4a, 0x97, 0xea, 0x2b, 0x8d,
-	0x56, 0x03, 0x55, 0x33, 0xd3, 0x2a, 0x4a, 0x1e, 0x41, 0x3f, 0x57, 0x06, 0x31, 0x23, 0x5f, 0x21,
-	0x5d, 0xd9, 0xf1, 0xf7, 0x70, 0x84, 0xdd, 0xae, 0xb8, 0xb1, 0x42, 0x32, 0x9c, 0xeb, 0x5f, 0x6e,
-	0xf9, 0x7b, 0x00, 0xc3, 0xcf, 0x17, 0x2a, 0xfd, 0xee, 0x05, 0x67, 0x19, 0xd7, 0x24, 0x82, 0xde,
-	0xa6, 0x46, 0x6a, 0xd3, 0xed, 0x62, 0xce, 0xc5, 0x6c, 0xbe, 0xda, 0x45, 0x65, 0x91, 0xe7, 0x70,
-	0x9c, 0x6b, 0x5e, 0x0a, 0x55, 0x98, 0x64, 0xea, 0x2a, 0xb9, 0xa5, 0xb6, 0xb7, 0xe0, 0x8e, 0xea,
-	0x14, 0xec, 0x35, 0xc9, 0xc8, 0x63, 0x18, 0x58, 0xb1, 0xe4, 0xc6, 0xb2, 0x65, 0x8e, 0x3a, 0x09,
-	0x69, 0xe3, 0x20, 0x9f, 0xc0, 0xb1, 0xd5, 0x4c, 0x1a, 0x96, 0x3a, 0x90, 0x26, 0xd1, 0x4a, 0xd9,
-	0xa8, 0xb3, 0x55, 0xf3, 0x68, 0x3d, 0x85, 0x2a, 0x65, 0xe3, 0x9f, 0x02, 0xe8, 0x7f, 0xfb, 0xea,
-	0x8d, 0x93, 0x9c, 0xc2, 0xc8, 0x70, 0x2d, 0xd8, 0x42, 0xdc, 0xf3, 0x2c, 0x31, 0xe2, 0x9e, 0xfb,
-	0x91, 0x0e, 0x1b, 0xf7, 0xad, 0xb8, 0xe7, 0xee, 0xcc, 0x38, 0x4c, 0x89, 0x66, 0x72, 0xc6, 0x3d,
-	0x75, 0x88, 0x92, 0x3a, 0x07, 0x39, 0x05, 0xd0, 0xdc, 0x14, 0x0b, 0x27, 0x63, 0x13, 0x85, 0x4f,
-	0xda, 0x1b, 0xf0, 0x06, 0x55, 0x6c, 0x92, 0x99, 0xf8, 0xcf, 0x00, 0xda, 0xd7, 0xc5, 0x2b, 0xf2,
-	0x11, 0xf4, 0x0c, 0x0a, 0xc9, 0x44, 0x01, 0x66, 0xe3, 0xc6, 0xd6, 0x04, 0x46, 0xeb, 0x38, 0x79,
-	0x0a, 0xbd, 0x5a, 0xc5, 0xad, 0x5d, 0x15, 0xd7, 0x31, 0xf2, 0x35, 0x3c, 0xf8, 0x41, 0x58, 0xc9,
-	0x8d, 0x49, 0xb2, 0x46, 0x34, 0x26, 0x6a, 0x63, 0xf9, 0x07, 0xab, 0xf2, 0x6b, 0x8a, 0xa2, 0x27,
-	0xfe, 0x8b, 0x35, 0x9f, 0x21, 0x1f, 0xc3, 0x71, 0x5d, 0x88, 0xe9, 0x59, 0xb1, 0xe4, 0xd2, 0x56,
-	0x23, 0x1d, 0xd0, 0x23, 0x1f, 0xb8, 0xac, 0xfd, 0xb1, 0x82, 0xfe, 0x17, 0x4a, 0xc8, 0x29, 0x33,
-	0x9c, 0x7c, 0x09, 0x27, 0x7b, 0x10, 0x78, 0xbd, 0xee, 0x07, 0x40, 0x76, 0x01, 0x38, 0x3d, 0x30,
-	0x3d, 0x15, 0x56, 0x33, 0xfd, 0xa3, 0xbf, 0x84, 0x1a, 0x47, 0xfc, 0x73, 0x00, 0x87, 0x37, 0x5a,
-	0xcc, 0x84, 0x64, 0x8b, 0x9b, 0xc2, 0xe6, 0x85, 0x25, 0xa7, 0xd0, 0xad, 0xb8, 0xf2, 0xad, 0x76,
-	0xa8, 0xf4, 0x61, 0xf2, 0x1c, 0x46, 0xa9, 0x92, 0x56, 0xab, 0x45, 0xf2, 0x37, 0x8c, 0x1e, 0xfa,
-	0x9c, 0xfa, 0x5a, 0x88, 0xa0, 0xa7, 0x74, 0xe6, 0xfa, 0xf9, 0xbd, 0xd7, 0xa6, 0x13, 0x85, 0xb1,
-	0xcc, 0xf2, 0x04, 0xaf, 0x8d, 0x8a, 0xa2, 0x01, 0x7a, 0xae, 0xdc, 0x8d, 0xf1, 0x6b, 0x00, 0x70,
-	0xa7, 0x2c, 0xff, 0xaf, 0x61, 0x12, 0x08, 0x4b, 0x65, 0x39, 0x9e, 0xad, 0x03, 0x8a, 0xbf, 0xb7,
-	0xa0, 0x77, 0xb6, 0xa1, 0xff, 0x11, 0xc0, 0xe0, 0x8e, 0x5b, 0x35, 0x91, 0x0e, 0xf9, 0x39, 0x8c,
-	0x4c, 0xce, 0xa5, 0x4d, 0x14, 0x4e, 0xd2, 0x5c, 0xd5, 0x8d, 0xc4, 0xdf, 0xc3, 0x84, 0x6a, 0xd2,
-	0x49, 0xf6, 0x3a, 0x29, 0xb4, 0xde, 0x51, 0x0a, 0x7b, 0xa5, 0xd8, 0xde, 0x2f, 0xc5, 0x75, 0x02,
-	0xc2, 0x0d, 0x02, 0xe2, 0x1b, 0x00, 0xca, 0xad, 0xd0, 0xdc, 0x25, 0xbe, 0xfd, 0x1e, 0xd6, 0x0a,
-	0xb6, 0x36, 0x0b, 0xfe, 0xd2, 0x82, 0xfe, 0xc4, 0xbf, 0x1e, 0xee, 0xec, 0x4b, 0xe5, 0xde, 0x9a,
-	0x39, 0x33, 0xf3, 0x1d, 0x62, 0x06, 0x18, 0xc3, 0x27, 0xfc, 0x2d, 0xef, 0xe8, 0xd7, 0x70, 0xd7,
-	0x7e, 0x47, 0xee, 0xae, 0x21, 0x5a, 0x71, 0x87, 0x0f, 0x6c, 0xb6, 0x7a, 0x21, 0x91, 0x9f, 0xe1,
-	0xf8, 0x64, 0x05, 0xa0, 0x79, 0x3c, 0xe9, 0xc3, 0x9a, 0xd7, 0xad, 0x47, 0x75, 0xef, 0x2a, 0x3a,
-	0x6f, 0x5e, 0x45, 0x77, 0x93, 0xb9, 0xdf, 0x02, 0xe8, 0xdc, 0xe6, 0x5c, 0x66, 0xff, 0x77, 0x51,
-	0x4d, 0xbb, 0xf8, 0x1f, 0xed, 0xe2, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8e, 0xe5, 0xe4, 0x4f,
-	0xaf, 0x09, 0x00, 0x00,
+	// 893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xcb, 0x6e, 0x1c, 0x45,
+	0x14, 0x55, 0x3f, 0x3c, 0x8f, 0x3b, 0xc6, 0x63, 0x97, 0xad, 0xa8, 0x15, 0x05, 0xc9, 0x6a, 0x29,
+	0x18, 0x84, 0x64, 0x39, 0xe3, 0xc0, 0x02, 0xb1, 0x31, 0x38, 0x90, 0x59, 0x58, 0x8e, 0xca, 0xc8,
+	0xdb, 0x56, 0x4d, 0x77, 0x65, 0xa6, 0x44, 0x4f, 0x57, 0x53, 0x55, 0xdd, 0x04, 0xff, 0x07, 0xff,
+	0xc0, 0x1f, 0xc0, 0x9a, 0x0d, 0x0b, 0xc4, 0x3f, 0xa1, 0xba, 0x5d, 0x3d, 0x2f, 0x4f, 0x48, 0x22,
+	0x84, 0x50, 0x76, 0x73, 0xef, 0x3d, 0xf7, 0x75, 0xea, 0x54, 0xd7, 0x40, 0x6f, 0x92, 0x9e, 0x96,
+	0x4a, 0x1a, 0x49, 0xfc, 0x49, 0x1a, 0x7f, 0x03, 0xe1, 0x73, 0xa6, 0x67, 0x64, 0x0f, 0xfc, 0xfa,
+	0x2c, 0xf2, 0x8e, 0xbd, 0x8f, 0x3b, 0xd4, 0xaf, 0xcf, 0xd0, 0x7e, 0x12, 0xf9, 0xce, 0x7e, 0x82,
+	0xf6, 0x28, 0x0a, 0x9c, 0x3d, 0x42, 0xfb, 0x3c, 0x0a, 0x9d, 0x7d, 0x1e, 0x7f, 0x09, 0xdd, 0x17,
+	0x4a, 0x4e, 0x15, 0x9b, 0x93, 0x0f, 0x01, 0xea, 0x79, 0x52, 0x73, 0xa5, 0x85, 0x2c, 0xb0, 0x64,
+	0x48, 0xfb, 0xf5, 0xfc, 0xb6, 0x71, 0x10, 0x02, 0x61, 0x2a, 0x33, 0x8e, 0xb5, 0x77, 0x29, 0xfe,
+	0x8e, 0xc7, 0xd0, 0xbd, 0xd0, 0x9a, 0x9b, 0xf1, 0xe5, 0xbf, 0x1e, 0xe4, 0x0a, 0x06, 0x58, 0xea,
+	0x62, 0x2e, 0xab, 0xc2, 0x90, 0x8f, 0xa0, 0xc7, 0xac, 0x99, 0x88, 0x0c, 0x8b, 0x0e, 0x46, 0x83,
+	0xd3, 0x49, 0x7a, 0xea, 0xba, 0xd1, 0x2e, 0x06, 0xc7, 0x19, 0x79, 0x00, 0x1d, 0x86, 0x19, 0xd8,
+	0x2a, 0xa4, 0xce, 0x8a, 0xa7, 0x30, 0x44, 0xec, 0x25, 0x7f, 0x29, 0x0a, 0x61, 0xec, 0x02, 0x9f,
+	0xc3, 0xbe, 0xd0, 0xba, 0x62, 0x45, 0xca, 0x93, 0xb2, 0xd9, 0x79, 0xb5, 0xb4, 0xa3, 0x81, 0x0e,
+	0x5b, 0x50, 0xcb, 0xcb, 0x23, 0x08, 0x33, 0x66, 0x18, 0x36, 0x18, 0x8c, 0x7a, 0x16, 0x6b, 0xa9,
+	0xa7, 0xe8, 0x8d, 0x73, 0x18, 0xdc, 0xb2, 0xbc, 0xe2, 0x37, 0xb2, 0x52, 0x29, 0x27, 0x0f, 0x21,
+	0x50, 0xfc, 0x65, 0xe4, 0x6d, 0x60, 0xad, 0x93, 0x3c, 0x86, 0x9d, 0xda, 0x42, 0x5d, 0xa5, 0xe1,
+	0x62, 0xa1, 0x66, 0x67, 0xda, 0x44, 0xc9, 0x43, 0xe8, 0x95, 0x52, 0xe3, 0xcc, 0xc8, 0x57, 0x48,
+	0x17, 0x76, 0xfc, 0x03, 0xec, 0x63, 0xb7, 0x4b, 0xae, 0x8d, 0x28, 0x18, 0xee, 0xf5, 0x1f, 0xb7,
+	0xfc, 0xdd, 0x87, 0xc1, 0x57, 0xb9, 0x4c, 0xbf, 0x7f, 0xce, 0x59, 0xc6, 0x15, 0x89, 0xa0, 0xbb,
+	0xae, 0x91, 0xd6, 0xb4, 0x67, 0x31, 0xe3, 0x62, 0x3a, 0x5b, 0x9c, 0x45, 0x63, 0x91, 0xa7, 0x70,
+	0x50, 0x2a, 0x5e, 0x0b, 0x59, 0xe9, 0x64, 0x62, 0x2b, 0xd9, 0x43, 0x0d, 0x36, 0xc6, 0x1d, 0xb6,
+	0x10, 0xec, 0x35, 0xce, 0xc8, 0x23, 0xe8, 0x1b, 0x31, 0xe7, 0xda, 0xb0, 0x79, 0x89, 0x3a, 0x09,
+	0xe9, 0xd2, 0x41, 0x3e, 0x83, 0x03, 0xa3, 0x58, 0xa1, 0x59, 0x6a, 0x87, 0xd4, 0x89, 0x92, 0xd2,
+	0x44, 0x3b, 0x1b, 0x35, 0xf7, 0x57, 0x21, 0x54, 0x4a, 0x43, 0xbe, 0x80, 0x23, 0x9d, 0x33, 0x3d,
+	0x4b, 0x78, 0x2d, 0x32, 0x5e, 0xa4, 0xdc, 0x65, 0x76, 0x36, 0x32, 0x09, 0xa2, 0x9e, 0xb5, 0xa0,
+	0x36, 0xb7, 0x64, 0x8a, 0xcd, 0xad, 0x78, 0x4a, 0xa9, 0x59, 0xee, 0x72, 0xbb, 0x9b, 0xb9, 0x88,
+	0x7a, 0xd1, 0x82, 0x6c, 0x6e, 0xfc, 0xb3, 0x07, 0xbd, 0xef, 0x5e, 0xbd, 0x91, 0xc1, 0x13, 0x18,
+	0x6a, 0xae, 0x04, 0xcb, 0xc5, 0x1d, 0xcf, 0x12, 0x2d, 0xee, 0xb8, 0xa3, 0x72, 0x6f, 0xe9, 0xbe,
+	0x11, 0x77, 0xdc, 0xde, 0x55, 0xcb, 0x45, 0xa2, 0x58, 0x31, 0xe5, 0x51, 0xb0, 0x64, 0x87, 0x5a,
+	0x07, 0x39, 0x01, 0x50, 0x5c, 0x57, 0xb9, 0xbd, 0x3e, 0x3a, 0x0a, 0x8f, 0x83, 0xb5, 0x01, 0xfb,
+	0x4d, 0x6c, 0x9c, 0xe9, 0xf8, 0x2f, 0x0f, 0x82, 0xab, 0xea, 0x15, 0xf9, 0x04, 0xba, 0x1a, 0x05,
+	0xac, 0x23, 0xef, 0x38, 0x68, 0x95, 0xb2, 0x22, 0x6c, 0xda, 0xc6, 0xc9, 0x63, 0xe8, 0xb6, 0xb7,
+	0xc7, 0xbf, 0x7f, 0x7b, 0xda, 0x18, 0xf9, 0x16, 0x8e, 0x7e, 0x14, 0xa6, 0xe0, 0x5a, 0x27, 0xd9,
+	0x52, 0xac, 0x3a, 0x0a, 0xb0, 0xfc, 0xd1, 0xa2, 0xfc, 0x8a, 0x92, 0xe9, 0xa1, 0xcb, 0x58, 0xf1,
+	0x69, 0xf2, 0x29, 0x1c, 0xb4, 0x85, 0x98, 0x9a, 0x56, 0x73, 0x5e, 0x98, 0x66, 0xa5, 0x5d, 0xba,
+	0xef, 0x02, 0x17, 0xad, 0x3f, 0x96, 0xd0, 0xfb, 0x5a, 0x8a, 0x62, 0xc2, 0x34, 0x27, 0xcf, 0xe0,
+	0x70, 0xcb, 0x04, 0xee, 0x9e, 0x6c, 0x1f, 0x80, 0xdc, 0x1f, 0xc0, 0xea, 0x90, 0xa9, 0x89, 0x30,
+	0x8a, 0xa9, 0x9f, 0xdc, 0xc7, 0x6f, 0xe9, 0x88, 0x7f, 0xf1, 0x60, 0xef, 0x5a, 0x89, 0xa9, 0x28,
+	0x58, 0x7e, 0x5d, 0x99, 0xb2, 0x32, 0xe4, 0x04, 0x3a, 0x0d, 0x57, 0xae, 0xd5, 0x3d, 0x2a, 0x5d,
+	0x98, 0x3c, 0x85, 0x61, 0x2a, 0x0b, 0xa3, 0x64, 0x9e, 0xfc, 0x03, 0xa3, 0x7b, 0x0e, 0xe3, 0x6c,
+	0xab, 0x1e, 0xa9, 0x32, 0xdb, 0xcf, 0x9d, 0x7b, 0x6b, 0x5a, 0x51, 0x68, 0xc3, 0x0c, 0x4f, 0xf0,
+	0x73, 0xd5, 0x50, 0xd4, 0x47, 0xcf, 0xa5, 0xfd, 0x52, 0xfd, 0xe6, 0x01, 0xdc, 0x4a, 0xc3, 0xff,
+	0xef, 0x31, 0x09, 0x84, 0xb5, 0x34, 0x1c, 0xef, 0xf4, 0x2e, 0xc5, 0xdf, 0x1b, 0xa3, 0xef, 0x6c,
+	0x8e, 0xfe, 0xa7, 0x07, 0xfd, 0x5b, 0x6e, 0xe4, 0xb8, 0xb0, 0x93, 0x9f, 0xc1, 0x50, 0x97, 0xbc,
+	0x30, 0x89, 0xc4, 0x4d, 0x96, 0x4f, 0xc4, 0x52, 0xe2, 0x1f, 0x20, 0xa0, 0xd9, 0x74, 0x9c, 0xbd,
+	0x4e, 0x0a, 0xfe, 0x3b, 0x4a, 0x61, 0xab, 0x14, 0x83, 0xed, 0x52, 0x5c, 0x25, 0x20, 0x5c, 0x23,
+	0x20, 0xbe, 0x06, 0xa0, 0xdc, 0x08, 0xc5, 0x2d, 0xf0, 0xed, 0xcf, 0x61, 0xa5, 0xa0, 0xbf, 0x5e,
+	0xf0, 0x57, 0x1f, 0x7a, 0x63, 0xf7, 0x6a, 0xd9, 0xbb, 0x5f, 0x48, 0xfb, 0xc6, 0xcd, 0x98, 0x9e,
+	0xdd, 0x23, 0xa6, 0x8f, 0x31, 0xfb, 0xf3, 0x6d, 0xdf, 0x86, 0xd7, 0x70, 0x17, 0xbc, 0x23, 0x77,
+	0x57, 0x10, 0x2d, 0xb8, 0xc3, 0x87, 0x3d, 0x5b, 0xbc, 0xcc, 0xc8, 0xcf, 0x60, 0x74, 0xb8, 0x18,
+	0x60, 0xf9, 0x68, 0xd3, 0x07, 0x2d, 0xaf, 0xeb, 0xfe, 0xed, 0x47, 0xb1, 0xf3, 0xe6, 0xa3, 0xe8,
+	0xac, 0x33, 0xf7, 0x87, 0x07, 0x3b, 0x37, 0x25, 0x2f, 0xb2, 0xf7, 0x5d, 0x54, 0x93, 0x0e, 0xfe,
+	0x37, 0x3c, 0xff, 0x7b, 0x00, 0x63, 0x40, 0x1b, 0x36, 0x27, 0x0a, 0x00, 0x00,
 }
@@ -11,16 +11,25 @@ func (bh *BlockHeader) writeForHash(w io.Writer) {
 	mustWriteForHash(w, bh.PreviousBlockId)
 	mustWriteForHash(w, bh.Timestamp)
 	mustWriteForHash(w, bh.TransactionsRoot)
+	// the headers without slash evidences and param proposals keep the origin hash
+	if bh.SlashEvidencesRoot.IsZero() && bh.ParamProposalsRoot.IsZero() {
+		return
+	}
+
+	mustWriteForHash(w, bh.SlashEvidencesRoot)
+	mustWriteForHash(w, bh.ParamProposalsRoot)
 }
 
 // NewBlockHeader creates a new BlockHeader and populates
 // its body.
-func NewBlockHeader(version, height uint64, previousBlockID *Hash, timestamp uint64, transactionsRoot *Hash) *BlockHeader {
+func NewBlockHeader(version, height uint64, previousBlockID *Hash, timestamp uint64, transactionsRoot, slashEvidencesRoot, paramProposalsRoot *Hash) *BlockHeader {
 	return &BlockHeader{
-		Version:          version,
-		Height:           height,
-		PreviousBlockId:  previousBlockID,
-		Timestamp:        timestamp,
-		TransactionsRoot: transactionsRoot,
+		Version:            version,
+		Height:             height,
+		PreviousBlockId:    previousBlockID,
+		Timestamp:          timestamp,
+		TransactionsRoot:   transactionsRoot,
+		SlashEvidencesRoot: slashEvidencesRoot,
+		ParamProposalsRoot: paramProposalsRoot,
 	}
 }
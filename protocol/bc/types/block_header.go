@@ -20,6 +20,8 @@ type BlockHeader struct {
 	Timestamp         uint64  // The time of the block in seconds.
 	BlockWitness
 	SupLinks
+	SlashEvidences
+	ParamProposals
 	BlockCommitment
 }
 
@@ -117,7 +119,7 @@ func (bh *BlockHeader) readFrom(r *blockchain.Reader) (serflag uint8, err error)
 		return 0, err
 	}
 
-	if _, err = blockchain.ReadExtensibleString(r, bh.SupLinks.readFrom); err != nil {
+	if _, err = blockchain.ReadExtensibleString(r, bh.readSupLinksFrom); err != nil {
 		return 0, err
 	}
 
@@ -154,9 +156,51 @@ func (bh *BlockHeader) writeTo(w io.Writer, serflags uint8) (err error) {
 		return err
 	}
 
-	if _, err = blockchain.WriteExtensibleString(w, nil, bh.SupLinks.writeTo); err != nil {
+	if _, err = blockchain.WriteExtensibleString(w, nil, bh.writeSupLinksTo); err != nil {
 		return err
 	}
 
 	return
 }
+
+// readSupLinksFrom read the sup links and the slash evidences and param proposals appended
+// to them, the blocks without slash evidences and param proposals keep the origin serialization
+func (bh *BlockHeader) readSupLinksFrom(r *blockchain.Reader) error {
+	if err := bh.SupLinks.readFrom(r); err != nil {
+		return err
+	}
+
+	if r.Len() == 0 {
+		return nil
+	}
+
+	if err := bh.SlashEvidences.readFrom(r); err != nil {
+		return err
+	}
+
+	if r.Len() == 0 {
+		return nil
+	}
+
+	return bh.ParamProposals.readFrom(r)
+}
+
+func (bh *BlockHeader) writeSupLinksTo(w io.Writer) error {
+	if err := bh.SupLinks.writeTo(w); err != nil {
+		return err
+	}
+
+	if len(bh.SlashEvidences) == 0 && len(bh.ParamProposals) == 0 {
+		return nil
+	}
+
+	if err := bh.SlashEvidences.writeTo(w); err != nil {
+		return err
+	}
+
+	if len(bh.ParamProposals) == 0 {
+		return nil
+	}
+
+	return bh.ParamProposals.writeTo(w)
+}
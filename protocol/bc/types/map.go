@@ -224,8 +224,12 @@ func MapTx(txData *TxData) *bc.Tx {
 	return mh.generateTx()
 }
 
+// mapBlockHeader commits the slash evidences and param proposals by their merkle
+// roots, so that the block hash and the signature of the proposer cover them
 func mapBlockHeader(old *BlockHeader) (bc.Hash, *bc.BlockHeader) {
-	bh := bc.NewBlockHeader(old.Version, old.Height, &old.PreviousBlockHash, old.Timestamp, &old.TransactionsMerkleRoot)
+	slashEvidencesRoot, _ := SlashEvidencesMerkleRoot(old.SlashEvidences)
+	paramProposalsRoot, _ := ParamProposalsMerkleRoot(old.ParamProposals)
+	bh := bc.NewBlockHeader(old.Version, old.Height, &old.PreviousBlockHash, old.Timestamp, &old.TransactionsMerkleRoot, &slashEvidencesRoot, &paramProposalsRoot)
 	return bc.EntryID(bh), bh
 }
 
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/fatih/set.v0"
 
 	"github.com/bytom/bytom/crypto/sha3pool"
+	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
 )
 
@@ -298,6 +299,45 @@ func TxMerkleRoot(transactions []*bc.Tx) (root bc.Hash, err error) {
 	return merkleRoot(nodes)
 }
 
+// SlashEvidencesMerkleRoot creates a merkle tree from a slice of slash evidences and returns
+// the root hash of the tree, the root of no evidences is the zero hash.
+func SlashEvidencesMerkleRoot(evidences []*SlashEvidence) (root bc.Hash, err error) {
+	if len(evidences) == 0 {
+		return bc.Hash{}, nil
+	}
+
+	nodes := []merkleNode{}
+	for _, evidence := range evidences {
+		nodes = append(nodes, serializedNode(evidence.writeTo))
+	}
+	return merkleRoot(nodes)
+}
+
+// ParamProposalsMerkleRoot creates a merkle tree from a slice of param proposals and returns
+// the root hash of the tree, the root of no proposals is the zero hash.
+func ParamProposalsMerkleRoot(proposals []*ParamProposal) (root bc.Hash, err error) {
+	if len(proposals) == 0 {
+		return bc.Hash{}, nil
+	}
+
+	nodes := []merkleNode{}
+	for _, proposal := range proposals {
+		nodes = append(nodes, serializedNode(proposal.writeTo))
+	}
+	return merkleRoot(nodes)
+}
+
+// serializedNode is a merkle leaf represented by its serialization
+type serializedNode func(io.Writer) error
+
+func (s serializedNode) WriteTo(w io.Writer) (int64, error) {
+	ew := errors.NewWriter(w)
+	if err := s(ew); err != nil {
+		return 0, err
+	}
+	return ew.Written(), ew.Err()
+}
+
 // prevPowerOfTwo returns the largest power of two that is smaller than a given number.
 // In other words, for some input n, the prevPowerOfTwo k is a power of two such that
 // k < n <= 2k. This is a helper function used during the calculation of a merkle tree.
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"io"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/encoding/blockchain"
+)
+
+// ParamProposals is alias of ParamProposal slice
+type ParamProposals []*ParamProposal
+
+func (p *ParamProposals) readFrom(r *blockchain.Reader) (err error) {
+	size, err := blockchain.ReadVarint31(r)
+	if err != nil {
+		return err
+	}
+
+	proposals := make([]*ParamProposal, size)
+	for i := 0; i < int(size); i++ {
+		proposal := &ParamProposal{}
+		if err := proposal.readFrom(r); err != nil {
+			return err
+		}
+
+		proposals[i] = proposal
+	}
+	*p = proposals
+	return nil
+}
+
+func (p ParamProposals) writeTo(w io.Writer) error {
+	if _, err := blockchain.WriteVarint31(w, uint64(len(p))); err != nil {
+		return err
+	}
+
+	for _, proposal := range p {
+		if err := proposal.writeTo(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParamProposal propose to change the consensus parameter from the activate height,
+// the signatures are placed by the order of the validators who signed it
+type ParamProposal struct {
+	Key            string
+	Value          uint64
+	ActivateHeight uint64
+	Signatures     [consensus.MaxNumOfValidators][]byte
+}
+
+// IsMajority if at least 2/3 of validators have signed the proposal
+func (p *ParamProposal) IsMajority(numOfValidators int) bool {
+	numOfSignatures := 0
+	for _, signature := range p.Signatures {
+		if len(signature) > 0 {
+			numOfSignatures++
+		}
+	}
+	return numOfSignatures > numOfValidators*2/3
+}
+
+func (p *ParamProposal) readFrom(r *blockchain.Reader) (err error) {
+	key, err := blockchain.ReadVarstr31(r)
+	if err != nil {
+		return err
+	}
+
+	p.Key = string(key)
+	if p.Value, err = blockchain.ReadVarint63(r); err != nil {
+		return err
+	}
+
+	if p.ActivateHeight, err = blockchain.ReadVarint63(r); err != nil {
+		return err
+	}
+
+	for i := 0; i < consensus.MaxNumOfValidators; i++ {
+		if p.Signatures[i], err = blockchain.ReadVarstr31(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *ParamProposal) writeTo(w io.Writer) error {
+	if _, err := blockchain.WriteVarstr31(w, []byte(p.Key)); err != nil {
+		return err
+	}
+
+	if _, err := blockchain.WriteVarint63(w, p.Value); err != nil {
+		return err
+	}
+
+	if _, err := blockchain.WriteVarint63(w, p.ActivateHeight); err != nil {
+		return err
+	}
+
+	for _, signature := range p.Signatures {
+		if _, err := blockchain.WriteVarstr31(w, signature); err != nil {
+			return err
+		}
+	}
+	return nil
+}
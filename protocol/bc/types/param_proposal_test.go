@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestBlockHashCommitsParamProposals(t *testing.T) {
+	blockHeader := &BlockHeader{
+		Version:           1,
+		Height:            200,
+		PreviousBlockHash: testutil.MustDecodeHash("c34048bd60c4c13144fd34f408627d1be68f6cb4fdd34e879d6d791060ea73a0"),
+		Timestamp:         1522908275,
+		SlashEvidences:    SlashEvidences{{PubKey: testutil.MustDecodeHexString("0a0b")}},
+	}
+	originHash := blockHeader.Hash()
+
+	blockHeader.ParamProposals = ParamProposals{{Key: "max_block_gas", Value: 20000000, ActivateHeight: 300}}
+	proposalHash := blockHeader.Hash()
+	if proposalHash == originHash {
+		t.Fatal("the block hash doesn't commit to the param proposals")
+	}
+
+	blockHeader.ParamProposals[0].Value = 30000000
+	if blockHeader.Hash() == proposalHash {
+		t.Fatal("the block hash doesn't commit to the content of the param proposals")
+	}
+
+	blockHeader.ParamProposals = nil
+	if blockHeader.Hash() != originHash {
+		t.Fatal("the block hash without param proposals should keep the hash of the other fields")
+	}
+}
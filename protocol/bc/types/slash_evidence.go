@@ -0,0 +1,127 @@
+package types
+
+import (
+	"io"
+
+	"github.com/bytom/bytom/encoding/blockchain"
+	"github.com/bytom/bytom/protocol/bc"
+)
+
+// SlashEvidences is alias of SlashEvidence slice
+type SlashEvidences []*SlashEvidence
+
+func (s *SlashEvidences) readFrom(r *blockchain.Reader) (err error) {
+	size, err := blockchain.ReadVarint31(r)
+	if err != nil {
+		return err
+	}
+
+	evidences := make([]*SlashEvidence, size)
+	for i := 0; i < int(size); i++ {
+		evidence := &SlashEvidence{}
+		if err := evidence.readFrom(r); err != nil {
+			return err
+		}
+
+		evidences[i] = evidence
+	}
+	*s = evidences
+	return nil
+}
+
+func (s SlashEvidences) writeTo(w io.Writer) error {
+	if _, err := blockchain.WriteVarint31(w, uint64(len(s))); err != nil {
+		return err
+	}
+
+	for _, evidence := range s {
+		if err := evidence.writeTo(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SlashEvidence proves that the validator signed two conflicting verifications,
+// the block includes it to slash the validator
+type SlashEvidence struct {
+	PubKey []byte
+	First  SignedLink
+	Second SignedLink
+}
+
+func (s *SlashEvidence) readFrom(r *blockchain.Reader) (err error) {
+	if s.PubKey, err = blockchain.ReadVarstr31(r); err != nil {
+		return err
+	}
+
+	if err := s.First.readFrom(r); err != nil {
+		return err
+	}
+
+	return s.Second.readFrom(r)
+}
+
+func (s *SlashEvidence) writeTo(w io.Writer) error {
+	if _, err := blockchain.WriteVarstr31(w, s.PubKey); err != nil {
+		return err
+	}
+
+	if err := s.First.writeTo(w); err != nil {
+		return err
+	}
+
+	return s.Second.writeTo(w)
+}
+
+// SignedLink is the link from the source checkpoint to the target checkpoint
+// with the signature of the validator
+type SignedLink struct {
+	SourceHeight uint64
+	SourceHash   bc.Hash
+	TargetHeight uint64
+	TargetHash   bc.Hash
+	Signature    []byte
+}
+
+func (s *SignedLink) readFrom(r *blockchain.Reader) (err error) {
+	if s.SourceHeight, err = blockchain.ReadVarint63(r); err != nil {
+		return err
+	}
+
+	if _, err := s.SourceHash.ReadFrom(r); err != nil {
+		return err
+	}
+
+	if s.TargetHeight, err = blockchain.ReadVarint63(r); err != nil {
+		return err
+	}
+
+	if _, err := s.TargetHash.ReadFrom(r); err != nil {
+		return err
+	}
+
+	s.Signature, err = blockchain.ReadVarstr31(r)
+	return err
+}
+
+func (s *SignedLink) writeTo(w io.Writer) error {
+	if _, err := blockchain.WriteVarint63(w, s.SourceHeight); err != nil {
+		return err
+	}
+
+	if _, err := s.SourceHash.WriteTo(w); err != nil {
+		return err
+	}
+
+	if _, err := blockchain.WriteVarint63(w, s.TargetHeight); err != nil {
+		return err
+	}
+
+	if _, err := s.TargetHash.WriteTo(w); err != nil {
+		return err
+	}
+
+	_, err := blockchain.WriteVarstr31(w, s.Signature)
+	return err
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/bytom/bytom/encoding/blockchain"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestBlockHeaderWithSlashEvidences(t *testing.T) {
+	blockHeader := &BlockHeader{
+		Version:           1,
+		Height:            200,
+		PreviousBlockHash: testutil.MustDecodeHash("c34048bd60c4c13144fd34f408627d1be68f6cb4fdd34e879d6d791060ea73a0"),
+		Timestamp:         1522908275,
+		SupLinks:          SupLinks{},
+		SlashEvidences: SlashEvidences{
+			{
+				PubKey: testutil.MustDecodeHexString("0a0b"),
+				First: SignedLink{
+					SourceHeight: 0,
+					SourceHash:   testutil.MustDecodeHash("0a3cd1175e295a35c2b63054969c3fe54eeaa3eb68258227b28d8daa6cf4c50c"),
+					TargetHeight: 100,
+					TargetHash:   testutil.MustDecodeHash("546c91cefc6a06f9b7a0aaa4d69db9a7f229af27928304a44ecd48e33ba2ba91"),
+					Signature:    testutil.MustDecodeHexString("01"),
+				},
+				Second: SignedLink{
+					SourceHeight: 0,
+					SourceHash:   testutil.MustDecodeHash("0a3cd1175e295a35c2b63054969c3fe54eeaa3eb68258227b28d8daa6cf4c50c"),
+					TargetHeight: 100,
+					TargetHash:   testutil.MustDecodeHash("ad9ac003d08ff305181a345d64fe0b02311cc1a6ec04ab73f3318d90139bfe03"),
+					Signature:    testutil.MustDecodeHexString("02"),
+				},
+			},
+		},
+	}
+
+	wantHex := strings.Join([]string{
+		"01",   // serialization flags
+		"01",   // version
+		"c801", // block height
+		"c34048bd60c4c13144fd34f408627d1be68f6cb4fdd34e879d6d791060ea73a0", // prev block hash
+		"f3f896d605", // timestamp
+		"20",         // commitment extensible field length
+		"0000000000000000000000000000000000000000000000000000000000000000", // transactions merkle root
+		"0100",   // block witness
+		"8d01",   // supLinks extensible field length
+		"00",     // len of sup links
+		"01",     // len of slash evidences
+		"020a0b", // pub key
+		"00",     // first source height
+		"0a3cd1175e295a35c2b63054969c3fe54eeaa3eb68258227b28d8daa6cf4c50c", // first source hash
+		"64", // first target height
+		"546c91cefc6a06f9b7a0aaa4d69db9a7f229af27928304a44ecd48e33ba2ba91", // first target hash
+		"0101", // first signature
+		"00",   // second source height
+		"0a3cd1175e295a35c2b63054969c3fe54eeaa3eb68258227b28d8daa6cf4c50c", // second source hash
+		"64", // second target height
+		"ad9ac003d08ff305181a345d64fe0b02311cc1a6ec04ab73f3318d90139bfe03", // second target hash
+		"0102", // second signature
+	}, "")
+
+	got := testutil.Serialize(t, blockHeader)
+	want, err := hex.DecodeString(wantHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("block header bytes = %x want %x", got, want)
+	}
+
+	gotBlockHeader := BlockHeader{}
+	if _, err := gotBlockHeader.readFrom(blockchain.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !testutil.DeepEqual(gotBlockHeader, *blockHeader) {
+		t.Errorf("got:\n%s\nwant:\n%s", spew.Sdump(gotBlockHeader), spew.Sdump(*blockHeader))
+	}
+}
+
+func TestBlockHashCommitsSlashEvidences(t *testing.T) {
+	blockHeader := &BlockHeader{
+		Version:           1,
+		Height:            200,
+		PreviousBlockHash: testutil.MustDecodeHash("c34048bd60c4c13144fd34f408627d1be68f6cb4fdd34e879d6d791060ea73a0"),
+		Timestamp:         1522908275,
+	}
+	originHash := blockHeader.Hash()
+
+	blockHeader.SlashEvidences = SlashEvidences{{PubKey: testutil.MustDecodeHexString("0a0b")}}
+	evidenceHash := blockHeader.Hash()
+	if evidenceHash == originHash {
+		t.Fatal("the block hash doesn't commit to the slash evidences")
+	}
+
+	blockHeader.SlashEvidences[0].PubKey = testutil.MustDecodeHexString("0a0c")
+	if blockHeader.Hash() == evidenceHash {
+		t.Fatal("the block hash doesn't commit to the content of the slash evidences")
+	}
+
+	blockHeader.SlashEvidences = nil
+	if blockHeader.Hash() != originHash {
+		t.Fatal("the block hash without slash evidences should keep the origin hash")
+	}
+}
@@ -3,9 +3,12 @@ package protocol
 import (
 	log "github.com/sirupsen/logrus"
 
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/database/storage"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/casper"
 	"github.com/bytom/bytom/protocol/state"
 	"github.com/bytom/bytom/protocol/validation"
 )
@@ -42,6 +45,25 @@ func (c *Chain) GetBlockByHeight(height uint64) (*types.Block, error) {
 	return c.store.GetBlock(hash)
 }
 
+// GetTransactionByID return the main chain transaction by given tx id, the header
+// of the block packs it and the position of it in the block
+func (c *Chain) GetTransactionByID(txID *bc.Hash) (*types.Tx, *types.BlockHeader, uint64, error) {
+	blockHash, position, err := c.store.GetTransactionIndex(txID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	block, err := c.store.GetBlock(blockHash)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if position >= uint64(len(block.Transactions)) {
+		return nil, nil, 0, errors.New("tx index position is out of the block transactions")
+	}
+	return block.Transactions[position], &block.BlockHeader, position, nil
+}
+
 // GetHeaderByHash return a block header by given hash
 func (c *Chain) GetHeaderByHash(hash *bc.Hash) (*types.BlockHeader, error) {
 	return c.store.GetBlockHeader(hash)
@@ -97,6 +119,7 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 
 	utxoView := state.NewUtxoViewpoint()
 	contractView := state.NewContractViewpoint()
+	indexView := state.NewIndexViewpoint()
 	txsToRestore := map[bc.Hash]*types.Tx{}
 	for _, detachNode := range detachNodes {
 		hash := detachNode.Hash()
@@ -110,6 +133,10 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 			return err
 		}
 
+		if err := c.store.GetBlockSpentUtxos(utxoView, &hash); err != nil {
+			return err
+		}
+
 		if err := utxoView.DetachBlock(detachBlock); err != nil {
 			return err
 		}
@@ -118,6 +145,8 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 			return err
 		}
 
+		indexView.DetachBlock(b)
+
 		for _, tx := range b.Transactions[1:] {
 			txsToRestore[tx.ID] = tx
 		}
@@ -125,6 +154,7 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 	}
 
 	txsToRemove := map[bc.Hash]*types.Tx{}
+	attachBlocks := []*types.Block{}
 	for _, attachNode := range attachNodes {
 		hash := attachNode.Hash()
 		b, err := c.store.GetBlock(&hash)
@@ -137,7 +167,12 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 			return err
 		}
 
-		if err := utxoView.ApplyBlock(attachBlock); err != nil {
+		checkpoint, err := c.PrevCheckpointByPrevHash(&b.PreviousBlockHash)
+		if err != nil {
+			return err
+		}
+
+		if err := utxoView.ApplyBlock(attachBlock, checkpoint); err != nil {
 			return err
 		}
 
@@ -145,6 +180,8 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 			return err
 		}
 
+		indexView.ApplyBlock(b)
+		attachBlocks = append(attachBlocks, b)
 		for _, tx := range b.Transactions[1:] {
 			if _, ok := txsToRestore[tx.ID]; !ok {
 				txsToRemove[tx.ID] = tx
@@ -156,10 +193,14 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 		log.WithFields(log.Fields{"module": logModule, "height": attachNode.Height, "hash": hash.String()}).Debug("attach from mainchain")
 	}
 
-	if err := c.setState(blockHeader, attachNodes, utxoView, contractView); err != nil {
+	if err := c.setState(blockHeader, attachNodes, utxoView, contractView, indexView); err != nil {
 		return err
 	}
 
+	for _, b := range attachBlocks {
+		c.txPool.ConfirmBlock(b)
+	}
+
 	for txHash := range txsToRemove {
 		c.txPool.RemoveTransaction(&txHash)
 	}
@@ -180,6 +221,100 @@ func (c *Chain) reorganizeChain(blockHeader *types.BlockHeader) error {
 	return nil
 }
 
+// blockUtxoView return the view of the utxos spent by the block on the chain of its parent, it's
+// nil if no opcode reading the utxos is activated at the height of the block by the checkpoint
+func (c *Chain) blockUtxoView(block *types.Block, parent *types.BlockHeader, checkpoint *state.Checkpoint) (*state.UtxoViewpoint, error) {
+	if !checkpoint.IsDeploymentActive(consensus.DeploymentRelativeTimelock, block.Height) {
+		return nil, nil
+	}
+
+	txs := types.MapBlock(block).Transactions
+	view := state.NewUtxoViewpoint()
+	if parent.Hash() != c.bestBlockHeader.Hash() {
+		if err := c.forkSpentUtxos(view, txs, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.loadSpentUtxos(view, txs, block.Height); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// forkSpentUtxos load the utxos spent by the txs on the best chain and then change them by the blocks
+// between the best chain and the chain of the parent. Only the spent outputs are tracked instead of
+// replaying the blocks, the detached blocks restore the outputs they spent with the recorded heights
+// and remove the outputs they created, then the attached blocks create and spend the outputs in order
+func (c *Chain) forkSpentUtxos(view *state.UtxoViewpoint, txs []*bc.Tx, parent *types.BlockHeader) error {
+	spentOutputs := make(map[bc.Hash]bool)
+	for _, tx := range txs {
+		for _, outputID := range tx.SpentOutputIDs {
+			spentOutputs[outputID] = true
+		}
+	}
+
+	if err := c.store.GetTransactionsUtxo(view, txs); err != nil {
+		return err
+	}
+
+	attachNodes, detachNodes, err := c.calcReorganizeChain(parent, c.bestBlockHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, detachNode := range detachNodes {
+		hash := detachNode.Hash()
+		blockView := state.NewUtxoViewpoint()
+		if err := c.store.GetBlockSpentUtxos(blockView, &hash); err != nil {
+			return err
+		}
+
+		for outputID, entry := range blockView.Entries {
+			if spentOutputs[outputID] {
+				entry.UnspendOutput()
+				view.Entries[outputID] = entry
+			}
+		}
+
+		b, err := c.store.GetBlock(&hash)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range b.Transactions {
+			for _, id := range tx.ResultIds {
+				if spentOutputs[*id] {
+					view.Entries[*id] = storage.NewUtxoEntry(storage.NormalUTXOType, 0, true)
+				}
+			}
+		}
+	}
+
+	for _, attachNode := range attachNodes {
+		hash := attachNode.Hash()
+		b, err := c.store.GetBlock(&hash)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range b.Transactions {
+			for _, outputID := range tx.SpentOutputIDs {
+				if entry, ok := view.Entries[outputID]; ok {
+					entry.SpendOutput()
+				}
+			}
+
+			for _, id := range tx.ResultIds {
+				if spentOutputs[*id] {
+					view.Entries[*id] = storage.NewUtxoEntry(storage.NormalUTXOType, b.Height, false)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // SaveBlock will validate and save block into storage
 func (c *Chain) saveBlock(block *types.Block) error {
 	parent, err := c.store.GetBlockHeader(&block.PreviousBlockHash)
@@ -192,7 +327,12 @@ func (c *Chain) saveBlock(block *types.Block) error {
 		return err
 	}
 
-	if err := validation.ValidateBlock(block, parent, checkpoint, c.ProgramConverter); err != nil {
+	view, err := c.blockUtxoView(block, parent, checkpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := validation.ValidateBlock(block, parent, checkpoint, view, c.ProgramConverter); err != nil {
 		return errors.Sub(ErrBadBlock, err)
 	}
 
@@ -256,11 +396,40 @@ func (c *Chain) blockProcessor() {
 			isOrphan, err := c.processBlock(msg.block)
 			msg.reply <- processBlockResponse{isOrphan: isOrphan, err: err}
 		case msg := <-c.casper.RollbackCh():
-			msg.Reply <- c.tryReorganize(msg.BestHash)
+			c.processRollback(msg)
 		}
 	}
 }
 
+// RollbackEvent is posted when casper rolls the main chain back to another fork
+type RollbackEvent struct {
+	PrevBestHeight uint64
+	PrevBestHash   bc.Hash
+	BestHeight     uint64
+	BestHash       bc.Hash
+}
+
+// processRollback reorganize the main chain to the best chain of casper, and notify
+// the subscribers if the main chain is changed
+func (c *Chain) processRollback(msg *casper.RollbackMsg) {
+	prevBestHeader := c.bestBlockHeader
+	err := c.tryReorganize(msg.BestHash)
+	msg.Reply <- err
+	if err != nil || c.bestBlockHeader == prevBestHeader {
+		return
+	}
+
+	event := RollbackEvent{
+		PrevBestHeight: prevBestHeader.Height,
+		PrevBestHash:   prevBestHeader.Hash(),
+		BestHeight:     c.bestBlockHeader.Height,
+		BestHash:       c.bestBlockHeader.Hash(),
+	}
+	if err := c.eventDispatcher.Post(event); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on post rollback event")
+	}
+}
+
 // ProcessBlock is the entry for handle block insert
 func (c *Chain) processBlock(block *types.Block) (bool, error) {
 	blockHash := block.Hash()
@@ -5,8 +5,6 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/bytom/bytom/config"
-	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
@@ -19,7 +17,12 @@ import (
 // it will return verification when an epoch is reached and the current node is the validator, otherwise return nil
 // the chain module must broadcast the verification
 func (c *Casper) ApplyBlock(block *types.Block) (bc.Hash, error) {
-	if block.Height%consensus.ActiveNetParams.BlocksOfEpoch == 1 {
+	parentHash, err := c.parentCheckpointHashByPrevHash(&block.PreviousBlockHash)
+	if err != nil {
+		return bc.Hash{}, err
+	}
+
+	if *parentHash == block.PreviousBlockHash {
 		c.newEpochCh <- block.PreviousBlockHash
 	}
 
@@ -52,10 +55,26 @@ func (c *Casper) applyBlockToCheckpoint(block *types.Block) (*state.Checkpoint,
 		return nil, err
 	}
 
-	if block.Height%consensus.ActiveNetParams.BlocksOfEpoch == 1 {
-		node = node.newChild()
+	// the checkpoint of the new epoch is attached to the tree only after the block is
+	// verified and applied to it, so an invalid block doesn't leave it in the tree
+	checkpoint := nextCheckpoint(node)
+
+	if err := c.verifySlashEvidences(checkpoint, block); err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyParamProposals(checkpoint, block); err != nil {
+		return nil, err
 	}
-	return node.Checkpoint, node.Increase(block)
+
+	if err := checkpoint.Increase(block); err != nil {
+		return nil, err
+	}
+
+	if checkpoint != node.Checkpoint {
+		node.addChild(checkpoint)
+	}
+	return checkpoint, nil
 }
 
 func (c *Casper) checkpointNodeByHash(hash bc.Hash) (*treeNode, error) {
@@ -68,7 +87,7 @@ func (c *Casper) checkpointNodeByHash(hash bc.Hash) (*treeNode, error) {
 		return nil, err
 	}
 
-	if block.Height%consensus.ActiveNetParams.BlocksOfEpoch == 0 {
+	if block.Height <= c.tree.Height {
 		return nil, errors.New("checkpointNodeByHash fail on previous round checkpoint")
 	}
 
@@ -77,12 +96,21 @@ func (c *Casper) checkpointNodeByHash(hash bc.Hash) (*treeNode, error) {
 		return nil, err
 	}
 
-	if block.Height%consensus.ActiveNetParams.BlocksOfEpoch == 1 {
+	if node.Status != state.Growing {
 		node = node.newChild()
 	}
 	return node, node.Increase(block)
 }
 
+// nextCheckpoint return the checkpoint the block next to the node is applied to, it's a new
+// checkpoint not attached to the tree if the node's epoch has ended
+func nextCheckpoint(node *treeNode) *state.Checkpoint {
+	if node.Status == state.Growing {
+		return node.Checkpoint
+	}
+	return state.NewCheckpoint(node.Checkpoint)
+}
+
 // applySupLinks copy the block's supLink to the checkpoint
 func (c *Casper) applySupLinks(target *state.Checkpoint, supLinks []*types.SupLink) ([]*state.Checkpoint, error) {
 	affectedCheckpoints := []*state.Checkpoint{target}
@@ -121,7 +149,7 @@ func (c *Casper) applyMyVerification(target *state.Checkpoint, block *types.Bloc
 }
 
 func (c *Casper) myVerification(target *state.Checkpoint) *verification {
-	if target.Status == state.Growing {
+	if c.signer == nil || target.Status == state.Growing {
 		return nil
 	}
 
@@ -131,8 +159,7 @@ func (c *Casper) myVerification(target *state.Checkpoint) *verification {
 		return nil
 	}
 
-	prvKey := config.CommonConfig.PrivateKey()
-	v, err := convertVerification(source, target, &ValidCasperSignMsg{PubKey: prvKey.XPub().String()})
+	v, err := convertVerification(source, target, &ValidCasperSignMsg{PubKey: c.signer.XPub().String()})
 	if err != nil {
 		return nil
 	}
@@ -142,8 +169,8 @@ func (c *Casper) myVerification(target *state.Checkpoint) *verification {
 		return nil
 	}
 
-	if err := v.Sign(*prvKey); err != nil {
-		log.WithField("module", logModule).Error("myVerification fail on sign msg")
+	if v.Signature, err = c.signer.SignVerification(v.SourceHeight, v.SourceHash, v.TargetHeight, v.TargetHash); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("myVerification fail on sign msg")
 		return nil
 	}
 
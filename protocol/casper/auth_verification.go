@@ -99,6 +99,7 @@ func (c *Casper) saveVerificationToHeader(v *verification) error {
 // source status is justified, and exist a super majority link from source to target
 func (c *Casper) setJustified(source, target *state.Checkpoint) {
 	target.Status = state.Justified
+	c.postCheckpointEvent(target)
 	// must direct child
 	if target.ParentHash == source.Hash {
 		c.setFinalized(source)
@@ -117,6 +118,15 @@ func (c *Casper) setFinalized(checkpoint *state.Checkpoint) {
 	newRoot.Status = state.Finalized
 	newRoot.Parent = nil
 	c.tree = newRoot
+	c.postCheckpointEvent(checkpoint)
+}
+
+// postCheckpointEvent notify the subscribers that the checkpoint is justified or finalized
+func (c *Casper) postCheckpointEvent(checkpoint *state.Checkpoint) {
+	event := CheckpointEvent{Height: checkpoint.Height, Hash: checkpoint.Hash, Status: checkpoint.Status}
+	if err := c.msgQueue.Post(event); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on post checkpoint event")
+	}
 }
 
 func (c *Casper) tryRollback(oldBestHash bc.Hash) error {
@@ -179,32 +189,39 @@ func (c *Casper) verifyVerification(v *verification) error {
 		return err
 	}
 
-	if err := c.verifySameHeight(v); err != nil {
-		return err
+	conflict, err := c.verifySameHeight(v)
+	if err == nil {
+		conflict, err = c.verifySpanHeight(v)
 	}
 
-	return c.verifySpanHeight(v)
+	if conflict != nil {
+		c.recordEvidence(v, conflict)
+	}
+	return err
 }
 
-// a validator must not publish two distinct votes for the same target height
-func (c *Casper) verifySameHeight(v *verification) error {
+// a validator must not publish two distinct votes for the same target height,
+// the conflicting vote is returned as the evidence
+func (c *Casper) verifySameHeight(v *verification) (*state.SignedVerification, error) {
 	checkpoints, err := c.store.GetCheckpointsByHeight(v.TargetHeight)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, checkpoint := range checkpoints {
 		for _, supLink := range checkpoint.SupLinks {
 			if len(supLink.Signatures[v.order]) != 0 && checkpoint.Hash != v.TargetHash {
-				return errSameHeightInVerification
+				return supLinkToSignedVerification(checkpoint, supLink, v.order), errSameHeightInVerification
 			}
 		}
 	}
-	return nil
+	return nil, nil
 }
 
-// a validator must not vote within the span of its other votes.
-func (c *Casper) verifySpanHeight(v *verification) error {
+// a validator must not vote within the span of its other votes,
+// the conflicting vote is returned as the evidence
+func (c *Casper) verifySpanHeight(v *verification) (*state.SignedVerification, error) {
+	var conflict *state.SignedVerification
 	if c.tree.findOnlyOne(func(checkpoint *state.Checkpoint) bool {
 		if checkpoint.Height == v.TargetHeight {
 			return false
@@ -214,15 +231,16 @@ func (c *Casper) verifySpanHeight(v *verification) error {
 			if len(supLink.Signatures[v.order]) != 0 {
 				if (checkpoint.Height < v.TargetHeight && supLink.SourceHeight > v.SourceHeight) ||
 					(checkpoint.Height > v.TargetHeight && supLink.SourceHeight < v.SourceHeight) {
+					conflict = supLinkToSignedVerification(checkpoint, supLink, v.order)
 					return true
 				}
 			}
 		}
 		return false
 	}) != nil {
-		return errSpanHeightInVerification
+		return conflict, errSpanHeightInVerification
 	}
-	return nil
+	return nil, nil
 }
 
 func verificationCacheKey(blockHash bc.Hash, pubKey string) string {
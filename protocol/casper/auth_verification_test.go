@@ -57,7 +57,7 @@ var (
 )
 
 func TestRollback(t *testing.T) {
-	casper := NewCasper(&mockStore2{}, event.NewDispatcher(), checkpoints)
+	casper := NewCasper(&mockStore2{}, event.NewDispatcher(), checkpoints, nil)
 	casper.prevCheckpointCache.Add(checkpoints[1].Hash, &checkpoints[0].Hash)
 	go func() {
 		rollbackMsg := <-casper.rollbackCh
@@ -112,9 +112,14 @@ func (s *mockStore2) GetTransactionsUtxo(*state.UtxoViewpoint, []*bc.Tx) error {
 func (s *mockStore2) GetUtxo(*bc.Hash) (*storage.UtxoEntry, error)             { return nil, nil }
 func (s *mockStore2) GetMainChainHash(uint64) (*bc.Hash, error)                { return nil, nil }
 func (s *mockStore2) GetContract([32]byte) ([]byte, error)                     { return nil, nil }
+func (s *mockStore2) GetTransactionIndex(*bc.Hash) (*bc.Hash, uint64, error)   { return nil, 0, nil }
+func (s *mockStore2) GetBlockSpentUtxos(*state.UtxoViewpoint, *bc.Hash) error  { return nil }
+func (s *mockStore2) GetEvidence(*bc.Hash) (*state.Evidence, error)            { return nil, nil }
+func (s *mockStore2) ListEvidences() ([]*state.Evidence, error)                { return nil, nil }
+func (s *mockStore2) SaveEvidence(*state.Evidence) error                       { return nil }
 func (s *mockStore2) SaveBlock(*types.Block) error                             { return nil }
 func (s *mockStore2) SaveBlockHeader(*types.BlockHeader) error                 { return nil }
-func (s *mockStore2) SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *state.UtxoViewpoint, *state.ContractViewpoint, uint64, *bc.Hash) error {
+func (s *mockStore2) SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *state.UtxoViewpoint, *state.ContractViewpoint, *state.IndexViewpoint, uint64, *bc.Hash) error {
 	return nil
 }
 func (s *mockStore2) GetBlockHeader(hash *bc.Hash) (*types.BlockHeader, error) {
@@ -1,15 +1,17 @@
 package casper
 
 import (
+	"sort"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/bytom/bytom/common"
-	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
 	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/signer"
 )
 
 var (
@@ -28,6 +30,13 @@ type RollbackMsg struct {
 	Reply    chan error
 }
 
+// CheckpointEvent is posted when the checkpoint becomes justified or finalized
+type CheckpointEvent struct {
+	Height uint64
+	Hash   bc.Hash
+	Status state.CheckpointStatus
+}
+
 type msgQueue interface {
 	Post(interface{}) error
 }
@@ -39,11 +48,15 @@ type Casper struct {
 	store    state.Store
 	msgQueue msgQueue
 	tree     *treeNode
+	// the signer of the verifications, the node doesn't sign the verifications if it's nil
+	signer signer.Signer
 
 	// block hash -> previous checkpoint hash
 	prevCheckpointCache *common.Cache
 	// block hash + pubKey -> verification
 	verificationCache *common.Cache
+	// id -> param proposal submitted to the node
+	paramProposals map[string]*pooledParamProposal
 
 	rollbackCh chan *RollbackMsg
 	newEpochCh chan bc.Hash
@@ -53,7 +66,8 @@ type Casper struct {
 // argument checkpoints load the checkpoints from leveldb
 // the first element of checkpoints must genesis checkpoint or the last finalized checkpoint in order to reduce memory space
 // the others must be successors of first one
-func NewCasper(store state.Store, queue msgQueue, checkpoints []*state.Checkpoint) *Casper {
+// argument signer sign the verifications, the node doesn't sign the verifications if it's nil
+func NewCasper(store state.Store, queue msgQueue, checkpoints []*state.Checkpoint, signer signer.Signer) *Casper {
 	if checkpoints[0].Height != 0 && checkpoints[0].Status != state.Finalized {
 		log.WithFields(log.Fields{"module": logModule}).Panic("first element of checkpoints must genesis or in finalized status")
 	}
@@ -62,8 +76,10 @@ func NewCasper(store state.Store, queue msgQueue, checkpoints []*state.Checkpoin
 		store:               store,
 		msgQueue:            queue,
 		tree:                makeTree(checkpoints[0], checkpoints[1:]),
+		signer:              signer,
 		prevCheckpointCache: common.NewCache(1024),
 		verificationCache:   common.NewCache(1024),
+		paramProposals:      make(map[string]*pooledParamProposal),
 		rollbackCh:          make(chan *RollbackMsg, 64),
 		newEpochCh:          make(chan bc.Hash, 64),
 	}
@@ -88,6 +104,103 @@ func (c *Casper) LastJustified() (uint64, bc.Hash) {
 	return node.Height, node.Hash
 }
 
+// Checkpoints return the copies of the checkpoints in the tree, which are the last finalized
+// checkpoint and its successors, in the order of height
+func (c *Casper) Checkpoints() []*state.Checkpoint {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var checkpoints []*state.Checkpoint
+	for nodes := []*treeNode{c.tree}; len(nodes) != 0; nodes = nodes[1:] {
+		checkpoints = append(checkpoints, copyCheckpoint(nodes[0].Checkpoint))
+		nodes = append(nodes, nodes[0].children...)
+	}
+
+	sort.SliceStable(checkpoints, func(i, j int) bool {
+		return checkpoints[i].Height < checkpoints[j].Height
+	})
+	return checkpoints
+}
+
+// Checkpoint return the checkpoint by the block hash, the checkpoint in the tree is
+// returned with the sup links received from the verifications
+func (c *Casper) Checkpoint(hash *bc.Hash) (*state.Checkpoint, error) {
+	c.mu.RLock()
+	node := c.tree.nodeByHash(*hash)
+	if node != nil {
+		defer c.mu.RUnlock()
+		return copyCheckpoint(node.Checkpoint), nil
+	}
+
+	c.mu.RUnlock()
+	return c.store.GetCheckpoint(hash)
+}
+
+// copyCheckpoint copy the checkpoint and its parent so that they can be read without
+// the lock, the ancestors beyond the parent are not kept in the copy
+func copyCheckpoint(checkpoint *state.Checkpoint) *state.Checkpoint {
+	result := *checkpoint
+	if checkpoint.Parent != nil {
+		parent := *checkpoint.Parent
+		parent.Parent = nil
+		result.Parent = copyCheckpoint(&parent)
+	}
+
+	result.Rewards = make(map[string]uint64, len(checkpoint.Rewards))
+	for script, reward := range checkpoint.Rewards {
+		result.Rewards[script] = reward
+	}
+
+	result.Votes = make(map[string]uint64, len(checkpoint.Votes))
+	for pubKey, num := range checkpoint.Votes {
+		result.Votes[pubKey] = num
+	}
+
+	result.Slashed = make(map[string]bool, len(checkpoint.Slashed))
+	for pubKey := range checkpoint.Slashed {
+		result.Slashed[pubKey] = true
+	}
+
+	result.Producers = make(map[string]string, len(checkpoint.Producers))
+	for script, pubKey := range checkpoint.Producers {
+		result.Producers[script] = pubKey
+	}
+
+	result.Stats = make(map[string]*state.ValidatorStats, len(checkpoint.Stats))
+	for pubKey, stats := range checkpoint.Stats {
+		copied := *stats
+		result.Stats[pubKey] = &copied
+	}
+
+	result.Params = make(map[string]uint64, len(checkpoint.Params))
+	for key, value := range checkpoint.Params {
+		result.Params[key] = value
+	}
+
+	result.Signals = make(map[string]uint64, len(checkpoint.Signals))
+	for name, num := range checkpoint.Signals {
+		result.Signals[name] = num
+	}
+
+	result.Activations = make(map[string]uint64, len(checkpoint.Activations))
+	for name, height := range checkpoint.Activations {
+		result.Activations[name] = height
+	}
+
+	result.ParamChanges = nil
+	for _, change := range checkpoint.ParamChanges {
+		copied := *change
+		result.ParamChanges = append(result.ParamChanges, &copied)
+	}
+
+	result.SupLinks = nil
+	for _, supLink := range checkpoint.SupLinks {
+		copied := *supLink
+		result.SupLinks = append(result.SupLinks, &copied)
+	}
+	return &result
+}
+
 func (c *Casper) RollbackCh() <-chan *RollbackMsg {
 	return c.rollbackCh
 }
@@ -159,10 +272,13 @@ func (c *Casper) parentCheckpointHashByPrevHash(prevBlockHash *bc.Hash) (*bc.Has
 			return nil, err
 		}
 
-		if mod := block.Height % consensus.ActiveNetParams.BlocksOfEpoch; mod == 0 {
+		isCheckpoint, err := c.isCheckpoint(block)
+		if err != nil {
+			return nil, err
+		}
+
+		if isCheckpoint {
 			return iterHash, nil
-		} else if mod == 1 {
-			return &block.PreviousBlockHash, nil
 		}
 
 		if data, ok := c.prevCheckpointCache.Get(*iterHash); ok {
@@ -173,3 +289,20 @@ func (c *Casper) parentCheckpointHashByPrevHash(prevBlockHash *bc.Hash) (*bc.Has
 		iterHash = &block.PreviousBlockHash
 	}
 }
+
+// isCheckpoint return whether the block is the last block of an epoch, the checkpoints
+// of the ended epochs are saved by their last blocks
+func (c *Casper) isCheckpoint(blockHeader *types.BlockHeader) (bool, error) {
+	checkpoints, err := c.store.GetCheckpointsByHeight(blockHeader.Height)
+	if err != nil {
+		return false, err
+	}
+
+	blockHash := blockHeader.Hash()
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Hash == blockHash && checkpoint.Status != state.Growing {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -114,3 +114,61 @@ func TestBestChain(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckpoints(t *testing.T) {
+	root := &state.Checkpoint{Height: 0, Hash: bc.Hash{V0: 1}, Status: state.Finalized, Votes: map[string]uint64{"a": 1}}
+	successors := []*state.Checkpoint{
+		{Height: 200, Hash: bc.Hash{V0: 3}, ParentHash: bc.Hash{V0: 2}, Status: state.Unjustified},
+		{Height: 100, Hash: bc.Hash{V0: 2}, ParentHash: bc.Hash{V0: 1}, Status: state.Justified},
+		{Height: 100, Hash: bc.Hash{V0: 4}, ParentHash: bc.Hash{V0: 1}, Status: state.Growing},
+	}
+	c := &Casper{tree: makeTree(root, successors)}
+
+	checkpoints := c.Checkpoints()
+	wantHashes := []bc.Hash{{V0: 1}, {V0: 2}, {V0: 4}, {V0: 3}}
+	if len(checkpoints) != len(wantHashes) {
+		t.Fatalf("got %d checkpoints, want %d", len(checkpoints), len(wantHashes))
+	}
+
+	for i, checkpoint := range checkpoints {
+		if checkpoint.Hash != wantHashes[i] {
+			t.Errorf("checkpoint %d: got hash %v, want %v", i, checkpoint.Hash, wantHashes[i])
+		}
+	}
+
+	checkpoint, err := c.Checkpoint(&bc.Hash{V0: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint.Parent.Votes["a"] = 2
+	checkpoint.Status = state.Finalized
+	if root.Votes["a"] != 1 || successors[1].Status != state.Justified {
+		t.Errorf("modify the copy of checkpoint changed the checkpoint in the tree")
+	}
+}
+
+type mockMsgQueue struct {
+	msgs []interface{}
+}
+
+func (q *mockMsgQueue) Post(msg interface{}) error {
+	q.msgs = append(q.msgs, msg)
+	return nil
+}
+
+func TestSetJustifiedPostEvents(t *testing.T) {
+	source := &state.Checkpoint{Height: 0, Hash: bc.Hash{V0: 1}, Status: state.Justified}
+	target := &state.Checkpoint{Height: 100, Hash: bc.Hash{V0: 2}, ParentHash: bc.Hash{V0: 1}, Status: state.Unjustified}
+	queue := &mockMsgQueue{}
+	c := &Casper{tree: makeTree(source, []*state.Checkpoint{target}), msgQueue: queue}
+
+	c.setJustified(source, target)
+	want := []interface{}{
+		CheckpointEvent{Height: 100, Hash: bc.Hash{V0: 2}, Status: state.Justified},
+		CheckpointEvent{Height: 0, Hash: bc.Hash{V0: 1}, Status: state.Finalized},
+	}
+	if !testutil.DeepEqual(queue.msgs, want) {
+		t.Errorf("got events %v, want %v", queue.msgs, want)
+	}
+}
@@ -0,0 +1,244 @@
+package casper
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ErrBadEvidence is returned when the evidence doesn't prove the validator published conflicting verifications
+var ErrBadEvidence = errors.New("invalid casper evidence")
+
+func (v *verification) toSignedVerification() *state.SignedVerification {
+	return &state.SignedVerification{
+		SourceHash:   v.SourceHash,
+		TargetHash:   v.TargetHash,
+		SourceHeight: v.SourceHeight,
+		TargetHeight: v.TargetHeight,
+		Signature:    v.Signature,
+	}
+}
+
+func supLinkToSignedVerification(target *state.Checkpoint, supLink *types.SupLink, order int) *state.SignedVerification {
+	return &state.SignedVerification{
+		SourceHash:   supLink.SourceHash,
+		TargetHash:   target.Hash,
+		SourceHeight: supLink.SourceHeight,
+		TargetHeight: target.Height,
+		Signature:    supLink.Signatures[order],
+	}
+}
+
+// newEvidence build the evidence from the two verifications of the validator, the
+// verification with lower target height or smaller target hash is put first
+func newEvidence(pubKey string, v1, v2 *state.SignedVerification) (*state.Evidence, error) {
+	if v2.TargetHeight < v1.TargetHeight || (v2.TargetHeight == v1.TargetHeight && bytes.Compare(v2.TargetHash.Bytes(), v1.TargetHash.Bytes()) < 0) {
+		v1, v2 = v2, v1
+	}
+
+	evidence := &state.Evidence{PubKey: pubKey, First: *v1, Second: *v2}
+	switch {
+	case v1.TargetHeight == v2.TargetHeight && v1.TargetHash != v2.TargetHash:
+		evidence.Type = state.DoubleVoteEvidence
+	case v1.TargetHeight < v2.TargetHeight && v1.SourceHeight > v2.SourceHeight:
+		evidence.Type = state.SurroundVoteEvidence
+	default:
+		return nil, errors.WithDetail(ErrBadEvidence, "verifications are not conflicting")
+	}
+	return evidence, nil
+}
+
+// verifyEvidence check the evidence is canonical, the heights match the checkpoints
+// in the store, and both verifications are signed by a validator of the target checkpoint
+func (c *Casper) verifyEvidence(evidence *state.Evidence) error {
+	expect, err := newEvidence(evidence.PubKey, &evidence.First, &evidence.Second)
+	if err != nil {
+		return err
+	}
+
+	if expect.Type != evidence.Type || expect.First.TargetHash != evidence.First.TargetHash {
+		return errors.WithDetail(ErrBadEvidence, "evidence is not canonical")
+	}
+
+	for _, sv := range []*state.SignedVerification{&evidence.First, &evidence.Second} {
+		source, err := c.store.GetCheckpoint(&sv.SourceHash)
+		if err != nil {
+			return errors.WithDetail(ErrBadEvidence, "source checkpoint not found")
+		}
+
+		target, err := c.store.GetCheckpoint(&sv.TargetHash)
+		if err != nil {
+			return errors.WithDetail(ErrBadEvidence, "target checkpoint not found")
+		}
+
+		if source.Height != sv.SourceHeight || target.Height != sv.TargetHeight {
+			return errors.WithDetail(ErrBadEvidence, "checkpoint height mismatch")
+		}
+
+		validators, err := c.validators(&sv.TargetHash)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := validators[evidence.PubKey]; !ok {
+			return errors.WithDetail(ErrBadEvidence, "pub key is not in validators of target checkpoint")
+		}
+
+		v := &verification{SourceHash: sv.SourceHash, TargetHash: sv.TargetHash, Signature: sv.Signature, PubKey: evidence.PubKey}
+		if err := v.verifySignature(); err != nil {
+			return errors.WithDetail(ErrBadEvidence, err.Error())
+		}
+	}
+	return nil
+}
+
+// saveEvidence persists the new evidence and notify the subscribers, the known
+// evidence is ignored
+func (c *Casper) saveEvidence(evidence *state.Evidence) error {
+	hash := evidence.Hash()
+	if _, err := c.store.GetEvidence(&hash); err == nil {
+		return nil
+	}
+
+	if err := c.store.SaveEvidence(evidence); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"module": logModule, "type": evidence.Type, "pub_key": evidence.PubKey, "hash": hash.String()}).Warn("validator published conflicting verifications")
+	return c.msgQueue.Post(*evidence)
+}
+
+// recordEvidence save the evidence of the verification conflicting with the one the
+// validator published before, the conflicting signature is checked before saved
+func (c *Casper) recordEvidence(v *verification, conflict *state.SignedVerification) {
+	conflictV := &verification{SourceHash: conflict.SourceHash, TargetHash: conflict.TargetHash, Signature: conflict.Signature, PubKey: v.PubKey}
+	if err := conflictV.verifySignature(); err != nil {
+		return
+	}
+
+	evidence, err := newEvidence(v.PubKey, v.toSignedVerification(), conflict)
+	if err != nil {
+		return
+	}
+
+	if err := c.saveEvidence(evidence); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on save casper evidence")
+	}
+}
+
+// ProcessEvidence verify and save the evidence received from the peers, the new
+// evidence is posted to the subscribers so that it's relayed to the other peers
+func (c *Casper) ProcessEvidence(evidence *state.Evidence) error {
+	if err := c.verifyEvidence(evidence); err != nil {
+		return err
+	}
+
+	return c.saveEvidence(evidence)
+}
+
+func toSignedLink(sv *state.SignedVerification) types.SignedLink {
+	return types.SignedLink{
+		SourceHeight: sv.SourceHeight,
+		SourceHash:   sv.SourceHash,
+		TargetHeight: sv.TargetHeight,
+		TargetHash:   sv.TargetHash,
+		Signature:    sv.Signature,
+	}
+}
+
+func signedLinkToSignedVerification(link *types.SignedLink) *state.SignedVerification {
+	return &state.SignedVerification{
+		SourceHash:   link.SourceHash,
+		TargetHash:   link.TargetHash,
+		SourceHeight: link.SourceHeight,
+		TargetHeight: link.TargetHeight,
+		Signature:    link.Signature,
+	}
+}
+
+// toSlashEvidence convert the evidence to the form included in the block
+func toSlashEvidence(evidence *state.Evidence) (*types.SlashEvidence, error) {
+	pubKey, err := hex.DecodeString(evidence.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SlashEvidence{
+		PubKey: pubKey,
+		First:  toSignedLink(&evidence.First),
+		Second: toSignedLink(&evidence.Second),
+	}, nil
+}
+
+// verifySlashEvidences check the evidences included in the block, each of them must be
+// valid and slash a different validator which has not been slashed by the checkpoint
+func (c *Casper) verifySlashEvidences(checkpoint *state.Checkpoint, block *types.Block) error {
+	slashed := map[string]bool{}
+	for _, slashEvidence := range block.SlashEvidences {
+		pubKey := hex.EncodeToString(slashEvidence.PubKey)
+		if checkpoint.IsSlashed(pubKey) || slashed[pubKey] {
+			return errors.WithDetailf(ErrBadEvidence, "validator %s has been slashed", pubKey)
+		}
+
+		evidence, err := newEvidence(pubKey, signedLinkToSignedVerification(&slashEvidence.First), signedLinkToSignedVerification(&slashEvidence.Second))
+		if err != nil {
+			return err
+		}
+
+		if err := c.verifyEvidence(evidence); err != nil {
+			return err
+		}
+
+		slashed[pubKey] = true
+	}
+	return nil
+}
+
+// SlashEvidences return the known evidences which can be included in the block next to
+// the specified block, the evidences of the validators slashed on that chain are excluded
+func (c *Casper) SlashEvidences(prevBlockHash *bc.Hash, height uint64) ([]*types.SlashEvidence, error) {
+	evidences, err := c.store.ListEvidences()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node := c.tree.nodeByHash(*prevBlockHash)
+	if node == nil || !node.IsDeploymentActive(consensus.DeploymentSlashing, height) {
+		return nil, nil
+	}
+
+	var result []*types.SlashEvidence
+	slashed := map[string]bool{}
+	for _, evidence := range evidences {
+		if len(result) >= consensus.MaxSlashEvidences {
+			break
+		}
+
+		if node.IsSlashed(evidence.PubKey) || slashed[evidence.PubKey] {
+			continue
+		}
+
+		if err := c.verifyEvidence(evidence); err != nil {
+			continue
+		}
+
+		slashEvidence, err := toSlashEvidence(evidence)
+		if err != nil {
+			continue
+		}
+
+		slashed[evidence.PubKey] = true
+		result = append(result, slashEvidence)
+	}
+	return result, nil
+}
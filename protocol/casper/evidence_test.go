@@ -0,0 +1,91 @@
+package casper
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+func TestNewEvidence(t *testing.T) {
+	cases := []struct {
+		desc      string
+		v1        *state.SignedVerification
+		v2        *state.SignedVerification
+		wantType  string
+		wantFirst bc.Hash
+		wantErr   error
+	}{
+		{
+			desc:      "double vote",
+			v1:        &state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 2}},
+			v2:        &state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 1}},
+			wantType:  state.DoubleVoteEvidence,
+			wantFirst: bc.Hash{V0: 1},
+		},
+		{
+			desc:      "surround vote",
+			v1:        &state.SignedVerification{SourceHeight: 0, TargetHeight: 300, TargetHash: bc.Hash{V0: 3}},
+			v2:        &state.SignedVerification{SourceHeight: 100, TargetHeight: 200, TargetHash: bc.Hash{V0: 2}},
+			wantType:  state.SurroundVoteEvidence,
+			wantFirst: bc.Hash{V0: 2},
+		},
+		{
+			desc:    "same target",
+			v1:      &state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 1}},
+			v2:      &state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 1}},
+			wantErr: ErrBadEvidence,
+		},
+		{
+			desc:    "consecutive votes",
+			v1:      &state.SignedVerification{SourceHeight: 0, TargetHeight: 100, TargetHash: bc.Hash{V0: 1}},
+			v2:      &state.SignedVerification{SourceHeight: 100, TargetHeight: 200, TargetHash: bc.Hash{V0: 2}},
+			wantErr: ErrBadEvidence,
+		},
+	}
+
+	for _, c := range cases {
+		evidence, err := newEvidence("pubkey", c.v1, c.v2)
+		if errors.Root(err) != c.wantErr {
+			t.Errorf("%s: got error %v, want %v", c.desc, err, c.wantErr)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if evidence.Type != c.wantType || evidence.First.TargetHash != c.wantFirst {
+			t.Errorf("%s: got evidence type %s first %v, want type %s first %v", c.desc, evidence.Type, evidence.First.TargetHash, c.wantType, c.wantFirst)
+		}
+
+		if swapped, _ := newEvidence("pubkey", c.v2, c.v1); swapped.Hash() != evidence.Hash() {
+			t.Errorf("%s: evidence hash depends on the order of verifications", c.desc)
+		}
+	}
+}
+
+func TestApplyBlockWithBadEvidence(t *testing.T) {
+	pubKey := []byte{0x01}
+	root := &state.Checkpoint{Height: 0, Hash: bc.Hash{V0: 1}, Status: state.Justified, Slashed: map[string]bool{hex.EncodeToString(pubKey): true}}
+	c := &Casper{tree: makeTree(root, nil)}
+
+	block := &types.Block{
+		BlockHeader: types.BlockHeader{
+			Height:            consensus.ActiveNetParams.BlocksOfEpoch + 1,
+			PreviousBlockHash: root.Hash,
+			SlashEvidences:    types.SlashEvidences{{PubKey: pubKey}},
+		},
+	}
+	if _, err := c.applyBlockToCheckpoint(block); errors.Root(err) != ErrBadEvidence {
+		t.Fatalf("got error %v, want %v", err, ErrBadEvidence)
+	}
+
+	if len(c.tree.children) != 0 {
+		t.Errorf("got %d checkpoints next to the root after the bad block, want 0", len(c.tree.children))
+	}
+}
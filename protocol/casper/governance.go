@@ -0,0 +1,229 @@
+package casper
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/signer"
+)
+
+// ErrBadParamProposal is returned when the consensus parameter proposal is invalid
+var ErrBadParamProposal = errors.New("invalid consensus parameter proposal")
+
+// pooledParamProposal is the param proposal submitted to the node, the signatures are
+// kept by the pub key since the orders of the validators change by epoch
+type pooledParamProposal struct {
+	key            string
+	value          uint64
+	activateHeight uint64
+	signatures     map[string][]byte
+}
+
+func paramProposalID(key string, value, activateHeight uint64) string {
+	return fmt.Sprintf("%s:%d:%d", key, value, activateHeight)
+}
+
+func verifyParamSignature(pubKey string, message, signature []byte) error {
+	xPub := chainkd.XPub{}
+	data, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return err
+	}
+
+	copy(xPub[:], data)
+	if !xPub.Verify(message, signature) {
+		return errors.WithDetailf(ErrBadParamProposal, "signature of validator %s is invalid", pubKey)
+	}
+	return nil
+}
+
+// checkParamChange check the parameter is governable, and the change activates at the first
+// block of an epoch after the next one by the epoch schedule of the checkpoint the block is
+// applied to, so that every node learns it at least one epoch in advance
+func checkParamChange(checkpoint *state.Checkpoint, key string, value, activateHeight uint64) error {
+	if err := consensus.ValidateParam(key, value); err != nil {
+		return errors.WithDetail(ErrBadParamProposal, err.Error())
+	}
+
+	if activateHeight <= checkpoint.EpochEnd()+1 || !checkpoint.IsEpochStart(activateHeight) {
+		return errors.WithDetailf(ErrBadParamProposal, "activate height %d is not a future epoch boundary", activateHeight)
+	}
+	return nil
+}
+
+// checkProposedChange check the change can be proposed along with the proposals before it in
+// the block. Only one change of the blocks of epoch can be pending, and no other change can
+// activate after it, since their activate heights are checked by the epochs before it
+func checkProposedChange(checkpoint *state.Checkpoint, proposed []*types.ParamProposal, key string, value, activateHeight uint64) error {
+	if err := checkParamChange(checkpoint, key, value, activateHeight); err != nil {
+		return err
+	}
+
+	changes := append([]*state.ParamChange{}, checkpoint.ParamChanges...)
+	for _, proposal := range proposed {
+		changes = append(changes, &state.ParamChange{Key: proposal.Key, Value: proposal.Value, ActivateHeight: proposal.ActivateHeight})
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Key == key && change.ActivateHeight == activateHeight:
+			return errors.WithDetailf(ErrBadParamProposal, "change of %s at height %d has been approved", key, activateHeight)
+		case change.Key == consensus.ParamBlocksOfEpoch && key == consensus.ParamBlocksOfEpoch:
+			return errors.WithDetailf(ErrBadParamProposal, "change of %s at height %d is pending", key, change.ActivateHeight)
+		case change.Key == consensus.ParamBlocksOfEpoch && activateHeight > change.ActivateHeight:
+			return errors.WithDetailf(ErrBadParamProposal, "change of %s activates after the change of %s", key, change.Key)
+		case key == consensus.ParamBlocksOfEpoch && change.ActivateHeight > activateHeight:
+			return errors.WithDetailf(ErrBadParamProposal, "change of %s activates after the change of %s", change.Key, key)
+		}
+	}
+	return nil
+}
+
+// verifyParamProposal check the proposal included in the block applied to the checkpoint can be
+// proposed along with the proposals before it, and is signed by the supermajority of the validators
+func verifyParamProposal(checkpoint *state.Checkpoint, proposed []*types.ParamProposal, proposal *types.ParamProposal, validators map[string]*state.Validator) error {
+	if err := checkProposedChange(checkpoint, proposed, proposal.Key, proposal.Value, proposal.ActivateHeight); err != nil {
+		return err
+	}
+
+	message, err := signer.ParamProposalMessage(proposal.Key, proposal.Value, proposal.ActivateHeight)
+	if err != nil {
+		return err
+	}
+
+	orderToValidator := map[int]*state.Validator{}
+	for _, validator := range validators {
+		orderToValidator[validator.Order] = validator
+	}
+
+	for order, signature := range proposal.Signatures {
+		if len(signature) == 0 {
+			continue
+		}
+
+		validator, ok := orderToValidator[order]
+		if !ok {
+			return errors.WithDetailf(ErrBadParamProposal, "no validator of order %d", order)
+		}
+
+		if err := verifyParamSignature(validator.PubKey, message, signature); err != nil {
+			return err
+		}
+	}
+
+	if !proposal.IsMajority(len(validators)) {
+		return errors.WithDetail(ErrBadParamProposal, "proposal is not signed by the supermajority of validators")
+	}
+	return nil
+}
+
+// verifyParamProposals check the param proposals included in the block, each of them must be
+// valid and change a different parameter or activate height which is not approved by the checkpoint
+func (c *Casper) verifyParamProposals(checkpoint *state.Checkpoint, block *types.Block) error {
+	if len(block.ParamProposals) == 0 {
+		return nil
+	}
+
+	if checkpoint.Parent == nil {
+		return errors.WithDetail(ErrBadParamProposal, "parent checkpoint is not found")
+	}
+
+	validators := checkpoint.Parent.EffectiveValidators()
+	for i, proposal := range block.ParamProposals {
+		if err := verifyParamProposal(checkpoint, block.ParamProposals[:i], proposal, validators); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubmitParamProposal add the signatures of the validators to the param proposal kept by the
+// node, the signatures can be submitted in batches, the proposal is included into the block
+// by the node once it's signed by the supermajority of the validators
+func (c *Casper) SubmitParamProposal(key string, value, activateHeight uint64, signatures map[string][]byte) error {
+	message, err := signer.ParamProposalMessage(key, value, activateHeight)
+	if err != nil {
+		return err
+	}
+
+	for pubKey, signature := range signatures {
+		if err := verifyParamSignature(pubKey, message, signature); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bestNode, _ := c.tree.bestNode(c.tree.Height)
+	if err := checkProposedChange(nextCheckpoint(bestNode), nil, key, value, activateHeight); err != nil {
+		return err
+	}
+
+	id := paramProposalID(key, value, activateHeight)
+	proposal, ok := c.paramProposals[id]
+	if !ok {
+		proposal = &pooledParamProposal{key: key, value: value, activateHeight: activateHeight, signatures: map[string][]byte{}}
+		c.paramProposals[id] = proposal
+	}
+
+	for pubKey, signature := range signatures {
+		proposal.signatures[pubKey] = signature
+	}
+	return nil
+}
+
+// ParamProposals return the submitted param proposals which can be included in the block next
+// to the specified block, the expired proposals are removed from the node
+func (c *Casper) ParamProposals(prevBlockHash *bc.Hash, height uint64) ([]*types.ParamProposal, error) {
+	parent, err := c.ParentCheckpointByPrevHash(prevBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parent.IsDeploymentActive(consensus.DeploymentGovernance, height) {
+		return nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	node := c.tree.nodeByHash(*prevBlockHash)
+	if node == nil {
+		return nil, nil
+	}
+
+	checkpoint := nextCheckpoint(node)
+	validators := parent.EffectiveValidators()
+	var result []*types.ParamProposal
+	for id, pooled := range c.paramProposals {
+		if pooled.activateHeight <= checkpoint.EpochEnd()+1 {
+			delete(c.paramProposals, id)
+			continue
+		}
+
+		if len(result) >= consensus.MaxParamProposals {
+			continue
+		}
+
+		proposal := &types.ParamProposal{Key: pooled.key, Value: pooled.value, ActivateHeight: pooled.activateHeight}
+		for pubKey, signature := range pooled.signatures {
+			if validator, ok := validators[pubKey]; ok {
+				proposal.Signatures[validator.Order] = signature
+			}
+		}
+
+		if err := verifyParamProposal(checkpoint, result, proposal, validators); err != nil {
+			continue
+		}
+
+		result = append(result, proposal)
+	}
+	return result, nil
+}
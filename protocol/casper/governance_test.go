@@ -0,0 +1,178 @@
+package casper
+
+import (
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/signer"
+)
+
+func TestVerifyParamProposal(t *testing.T) {
+	var xPrvs []chainkd.XPrv
+	validators := map[string]*state.Validator{}
+	for i := 0; i < 3; i++ {
+		xPrv, err := chainkd.NewXPrv(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		xPrvs = append(xPrvs, xPrv)
+		validators[xPrv.XPub().String()] = &state.Validator{PubKey: xPrv.XPub().String(), Order: i}
+	}
+
+	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
+	newProposal := func(key string, value, activateHeight uint64, signers ...int) *types.ParamProposal {
+		proposal := &types.ParamProposal{Key: key, Value: value, ActivateHeight: activateHeight}
+		for _, i := range signers {
+			message, err := signer.ParamProposalMessage(key, value, activateHeight)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proposal.Signatures[i] = xPrvs[i].Sign(message)
+		}
+		return proposal
+	}
+
+	cases := []struct {
+		desc     string
+		proposal *types.ParamProposal
+		wantErr  bool
+	}{
+		{
+			desc:     "signed by all validators",
+			proposal: newProposal(consensus.ParamMaxBlockGas, 20000000, 3*blocksOfEpoch+1, 0, 1, 2),
+		},
+		{
+			desc:     "signed by 2 of 3 validators",
+			proposal: newProposal(consensus.ParamMaxBlockGas, 20000000, 3*blocksOfEpoch+1, 0, 1),
+			wantErr:  true,
+		},
+		{
+			desc:     "activate in the next epoch",
+			proposal: newProposal(consensus.ParamMaxBlockGas, 20000000, 2*blocksOfEpoch+1, 0, 1, 2),
+			wantErr:  true,
+		},
+		{
+			desc:     "activate in the middle of epoch",
+			proposal: newProposal(consensus.ParamMaxBlockGas, 20000000, 3*blocksOfEpoch+2, 0, 1, 2),
+			wantErr:  true,
+		},
+		{
+			desc:     "parameter is not governable",
+			proposal: newProposal("block_time_interval", 200, 3*blocksOfEpoch+1, 0, 1, 2),
+			wantErr:  true,
+		},
+		{
+			desc:     "value is out of range",
+			proposal: newProposal(consensus.ParamMaxNumOfValidators, uint64(consensus.MaxNumOfValidators)+1, 3*blocksOfEpoch+1, 0, 1, 2),
+			wantErr:  true,
+		},
+	}
+
+	checkpoint := &state.Checkpoint{Height: blocksOfEpoch + 50, Status: state.Growing, Parent: &state.Checkpoint{Height: blocksOfEpoch, Status: state.Unjustified}}
+	for _, c := range cases {
+		if err := verifyParamProposal(checkpoint, nil, c.proposal, validators); (err != nil) != c.wantErr {
+			t.Errorf("case %s: got error %v, want error %v", c.desc, err, c.wantErr)
+		}
+	}
+
+	tampered := newProposal(consensus.ParamMaxBlockGas, 20000000, 3*blocksOfEpoch+1, 0, 1, 2)
+	tampered.Value = 30000000
+	if err := verifyParamProposal(checkpoint, nil, tampered, validators); err == nil {
+		t.Errorf("tampered proposal passed the verification")
+	}
+}
+
+func TestCheckProposedChange(t *testing.T) {
+	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
+	checkpoint := &state.Checkpoint{
+		Height:       blocksOfEpoch + 50,
+		Status:       state.Growing,
+		Parent:       &state.Checkpoint{Height: blocksOfEpoch, Status: state.Unjustified},
+		ParamChanges: []*state.ParamChange{{Key: consensus.ParamBlocksOfEpoch, Value: blocksOfEpoch / 2, ActivateHeight: 3*blocksOfEpoch + 1}},
+	}
+
+	cases := []struct {
+		desc           string
+		proposed       []*types.ParamProposal
+		key            string
+		value          uint64
+		activateHeight uint64
+		wantErr        bool
+	}{
+		{
+			desc:           "activate with the change of blocks of epoch",
+			key:            consensus.ParamMaxBlockGas,
+			value:          20000000,
+			activateHeight: 3*blocksOfEpoch + 1,
+		},
+		{
+			desc:           "activate after the change of blocks of epoch",
+			key:            consensus.ParamMaxBlockGas,
+			value:          20000000,
+			activateHeight: 3*blocksOfEpoch + blocksOfEpoch/2 + 1,
+			wantErr:        true,
+		},
+		{
+			desc:           "another change of blocks of epoch",
+			key:            consensus.ParamBlocksOfEpoch,
+			value:          blocksOfEpoch,
+			activateHeight: 3*blocksOfEpoch + 1,
+			wantErr:        true,
+		},
+		{
+			desc:           "change approved by the checkpoint",
+			key:            consensus.ParamBlocksOfEpoch,
+			value:          blocksOfEpoch / 2,
+			activateHeight: 3*blocksOfEpoch + 1,
+			wantErr:        true,
+		},
+		{
+			desc:           "change proposed by the same block",
+			proposed:       []*types.ParamProposal{{Key: consensus.ParamMaxBlockGas, Value: 30000000, ActivateHeight: 3*blocksOfEpoch + 1}},
+			key:            consensus.ParamMaxBlockGas,
+			value:          20000000,
+			activateHeight: 3*blocksOfEpoch + 1,
+			wantErr:        true,
+		},
+		{
+			desc:           "zero blocks of epoch",
+			key:            consensus.ParamBlocksOfEpoch,
+			activateHeight: 3*blocksOfEpoch + 1,
+			wantErr:        true,
+		},
+	}
+
+	for _, c := range cases {
+		if err := checkProposedChange(checkpoint, c.proposed, c.key, c.value, c.activateHeight); (err != nil) != c.wantErr {
+			t.Errorf("case %s: got error %v, want error %v", c.desc, err, c.wantErr)
+		}
+	}
+}
+
+func TestApplyBlockWithBadParamProposal(t *testing.T) {
+	root := &state.Checkpoint{Height: 0, Hash: bc.Hash{V0: 1}, Status: state.Justified}
+	c := &Casper{tree: makeTree(root, nil)}
+
+	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
+	block := &types.Block{
+		BlockHeader: types.BlockHeader{
+			Height:            blocksOfEpoch + 1,
+			PreviousBlockHash: root.Hash,
+			ParamProposals:    types.ParamProposals{{Key: consensus.ParamMaxBlockGas, Value: 20000000, ActivateHeight: 3*blocksOfEpoch + 1}},
+		},
+	}
+	if _, err := c.applyBlockToCheckpoint(block); errors.Root(err) != ErrBadParamProposal {
+		t.Fatalf("got error %v, want %v", err, ErrBadParamProposal)
+	}
+
+	if len(c.tree.children) != 0 {
+		t.Errorf("got %d checkpoints next to the root after the bad block, want 0", len(c.tree.children))
+	}
+}
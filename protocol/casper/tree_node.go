@@ -32,7 +32,12 @@ func makeTree(root *state.Checkpoint, successors []*state.Checkpoint) *treeNode
 }
 
 func (t *treeNode) newChild() *treeNode {
-	child := &treeNode{Checkpoint: state.NewCheckpoint(t.Checkpoint)}
+	return t.addChild(state.NewCheckpoint(t.Checkpoint))
+}
+
+// addChild attach the checkpoint created by state.NewCheckpoint from the node to the tree
+func (t *treeNode) addChild(checkpoint *state.Checkpoint) *treeNode {
+	child := &treeNode{Checkpoint: checkpoint}
 	t.children = append(t.children, child)
 	return child
 }
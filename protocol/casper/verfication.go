@@ -1,17 +1,14 @@
 package casper
 
 import (
-	"bytes"
 	"encoding/hex"
 	"errors"
 
-	"golang.org/x/crypto/sha3"
-
-	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/crypto/ed25519/chainkd"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
 	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/signer"
 )
 
 var errVerifySignature = errors.New("signature of verification message is invalid")
@@ -37,6 +34,10 @@ type verification struct {
 }
 
 func convertVerification(source, target *state.Checkpoint, msg *ValidCasperSignMsg) (*verification, error) {
+	if source.Status == state.Growing || target.Status == state.Growing {
+		return nil, errVoteToGrowingCheckpoint
+	}
+
 	validators := target.Parent.EffectiveValidators()
 	if _, ok := validators[msg.PubKey]; !ok {
 		return nil, errPubKeyIsNotValidator
@@ -54,6 +55,10 @@ func convertVerification(source, target *state.Checkpoint, msg *ValidCasperSignM
 }
 
 func supLinkToVerifications(source, target *state.Checkpoint, supLink *types.SupLink) []*verification {
+	if source.Status == state.Growing || target.Status == state.Growing {
+		return nil
+	}
+
 	var result []*verification
 	for _, validator := range target.Parent.EffectiveValidators() {
 		if signature := supLink.Signatures[validator.Order]; len(signature) != 0 {
@@ -92,11 +97,6 @@ func (v *verification) toValidCasperSignMsg() ValidCasperSignMsg {
 }
 
 func (v *verification) valid() error {
-	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
-	if v.SourceHeight%blocksOfEpoch != 0 || v.TargetHeight%blocksOfEpoch != 0 {
-		return errVoteToGrowingCheckpoint
-	}
-
 	if v.SourceHeight >= v.TargetHeight {
 		return errVoteToSameCheckpoint
 	}
@@ -127,15 +127,5 @@ func (v *verification) verifySignature() error {
 
 // encodeMessage encode the verification for the validators to sign or verify
 func (v *verification) encodeMessage() ([]byte, error) {
-	buff := new(bytes.Buffer)
-	if _, err := v.SourceHash.WriteTo(buff); err != nil {
-		return nil, err
-	}
-
-	if _, err := v.TargetHash.WriteTo(buff); err != nil {
-		return nil, err
-	}
-
-	msg := sha3.Sum256(buff.Bytes())
-	return msg[:], nil
+	return signer.VerificationMessage(v.SourceHash, v.TargetHash)
 }
@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ListCheckpoints return the last finalized checkpoint and its successors which
+// have not been finalized, in the order of height
+func (c *Chain) ListCheckpoints() []*state.Checkpoint {
+	return c.casper.Checkpoints()
+}
+
+// GetCheckpointsByHeight return the checkpoints of all the forks at the specified height
+func (c *Chain) GetCheckpointsByHeight(height uint64) ([]*state.Checkpoint, error) {
+	return c.store.GetCheckpointsByHeight(height)
+}
+
+// GetCheckpoint return the checkpoint by the hash of its last block
+func (c *Chain) GetCheckpoint(hash *bc.Hash) (*state.Checkpoint, error) {
+	return c.casper.Checkpoint(hash)
+}
+
+// CheckpointValidators return the validators which verify the checkpoint, they are
+// the effective validators of the parent checkpoint, the genesis checkpoint has no validator
+func (c *Chain) CheckpointValidators(checkpoint *state.Checkpoint) (map[string]*state.Validator, error) {
+	if checkpoint.Height == 0 {
+		return map[string]*state.Validator{}, nil
+	}
+
+	if checkpoint.Parent != nil {
+		return checkpoint.Parent.EffectiveValidators(), nil
+	}
+
+	parent, err := c.store.GetCheckpoint(&checkpoint.ParentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return parent.EffectiveValidators(), nil
+}
@@ -0,0 +1,53 @@
+package protocol
+
+import (
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// DeploymentStatus is the state of the deployment at the block next to the best block
+type DeploymentStatus struct {
+	Name             string `json:"name"`
+	Bit              uint8  `json:"bit"`
+	StartHeight      uint64 `json:"start_height"`
+	TimeoutHeight    uint64 `json:"timeout_height"`
+	ActivationHeight uint64 `json:"activation_height"`
+	State            string `json:"state"`
+	Signals          uint64 `json:"signals"`
+	Threshold        uint64 `json:"threshold"`
+}
+
+// ListDeployments return the states of the deployments of the network, the signals
+// are the number of the blocks signalling the deployment in the current epoch
+func (c *Chain) ListDeployments() ([]*DeploymentStatus, error) {
+	bestHeader := c.BestBlockHeader()
+	bestHash := bestHeader.Hash()
+	checkpoint, err := c.casper.Checkpoint(&bestHash)
+	if err != nil {
+		return nil, err
+	}
+
+	height := bestHeader.Height + 1
+	result := []*DeploymentStatus{}
+	for _, deployment := range consensus.ActiveNetParams.Deployments {
+		status := &DeploymentStatus{
+			Name:             deployment.Name,
+			Bit:              deployment.Bit,
+			StartHeight:      deployment.StartHeight,
+			TimeoutHeight:    deployment.TimeoutHeight,
+			ActivationHeight: deployment.ActivationHeight,
+			State:            checkpoint.DeploymentState(deployment.Name, height),
+			Threshold:        checkpoint.BlocksOfEpoch(checkpoint.Height)*2/3 + 1,
+		}
+
+		if activation, ok := checkpoint.Activations[deployment.Name]; ok && activation < status.ActivationHeight {
+			status.ActivationHeight = activation
+		}
+
+		if status.State == state.DeploymentStarted {
+			status.Signals = checkpoint.Signals[deployment.Name]
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package protocol
+
+import (
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ConsensusParams is the governable consensus parameters effective at the height,
+// and the approved changes which will activate after it
+type ConsensusParams struct {
+	Height  uint64
+	Params  map[string]uint64
+	Pending []*state.ParamChange
+}
+
+// ConsensusParam return the value of the consensus parameter effective at the block next to the specified block
+func (c *Chain) ConsensusParam(prevBlockHash *bc.Hash, height uint64, key string) (uint64, error) {
+	checkpoint, err := c.PrevCheckpointByPrevHash(prevBlockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return checkpoint.Param(key, height), nil
+}
+
+// GetConsensusParams return the consensus parameters effective at the block next to the best block
+func (c *Chain) GetConsensusParams() (*ConsensusParams, error) {
+	bestHeader := c.BestBlockHeader()
+	bestHash := bestHeader.Hash()
+	checkpoint, err := c.casper.Checkpoint(&bestHash)
+	if err != nil {
+		return nil, err
+	}
+
+	height := bestHeader.Height + 1
+	result := &ConsensusParams{Height: height, Params: checkpoint.ParamsAt(height), Pending: []*state.ParamChange{}}
+	for _, change := range checkpoint.ParamChanges {
+		if change.ActivateHeight > height {
+			result.Pending = append(result.Pending, change)
+		}
+	}
+	return result, nil
+}
+
+// SubmitParamProposal add the signatures of the validators to the consensus parameter proposal
+func (c *Chain) SubmitParamProposal(key string, value, activateHeight uint64, signatures map[string][]byte) error {
+	return c.casper.SubmitParamProposal(key, value, activateHeight, signatures)
+}
+
+// ParamProposals return the param proposals to be included in the block next to the specified block
+func (c *Chain) ParamProposals(prevBlockHash *bc.Hash, height uint64) ([]*types.ParamProposal, error) {
+	return c.casper.ParamProposals(prevBlockHash, height)
+}
@@ -6,11 +6,13 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/bytom/bytom/config"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
 	"github.com/bytom/bytom/event"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
 	"github.com/bytom/bytom/protocol/casper"
 	"github.com/bytom/bytom/protocol/state"
+	"github.com/bytom/bytom/signer"
 )
 
 const (
@@ -25,6 +27,9 @@ type Chain struct {
 	casper          *casper.Casper
 	processBlockCh  chan *processBlockMsg
 	eventDispatcher *event.Dispatcher
+	// the signer of the blocks and verifications, it's created by the key of the node config
+	// if no signer is given
+	signer signer.Signer
 
 	cond            sync.Cond
 	bestBlockHeader *types.BlockHeader // the last block on current main chain
@@ -35,12 +40,30 @@ func NewChain(store state.Store, txPool *TxPool, eventDispatcher *event.Dispatch
 	return NewChainWithOrphanManage(store, txPool, NewOrphanManage(), eventDispatcher)
 }
 
+// NewChainWithSigner returns a new Chain which signs the blocks and verifications by the
+// signer instead of the key of the node config, e.g. the remote signer of the validator
+func NewChainWithSigner(store state.Store, txPool *TxPool, eventDispatcher *event.Dispatcher, signer signer.Signer) (*Chain, error) {
+	return newChain(store, txPool, NewOrphanManage(), eventDispatcher, signer)
+}
+
 func NewChainWithOrphanManage(store state.Store, txPool *TxPool, manage *OrphanManage, eventDispatcher *event.Dispatcher) (*Chain, error) {
+	return newChain(store, txPool, manage, eventDispatcher, nil)
+}
+
+func newChain(store state.Store, txPool *TxPool, manage *OrphanManage, eventDispatcher *event.Dispatcher, signer signer.Signer) (*Chain, error) {
+	if signer == nil {
+		var err error
+		if signer, err = newConfigSigner(); err != nil {
+			return nil, err
+		}
+	}
+
 	c := &Chain{
 		orphanManage:    manage,
 		eventDispatcher: eventDispatcher,
 		txPool:          txPool,
 		store:           store,
+		signer:          signer,
 		processBlockCh:  make(chan *processBlockMsg, maxProcessBlockChSize),
 	}
 	c.cond.L = new(sync.Mutex)
@@ -59,7 +82,7 @@ func NewChainWithOrphanManage(store state.Store, txPool *TxPool, manage *OrphanM
 		return nil, err
 	}
 
-	casper, err := newCasper(store, eventDispatcher, storeStatus)
+	casper, err := newCasper(store, eventDispatcher, storeStatus, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -88,22 +111,24 @@ func (c *Chain) initChainStatus() error {
 
 	utxoView := state.NewUtxoViewpoint()
 	bcBlock := types.MapBlock(genesisBlock)
-	if err := utxoView.ApplyBlock(bcBlock); err != nil {
+	if err := utxoView.ApplyBlock(bcBlock, nil); err != nil {
 		return err
 	}
 
 	contractView := state.NewContractViewpoint()
+	indexView := state.NewIndexViewpoint()
+	indexView.ApplyBlock(genesisBlock)
 	genesisBlockHeader := &genesisBlock.BlockHeader
-	return c.store.SaveChainStatus(genesisBlockHeader, []*types.BlockHeader{genesisBlockHeader}, utxoView, contractView, 0, &checkpoint.Hash)
+	return c.store.SaveChainStatus(genesisBlockHeader, []*types.BlockHeader{genesisBlockHeader}, utxoView, contractView, indexView, 0, &checkpoint.Hash)
 }
 
-func newCasper(store state.Store, e *event.Dispatcher, storeStatus *state.BlockStoreState) (*casper.Casper, error) {
+func newCasper(store state.Store, e *event.Dispatcher, storeStatus *state.BlockStoreState, signer signer.Signer) (*casper.Casper, error) {
 	checkpoints, err := store.CheckpointsFromNode(storeStatus.FinalizedHeight, storeStatus.FinalizedHash)
 	if err != nil {
 		return nil, err
 	}
 
-	return casper.NewCasper(store, e, checkpoints), nil
+	return casper.NewCasper(store, e, checkpoints, signer), nil
 }
 
 // LastJustifiedHeader return the last justified block header of the block chain
@@ -123,6 +148,21 @@ func (c *Chain) ProcessBlockVerification(v *casper.ValidCasperSignMsg) error {
 	return c.casper.AuthVerification(v)
 }
 
+// ProcessEvidence process the casper evidence received from the peers
+func (c *Chain) ProcessEvidence(evidence *state.Evidence) error {
+	return c.casper.ProcessEvidence(evidence)
+}
+
+// SlashEvidences return the evidences to be included in the block next to the specified block
+func (c *Chain) SlashEvidences(prevBlockHash *bc.Hash, height uint64) ([]*types.SlashEvidence, error) {
+	return c.casper.SlashEvidences(prevBlockHash, height)
+}
+
+// ListCasperEvidences return the known evidences of the validators which published conflicting verifications
+func (c *Chain) ListCasperEvidences() ([]*state.Evidence, error) {
+	return c.store.ListEvidences()
+}
+
 // BestBlockHeight returns the current height of the blockchain.
 func (c *Chain) BestBlockHeight() uint64 {
 	c.cond.L.Lock()
@@ -192,16 +232,42 @@ func (c *Chain) InMainChain(hash bc.Hash) bool {
 	return *blockHash == hash
 }
 
-func (c *Chain) SignBlockHeader(blockHeader *types.BlockHeader) {
-	xprv := config.CommonConfig.PrivateKey()
-	signature := xprv.Sign(blockHeader.Hash().Bytes())
+// newConfigSigner create the signer of the key in the node config, the chain shares it with
+// casper so that the signed state is checked by all the signing. It's nil if the node config
+// is not initialized, and such chain can't sign
+func newConfigSigner() (signer.Signer, error) {
+	if config.CommonConfig == nil {
+		return nil, nil
+	}
+
+	return signer.NewLocalSigner(*config.CommonConfig.PrivateKey(), "")
+}
+
+// XPub return the pub key of the node to sign the blocks and verifications
+func (c *Chain) XPub() chainkd.XPub {
+	return c.signer.XPub()
+}
+
+// SignBlockHeader sign the block header by the signer of the chain
+func (c *Chain) SignBlockHeader(blockHeader *types.BlockHeader) error {
+	signature, err := c.signer.SignBlock(blockHeader)
+	if err != nil {
+		return err
+	}
+
 	blockHeader.Set(signature)
+	return nil
+}
+
+// SignParamProposal sign the param proposal by the signer of the chain
+func (c *Chain) SignParamProposal(key string, value, activateHeight uint64) ([]byte, error) {
+	return c.signer.SignParamProposal(key, value, activateHeight)
 }
 
 // This function must be called with mu lock in above level
-func (c *Chain) setState(blockHeader *types.BlockHeader, mainBlockHeaders []*types.BlockHeader, view *state.UtxoViewpoint, contractView *state.ContractViewpoint) error {
+func (c *Chain) setState(blockHeader *types.BlockHeader, mainBlockHeaders []*types.BlockHeader, view *state.UtxoViewpoint, contractView *state.ContractViewpoint, indexView *state.IndexViewpoint) error {
 	finalizedHeight, finalizedHash := c.casper.LastFinalized()
-	if err := c.store.SaveChainStatus(blockHeader, mainBlockHeaders, view, contractView, finalizedHeight, &finalizedHash); err != nil {
+	if err := c.store.SaveChainStatus(blockHeader, mainBlockHeaders, view, contractView, indexView, finalizedHeight, &finalizedHash); err != nil {
 		return err
 	}
 
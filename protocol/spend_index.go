@@ -0,0 +1,20 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// ErrSpendIndexDisabled is returned when the chain store doesn't maintain the spend index
+var ErrSpendIndexDisabled = errors.New("spend index is not enabled on the node")
+
+// GetOutputSpender return the main chain transaction input which spends the output
+func (c *Chain) GetOutputSpender(outputID *bc.Hash) (*state.OutputSpender, error) {
+	store, ok := c.store.(state.SpendIndexStore)
+	if !ok || !store.SpendIndexEnabled() {
+		return nil, ErrSpendIndexDisabled
+	}
+	return store.GetOutputSpender(outputID)
+}
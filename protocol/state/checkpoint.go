@@ -29,6 +29,21 @@ const (
 	Finalized
 )
 
+var checkpointStatusNames = map[CheckpointStatus]string{
+	Growing:     "growing",
+	Unjustified: "unjustified",
+	Justified:   "justified",
+	Finalized:   "finalized",
+}
+
+// String return the name of the checkpoint status
+func (s CheckpointStatus) String() string {
+	if name, ok := checkpointStatusNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 var errIncreaseCheckpoint = errors.New("invalid block for increase checkpoint")
 
 // Checkpoint represent the block/hash under consideration for finality for a given epoch.
@@ -42,8 +57,18 @@ type Checkpoint struct {
 	Timestamp  uint64
 	Status     CheckpointStatus
 
-	Rewards map[string]uint64 // controlProgram -> num of reward
-	Votes   map[string]uint64 // pubKey -> num of vote
+	Rewards   map[string]uint64 // controlProgram -> num of reward
+	Votes     map[string]uint64 // pubKey -> num of vote
+	Slashed   map[string]bool   `json:",omitempty"` // pubKey -> whether the validator is slashed
+	Producers map[string]string `json:",omitempty"` // controlProgram -> pubKey of the rewarded validator
+
+	Stats map[string]*ValidatorStats `json:",omitempty"` // pubKey -> performance of the validator in the epoch
+
+	Params       map[string]uint64 `json:",omitempty"` // key -> value of the consensus parameter changed by the governance
+	ParamChanges []*ParamChange    `json:",omitempty"` // the approved parameter changes not activated in the epoch yet
+
+	Signals     map[string]uint64 `json:",omitempty"` // deployment -> num of blocks signalling it in the epoch
+	Activations map[string]uint64 `json:",omitempty"` // deployment -> activation height locked in by the signals
 
 	// only save in the memory, not be persisted
 	Parent   *Checkpoint      `json:"-"`
@@ -68,11 +93,19 @@ func NewCheckpoint(parent *Checkpoint) *Checkpoint {
 			checkpoint.Votes[pubKey] = num
 		}
 	}
+
+	for pubKey := range parent.Slashed {
+		checkpoint.slash(pubKey)
+	}
+
+	checkpoint.inheritParams(parent)
+	checkpoint.inheritDeployments(parent)
 	return checkpoint
 }
 
 // AddVerification add a valid verification to checkpoint's supLink
 func (c *Checkpoint) AddVerification(sourceHash bc.Hash, sourceHeight uint64, validatorOrder int, signature []byte) *types.SupLink {
+	c.applyVerificationStats(validatorOrder)
 	for _, supLink := range c.SupLinks {
 		if supLink.SourceHash == sourceHash {
 			supLink.Signatures[validatorOrder] = signature
@@ -120,15 +153,21 @@ func (c *Checkpoint) Increase(block *types.Block) error {
 		return errIncreaseCheckpoint
 	}
 
-	if block.Height%consensus.ActiveNetParams.BlocksOfEpoch == 0 {
+	if block.Height == c.EpochEnd() {
 		c.Status = Unjustified
 	}
 
+	producer := c.blockProducer(block)
+	c.applyValidatorStats(block, producer)
+
 	c.Hash = block.Hash()
 	c.Height = block.Height
 	c.Timestamp = block.Timestamp
+	c.applySlashEvidences(block)
+	c.applyParamProposals(block)
+	c.applyVersionBits(block)
 	c.applyVotes(block)
-	c.applyValidatorReward(block)
+	c.applyValidatorReward(block, producer)
 	return nil
 }
 
@@ -148,7 +187,8 @@ func (c *Checkpoint) EffectiveValidators() map[string]*Validator {
 	}
 
 	result := make(map[string]*Validator)
-	for i := 0; i < len(validators) && i < consensus.MaxNumOfValidators; i++ {
+	maxNumOfValidators := int(c.Param(consensus.ParamMaxNumOfValidators, c.Height+1))
+	for i := 0; i < len(validators) && i < maxNumOfValidators; i++ {
 		validator := validators[i]
 		validator.Order = i
 		result[validator.PubKey] = validator
@@ -163,8 +203,9 @@ func (c *Checkpoint) AllValidators() []*Validator {
 	}
 
 	var validators []*Validator
+	minValidatorVoteNum := c.Param(consensus.ParamMinValidatorVoteNum, c.Height+1)
 	for pubKey, voteNum := range c.Votes {
-		if voteNum >= consensus.ActiveNetParams.MinValidatorVoteNum {
+		if voteNum >= minValidatorVoteNum && !c.Slashed[pubKey] {
 			validators = append(validators, &Validator{
 				PubKey:  pubKey,
 				VoteNum: c.Votes[pubKey],
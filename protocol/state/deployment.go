@@ -0,0 +1,81 @@
+package state
+
+import (
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// the states of the deployment at a block height
+const (
+	DeploymentDefined  = "defined"
+	DeploymentStarted  = "started"
+	DeploymentLockedIn = "locked_in"
+	DeploymentActive   = "active"
+	DeploymentFailed   = "failed"
+)
+
+// applyVersionBits count the blocks signalling the deployments in their signal windows
+func (c *Checkpoint) applyVersionBits(block *types.Block) {
+	for _, deployment := range consensus.ActiveNetParams.Deployments {
+		if !deployment.InSignalWindow(block.Height) || !consensus.SignalsBit(block.Version, deployment.Bit) {
+			continue
+		}
+
+		if c.Signals == nil {
+			c.Signals = make(map[string]uint64)
+		}
+		c.Signals[deployment.Name]++
+	}
+}
+
+// inheritDeployments copy the activation heights from the parent, the deployment signalled by
+// more than 2/3 blocks of the parent's epoch is locked in, and activates after the new epoch
+func (c *Checkpoint) inheritDeployments(parent *Checkpoint) {
+	for name, height := range parent.Activations {
+		c.setActivation(name, height)
+	}
+
+	parentBlocksOfEpoch := parent.BlocksOfEpoch(parent.Height)
+	for name, num := range parent.Signals {
+		if _, ok := c.Activations[name]; !ok && num > parentBlocksOfEpoch*2/3 {
+			c.setActivation(name, parent.Height+c.BlocksOfEpoch(parent.Height+1)+1)
+		}
+	}
+}
+
+func (c *Checkpoint) setActivation(name string, height uint64) {
+	if c.Activations == nil {
+		c.Activations = make(map[string]uint64)
+	}
+	c.Activations[name] = height
+}
+
+// IsDeploymentActive return whether the deployment is active at the block height, the height
+// should not be lower than the first block of the checkpoint's epoch
+func (c *Checkpoint) IsDeploymentActive(name string, height uint64) bool {
+	if consensus.ActiveNetParams.IsActive(name, height) {
+		return true
+	}
+
+	activation, ok := c.Activations[name]
+	return ok && height >= activation
+}
+
+// DeploymentState return the state of the deployment at the block height
+func (c *Checkpoint) DeploymentState(name string, height uint64) string {
+	deployment := consensus.ActiveNetParams.Deployment(name)
+	switch {
+	case deployment == nil:
+		return DeploymentDefined
+	case c.IsDeploymentActive(name, height):
+		return DeploymentActive
+	case c.Activations[name] != 0:
+		return DeploymentLockedIn
+	case height >= deployment.TimeoutHeight:
+		return DeploymentFailed
+	case height >= deployment.StartHeight:
+		return DeploymentStarted
+	default:
+		return DeploymentDefined
+	}
+}
@@ -0,0 +1,47 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+func TestDeploymentActivation(t *testing.T) {
+	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
+	deployment := consensus.ActiveNetParams.Deployment(consensus.DeploymentGovernance)
+	startHeight, timeoutHeight := deployment.StartHeight, deployment.TimeoutHeight
+	deployment.StartHeight, deployment.TimeoutHeight = blocksOfEpoch+1, 10*blocksOfEpoch+1
+	defer func() { deployment.StartHeight, deployment.TimeoutHeight = startHeight, timeoutHeight }()
+
+	parent := &Checkpoint{Height: blocksOfEpoch, Hash: bc.Hash{V0: 1}, Status: Unjustified}
+	checkpoint := NewCheckpoint(parent)
+	if got := checkpoint.DeploymentState(deployment.Name, blocksOfEpoch+1); got != DeploymentStarted {
+		t.Errorf("got deployment state %s, want %s", got, DeploymentStarted)
+	}
+
+	// the blocks in the first 2/3 of the epoch signal the deployment
+	for height := blocksOfEpoch + 1; height < 2*blocksOfEpoch; height++ {
+		version := consensus.BaseBlockVersion
+		if height <= blocksOfEpoch+blocksOfEpoch*2/3 {
+			version = consensus.ActiveNetParams.BlockVersion(height)
+		}
+		checkpoint.applyVersionBits(&types.Block{BlockHeader: types.BlockHeader{Version: version, Height: height}})
+	}
+
+	checkpoint.Height = 2 * blocksOfEpoch
+	if got := NewCheckpoint(checkpoint).DeploymentState(deployment.Name, 2*blocksOfEpoch+1); got != DeploymentStarted {
+		t.Errorf("got deployment state %s without enough signals, want %s", got, DeploymentStarted)
+	}
+
+	checkpoint.applyVersionBits(&types.Block{BlockHeader: types.BlockHeader{Version: consensus.ActiveNetParams.BlockVersion(2 * blocksOfEpoch), Height: 2 * blocksOfEpoch}})
+	child := NewCheckpoint(checkpoint)
+	if got := child.DeploymentState(deployment.Name, 2*blocksOfEpoch+1); got != DeploymentLockedIn {
+		t.Errorf("got deployment state %s after signalled, want %s", got, DeploymentLockedIn)
+	}
+
+	if !child.IsDeploymentActive(deployment.Name, 3*blocksOfEpoch+1) {
+		t.Errorf("deployment is not active one epoch after locked in")
+	}
+}
@@ -0,0 +1,48 @@
+package state
+
+import (
+	"github.com/bytom/bytom/consensus"
+)
+
+// BlocksOfEpoch return the number of blocks of the epoch starting at the height, the change
+// of it approved by the validators activates at the first block of an epoch
+func (c *Checkpoint) BlocksOfEpoch(height uint64) uint64 {
+	return c.Param(consensus.ParamBlocksOfEpoch, height)
+}
+
+// EpochEnd return the height of the last block of the checkpoint's epoch, it's the height
+// of the checkpoint once the epoch ends
+func (c *Checkpoint) EpochEnd() uint64 {
+	if c.Status != Growing {
+		return c.Height
+	}
+
+	if c.Parent != nil {
+		return c.Parent.Height + c.Parent.BlocksOfEpoch(c.Parent.Height+1)
+	}
+
+	// the growing checkpoint is not linked to the parent, the epoch is assumed to start at
+	// a multiple of the blocks of epoch
+	blocksOfEpoch := c.BlocksOfEpoch(c.Height + 1)
+	return c.Height - c.Height%blocksOfEpoch + blocksOfEpoch
+}
+
+// IsEpochStart return whether the block at the height is the first block of an epoch after
+// the checkpoint's epoch, the epochs are scheduled by the blocks of epoch changes approved by
+// the checkpoint, each of them activates at an epoch boundary of the schedule before it
+func (c *Checkpoint) IsEpochStart(height uint64) bool {
+	start := c.EpochEnd() + 1
+	blocksOfEpoch := c.BlocksOfEpoch(start)
+	for _, change := range c.ParamChanges {
+		if change.Key != consensus.ParamBlocksOfEpoch || change.ActivateHeight <= start {
+			continue
+		}
+
+		if height < change.ActivateHeight {
+			break
+		}
+
+		start, blocksOfEpoch = change.ActivateHeight, change.Value
+	}
+	return height >= start && (height-start)%blocksOfEpoch == 0
+}
@@ -0,0 +1,54 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+)
+
+func TestEpochSchedule(t *testing.T) {
+	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
+	parent := &Checkpoint{
+		Height:       blocksOfEpoch,
+		Status:       Unjustified,
+		ParamChanges: []*ParamChange{{Key: consensus.ParamBlocksOfEpoch, Value: blocksOfEpoch / 2, ActivateHeight: 3*blocksOfEpoch + 1}},
+	}
+	checkpoint := NewCheckpoint(parent)
+	if got := checkpoint.EpochEnd(); got != 2*blocksOfEpoch {
+		t.Fatalf("got epoch end %d, want %d", got, 2*blocksOfEpoch)
+	}
+
+	cases := []struct {
+		height uint64
+		want   bool
+	}{
+		{height: blocksOfEpoch + 1, want: false},
+		{height: 2*blocksOfEpoch + 1, want: true},
+		{height: 2*blocksOfEpoch + blocksOfEpoch/2 + 1, want: false},
+		{height: 3*blocksOfEpoch + 1, want: true},
+		{height: 3*blocksOfEpoch + blocksOfEpoch/2 + 1, want: true},
+		{height: 4*blocksOfEpoch + 1, want: true},
+		{height: 4*blocksOfEpoch + 2, want: false},
+	}
+
+	for _, c := range cases {
+		if got := checkpoint.IsEpochStart(c.height); got != c.want {
+			t.Errorf("height %d: got epoch start %v, want %v", c.height, got, c.want)
+		}
+	}
+
+	checkpoint.Height, checkpoint.Status = 2*blocksOfEpoch, Unjustified
+	if got := checkpoint.EpochEnd(); got != 2*blocksOfEpoch {
+		t.Fatalf("got epoch end %d of the ended epoch, want %d", got, 2*blocksOfEpoch)
+	}
+
+	child := NewCheckpoint(checkpoint)
+	if got := child.EpochEnd(); got != 3*blocksOfEpoch {
+		t.Errorf("got epoch end %d of the child, want %d", got, 3*blocksOfEpoch)
+	}
+
+	grandChild := NewCheckpoint(&Checkpoint{Height: 3 * blocksOfEpoch, Status: Unjustified, Params: child.Params, ParamChanges: child.ParamChanges})
+	if got := grandChild.EpochEnd(); got != 3*blocksOfEpoch+blocksOfEpoch/2 {
+		t.Errorf("got epoch end %d after the change, want %d", got, 3*blocksOfEpoch+blocksOfEpoch/2)
+	}
+}
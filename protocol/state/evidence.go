@@ -0,0 +1,46 @@
+package state
+
+import (
+	"github.com/bytom/bytom/crypto/sha3pool"
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/protocol/bc"
+)
+
+const (
+	// DoubleVoteEvidence means the validator published two distinct verifications for the same target height
+	DoubleVoteEvidence = "double_vote"
+	// SurroundVoteEvidence means the validator published a verification within the span of its other verification
+	SurroundVoteEvidence = "surround_vote"
+)
+
+// SignedVerification is a casper verification with the signature of the validator
+type SignedVerification struct {
+	SourceHash   bc.Hash            `json:"source_hash"`
+	TargetHash   bc.Hash            `json:"target_hash"`
+	SourceHeight uint64             `json:"source_height"`
+	TargetHeight uint64             `json:"target_height"`
+	Signature    chainjson.HexBytes `json:"signature"`
+}
+
+// Evidence is the proof that a validator published two conflicting verifications,
+// the first verification is the one with the lower target height or the smaller target hash
+type Evidence struct {
+	Type   string             `json:"type"`
+	PubKey string             `json:"pub_key"`
+	First  SignedVerification `json:"first"`
+	Second SignedVerification `json:"second"`
+}
+
+// Hash return the unique id of the evidence
+func (e *Evidence) Hash() bc.Hash {
+	hasher := sha3pool.Get256()
+	defer sha3pool.Put256(hasher)
+
+	hasher.Write([]byte(e.PubKey))
+	hasher.Write(e.First.Signature)
+	hasher.Write(e.Second.Signature)
+
+	var b32 [32]byte
+	hasher.Read(b32[:])
+	return bc.NewHash(b32)
+}
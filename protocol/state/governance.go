@@ -0,0 +1,91 @@
+package state
+
+import (
+	"sort"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// ParamChange is the consensus parameter change approved by the supermajority of the validators
+type ParamChange struct {
+	Key            string `json:"key"`
+	Value          uint64 `json:"value"`
+	ActivateHeight uint64 `json:"activate_height"`
+}
+
+// inheritParams copy the changed consensus parameters from the parent, the pending changes
+// which activate at the first block of the new epoch take effect
+func (c *Checkpoint) inheritParams(parent *Checkpoint) {
+	for key, value := range parent.Params {
+		c.setParam(key, value)
+	}
+
+	for _, change := range parent.ParamChanges {
+		if change.ActivateHeight <= parent.Height+1 {
+			c.setParam(change.Key, change.Value)
+			continue
+		}
+
+		copied := *change
+		c.ParamChanges = append(c.ParamChanges, &copied)
+	}
+}
+
+func (c *Checkpoint) setParam(key string, value uint64) {
+	if c.Params == nil {
+		c.Params = make(map[string]uint64)
+	}
+	c.Params[key] = value
+}
+
+// applyParamProposals record the param proposals of the block as pending changes, the
+// proposals have been verified by casper
+func (c *Checkpoint) applyParamProposals(block *types.Block) {
+	for _, proposal := range block.ParamProposals {
+		c.ParamChanges = append(c.ParamChanges, &ParamChange{
+			Key:            proposal.Key,
+			Value:          proposal.Value,
+			ActivateHeight: proposal.ActivateHeight,
+		})
+	}
+
+	sort.SliceStable(c.ParamChanges, func(i, j int) bool {
+		return c.ParamChanges[i].ActivateHeight < c.ParamChanges[j].ActivateHeight
+	})
+}
+
+// HasParamChange return whether the change of the parameter activating at the height has been recorded
+func (c *Checkpoint) HasParamChange(key string, activateHeight uint64) bool {
+	for _, change := range c.ParamChanges {
+		if change.Key == key && change.ActivateHeight == activateHeight {
+			return true
+		}
+	}
+	return false
+}
+
+// Param return the value of the consensus parameter effective at the block height, the height
+// should not be lower than the first block of the checkpoint's epoch
+func (c *Checkpoint) Param(key string, height uint64) uint64 {
+	value, ok := c.Params[key]
+	if !ok {
+		value, _ = consensus.DefaultParam(key, height)
+	}
+
+	for _, change := range c.ParamChanges {
+		if change.Key == key && change.ActivateHeight <= height {
+			value = change.Value
+		}
+	}
+	return value
+}
+
+// ParamsAt return all the governable consensus parameters effective at the block height
+func (c *Checkpoint) ParamsAt(height uint64) map[string]uint64 {
+	params := consensus.DefaultParams(height)
+	for key := range params {
+		params[key] = c.Param(key, height)
+	}
+	return params
+}
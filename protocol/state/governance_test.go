@@ -0,0 +1,60 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+func TestParamChanges(t *testing.T) {
+	blocksOfEpoch := consensus.ActiveNetParams.BlocksOfEpoch
+	minVoteNum := consensus.ActiveNetParams.MinValidatorVoteNum
+	parent := &Checkpoint{
+		Height: blocksOfEpoch,
+		Hash:   bc.Hash{V0: 1},
+		Status: Unjustified,
+		Votes:  map[string]uint64{"aa": 3 * minVoteNum, "bb": 2 * minVoteNum, "cc": minVoteNum},
+	}
+
+	checkpoint := NewCheckpoint(parent)
+	checkpoint.applyParamProposals(&types.Block{
+		BlockHeader: types.BlockHeader{
+			ParamProposals: types.ParamProposals{
+				{Key: consensus.ParamMaxBlockGas, Value: 20000000, ActivateHeight: 3*blocksOfEpoch + 1},
+				{Key: consensus.ParamMaxNumOfValidators, Value: 2, ActivateHeight: 2*blocksOfEpoch + 1},
+			},
+		},
+	})
+
+	if !checkpoint.HasParamChange(consensus.ParamMaxNumOfValidators, 2*blocksOfEpoch+1) {
+		t.Fatalf("param change of max num of validators is not recorded")
+	}
+
+	if got := checkpoint.ParamChanges[0].Key; got != consensus.ParamMaxNumOfValidators {
+		t.Errorf("got first param change %s, want the one activates first", got)
+	}
+
+	checkpoint.Height, checkpoint.Status = 2*blocksOfEpoch, Unjustified
+	if got := checkpoint.Param(consensus.ParamMaxBlockGas, 2*blocksOfEpoch+1); got != consensus.MaxBlockGas {
+		t.Errorf("got max block gas %d before activated, want %d", got, consensus.MaxBlockGas)
+	}
+
+	if got := len(checkpoint.EffectiveValidators()); got != 2 {
+		t.Errorf("got %d validators of the next epoch, want 2", got)
+	}
+
+	child := NewCheckpoint(checkpoint)
+	if got := child.Params[consensus.ParamMaxNumOfValidators]; got != 2 {
+		t.Errorf("got activated max num of validators %d, want 2", got)
+	}
+
+	if len(child.ParamChanges) != 1 || child.ParamChanges[0].Key != consensus.ParamMaxBlockGas {
+		t.Errorf("got pending param changes %v, want the change of max block gas", child.ParamChanges)
+	}
+
+	if got := child.Param(consensus.ParamMaxBlockGas, 3*blocksOfEpoch+1); got != 20000000 {
+		t.Errorf("got max block gas %d after activated, want 20000000", got)
+	}
+}
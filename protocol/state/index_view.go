@@ -0,0 +1,70 @@
+package state
+
+import (
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// IndexViewpoint represents the blocks attached to and detached from the main
+// chain in one chain status update, the store use it to maintain the indexes
+type IndexViewpoint struct {
+	AttachBlocks []*types.Block
+	DetachBlocks []*types.Block
+}
+
+// NewIndexViewpoint returns a new empty index view.
+func NewIndexViewpoint() *IndexViewpoint {
+	return &IndexViewpoint{}
+}
+
+// ApplyBlock add the block to the attach list of the index view
+func (view *IndexViewpoint) ApplyBlock(block *types.Block) {
+	view.AttachBlocks = append(view.AttachBlocks, block)
+}
+
+// DetachBlock add the block to the detach list of the index view, the blocks
+// should be detached from the chain tail
+func (view *IndexViewpoint) DetachBlock(block *types.Block) {
+	view.DetachBlocks = append(view.DetachBlocks, block)
+}
+
+// AddressTx is the main chain transaction related to a control program
+type AddressTx struct {
+	TxID        bc.Hash `json:"tx_id"`
+	BlockHeight uint64  `json:"block_height"`
+	Position    uint32  `json:"position"`
+}
+
+// AddressUtxo is the unspent output locked by a control program
+type AddressUtxo struct {
+	OutputID       bc.Hash            `json:"output_id"`
+	AssetID        bc.AssetID         `json:"asset_id"`
+	Amount         uint64             `json:"amount"`
+	ControlProgram chainjson.HexBytes `json:"control_program"`
+	SourceID       bc.Hash            `json:"source_id"`
+	SourcePos      uint64             `json:"source_pos"`
+	Vote           chainjson.HexBytes `json:"vote,omitempty"`
+}
+
+// AddressIndexStore is implemented by the store which maintains the address index
+type AddressIndexStore interface {
+	AddressIndexEnabled() bool
+	AddressIndexStart() uint64
+	GetAddressHistory(program []byte, after string, count int) ([]*AddressTx, string, error)
+	GetAddressUtxos(program []byte, assetID *bc.AssetID) ([]*AddressUtxo, error)
+}
+
+// OutputSpender is the main chain transaction input which spends an output
+type OutputSpender struct {
+	OutputID    bc.Hash `json:"output_id"`
+	TxID        bc.Hash `json:"tx_id"`
+	InputIndex  uint32  `json:"input_index"`
+	BlockHeight uint64  `json:"block_height"`
+}
+
+// SpendIndexStore is implemented by the store which maintains the spend index
+type SpendIndexStore interface {
+	SpendIndexEnabled() bool
+	GetOutputSpender(outputID *bc.Hash) (*OutputSpender, error)
+}
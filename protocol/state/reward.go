@@ -26,12 +26,28 @@ func (c *Checkpoint) pledgeRate() float64 {
 	return float64(totalVotes) / float64(totalSupply)
 }
 
-// applyValidatorReward calculate the coinbase reward for validator
-func (c *Checkpoint) applyValidatorReward(block *types.Block) {
+// applyValidatorReward calculate the coinbase reward for validator, since the slashing
+// activated the slashed validator gets nothing from the blocks it produced
+func (c *Checkpoint) applyValidatorReward(block *types.Block, producer *Validator) {
 	validatorScript := hex.EncodeToString(block.Transactions[0].Outputs[0].ControlProgram)
+	if c.IsDeploymentActive(consensus.DeploymentSlashing, block.Height) && producer != nil {
+		if c.Slashed[producer.PubKey] {
+			return
+		}
+
+		if c.Producers == nil {
+			c.Producers = make(map[string]string)
+		}
+		c.Producers[validatorScript] = producer.PubKey
+	}
+
+	reward := c.validatorReward()
 	for _, tx := range block.Transactions {
-		c.Rewards[validatorScript] += tx.Fee()
+		reward += tx.Fee()
 	}
 
-	c.Rewards[validatorScript] += c.validatorReward()
+	c.Rewards[validatorScript] += reward
+	if producer != nil {
+		c.validatorStats(producer.PubKey).Reward += reward
+	}
 }
@@ -0,0 +1,39 @@
+package state
+
+import (
+	"encoding/hex"
+
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// applySlashEvidences slash the validators proved to sign conflicting verifications
+// by the evidences of the block
+func (c *Checkpoint) applySlashEvidences(block *types.Block) {
+	for _, evidence := range block.SlashEvidences {
+		c.slash(hex.EncodeToString(evidence.PubKey))
+	}
+}
+
+// slash mark the validator as slashed, the slashed validator forfeits the reward of
+// the current epoch and is removed from the validators of the subsequent epochs
+func (c *Checkpoint) slash(pubKey string) {
+	if c.Slashed == nil {
+		c.Slashed = make(map[string]bool)
+	}
+	c.Slashed[pubKey] = true
+	if stats, ok := c.Stats[pubKey]; ok {
+		stats.Reward = 0
+	}
+
+	for script, producer := range c.Producers {
+		if producer == pubKey {
+			delete(c.Rewards, script)
+			delete(c.Producers, script)
+		}
+	}
+}
+
+// IsSlashed return whether the validator has been slashed
+func (c *Checkpoint) IsSlashed(pubKey string) bool {
+	return c.Slashed[pubKey]
+}
@@ -0,0 +1,79 @@
+package state
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+func TestSlashValidator(t *testing.T) {
+	deployment := consensus.ActiveNetParams.Deployment(consensus.DeploymentSlashing)
+	activationHeight := deployment.ActivationHeight
+	deployment.ActivationHeight = 0
+	defer func() { deployment.ActivationHeight = activationHeight }()
+
+	minVoteNum := consensus.ActiveNetParams.MinValidatorVoteNum
+	parent := &Checkpoint{
+		Height: consensus.ActiveNetParams.BlocksOfEpoch,
+		Hash:   bc.Hash{V0: 1},
+		Status: Unjustified,
+		Votes:  map[string]uint64{"aa": 3 * minVoteNum, "bb": 2 * minVoteNum, "cc": minVoteNum},
+	}
+	checkpoint := NewCheckpoint(parent)
+
+	// the validators produce the blocks in the order of the votes
+	interval := consensus.ActiveNetParams.BlockTimeInterval
+	programs := []string{"51", "52", "53", "54"}
+	for i, program := range programs {
+		controlProgram, _ := hex.DecodeString(program)
+		block := &types.Block{
+			BlockHeader: types.BlockHeader{
+				Height:            checkpoint.Height + 1,
+				PreviousBlockHash: checkpoint.Hash,
+				Timestamp:         uint64(i+1) * interval,
+			},
+			Transactions: []*types.Tx{
+				types.NewTx(types.TxData{
+					Inputs:  []*types.TxInput{types.NewCoinbaseInput(nil)},
+					Outputs: []*types.TxOutput{types.NewOriginalTxOutput(*consensus.BTMAssetID, 0, controlProgram, nil)},
+				}),
+			},
+		}
+		if i == 2 {
+			block.SlashEvidences = types.SlashEvidences{{PubKey: []byte{0xaa}}}
+		}
+
+		if err := checkpoint.Increase(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !checkpoint.IsSlashed("aa") || checkpoint.IsSlashed("bb") {
+		t.Errorf("got slashed validators %v, want only aa", checkpoint.Slashed)
+	}
+
+	for _, program := range []string{"51", "54"} {
+		if _, ok := checkpoint.Rewards[program]; ok {
+			t.Errorf("slashed validator got the reward of program %s", program)
+		}
+	}
+
+	for _, program := range []string{"52", "53"} {
+		if _, ok := checkpoint.Rewards[program]; !ok {
+			t.Errorf("validator lost the reward of program %s", program)
+		}
+	}
+
+	checkpoint.Status = Unjustified
+	validators := checkpoint.EffectiveValidators()
+	if _, ok := validators["aa"]; ok || len(validators) != 2 {
+		t.Errorf("got validators %v, want the slashed validator removed", validators)
+	}
+
+	if child := NewCheckpoint(checkpoint); !child.IsSlashed("aa") {
+		t.Errorf("slashed validator is not inherited by the next checkpoint")
+	}
+}
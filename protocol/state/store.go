@@ -17,15 +17,21 @@ type Store interface {
 	GetUtxo(*bc.Hash) (*storage.UtxoEntry, error)
 	GetMainChainHash(uint64) (*bc.Hash, error)
 	GetContract(hash [32]byte) ([]byte, error)
+	GetTransactionIndex(*bc.Hash) (*bc.Hash, uint64, error)
+	GetBlockSpentUtxos(*UtxoViewpoint, *bc.Hash) error
 
 	GetCheckpoint(*bc.Hash) (*Checkpoint, error)
 	CheckpointsFromNode(height uint64, hash *bc.Hash) ([]*Checkpoint, error)
 	GetCheckpointsByHeight(uint64) ([]*Checkpoint, error)
 	SaveCheckpoints([]*Checkpoint) error
 
+	GetEvidence(*bc.Hash) (*Evidence, error)
+	ListEvidences() ([]*Evidence, error)
+	SaveEvidence(*Evidence) error
+
 	SaveBlock(*types.Block) error
 	SaveBlockHeader(*types.BlockHeader) error
-	SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *UtxoViewpoint, *ContractViewpoint, uint64, *bc.Hash) error
+	SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *UtxoViewpoint, *ContractViewpoint, *IndexViewpoint, uint64, *bc.Hash) error
 }
 
 // BlockStoreState represents the core's db status
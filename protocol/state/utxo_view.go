@@ -19,15 +19,17 @@ func NewUtxoViewpoint() *UtxoViewpoint {
 	}
 }
 
-func (view *UtxoViewpoint) ApplyTransaction(block *bc.Block, tx *bc.Tx) error {
-	if err := view.applySpendUtxo(block, tx); err != nil {
+// ApplyTransaction spend the utxos of the tx and add its outputs, the vote utxos are locked by the
+// vote pending block num of the checkpoint previous to the block, it's nil for the genesis block
+func (view *UtxoViewpoint) ApplyTransaction(block *bc.Block, tx *bc.Tx, checkpoint *Checkpoint) error {
+	if err := view.applySpendUtxo(block, tx, checkpoint); err != nil {
 		return err
 	}
 
 	return view.applyOutputUtxo(block, tx)
 }
 
-func (view *UtxoViewpoint) applySpendUtxo(block *bc.Block, tx *bc.Tx) error {
+func (view *UtxoViewpoint) applySpendUtxo(block *bc.Block, tx *bc.Tx, checkpoint *Checkpoint) error {
 	for _, prevout := range tx.SpentOutputIDs {
 		entry, ok := view.Entries[prevout]
 		if !ok {
@@ -42,7 +44,7 @@ func (view *UtxoViewpoint) applySpendUtxo(block *bc.Block, tx *bc.Tx) error {
 				return errors.New("coinbase utxo is not ready for use")
 			}
 		case storage.VoteUTXOType:
-			if entry.BlockHeight + consensus.VotePendingBlockNums(block.Height) > block.Height {
+			if entry.BlockHeight+votePendingBlockNum(checkpoint, block.Height) > block.Height {
 				return errors.New("Coin is  within the voting lock time")
 			}
 		}
@@ -87,9 +89,10 @@ func (view *UtxoViewpoint) applyOutputUtxo(block *bc.Block, tx *bc.Tx) error {
 	return nil
 }
 
-func (view *UtxoViewpoint) ApplyBlock(block *bc.Block) error {
+// ApplyBlock apply the txs of the block, the checkpoint is the one previous to the block
+func (view *UtxoViewpoint) ApplyBlock(block *bc.Block, checkpoint *Checkpoint) error {
 	for _, tx := range block.Transactions {
-		if err := view.ApplyTransaction(block, tx); err != nil {
+		if err := view.ApplyTransaction(block, tx, checkpoint); err != nil {
 			return err
 		}
 	}
@@ -183,3 +186,10 @@ func (view *UtxoViewpoint) HasUtxo(hash *bc.Hash) bool {
 	_, ok := view.Entries[*hash]
 	return ok
 }
+
+func votePendingBlockNum(checkpoint *Checkpoint, height uint64) uint64 {
+	if checkpoint == nil {
+		return consensus.VotePendingBlockNums(height)
+	}
+	return checkpoint.Param(consensus.ParamVotePendingBlockNum, height)
+}
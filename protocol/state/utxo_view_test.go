@@ -273,7 +273,7 @@ func TestApplyBlock(t *testing.T) {
 	}
 
 	for i, c := range cases {
-		if err := c.inputView.ApplyBlock(c.block); c.err != (err != nil) {
+		if err := c.inputView.ApplyBlock(c.block, nil); c.err != (err != nil) {
 			t.Errorf("case #%d want err = %v, get err = %v", i, c.err, err)
 		}
 		if c.err {
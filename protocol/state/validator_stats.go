@@ -0,0 +1,90 @@
+package state
+
+import (
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// ValidatorStats is the performance of the validator in one epoch
+type ValidatorStats struct {
+	ProposedBlocks     uint64 `json:"proposed_blocks"`
+	MissedSlots        uint64 `json:"missed_slots"`
+	Reward             uint64 `json:"reward"`
+	SignedVerification bool   `json:"signed_verification"`
+}
+
+func (c *Checkpoint) validatorStats(pubKey string) *ValidatorStats {
+	if c.Stats == nil {
+		c.Stats = make(map[string]*ValidatorStats)
+	}
+
+	if _, ok := c.Stats[pubKey]; !ok {
+		c.Stats[pubKey] = &ValidatorStats{}
+	}
+	return c.Stats[pubKey]
+}
+
+// blockProducer return the validator which should produce the block, it's nil
+// when the parent checkpoint is not in the memory
+func (c *Checkpoint) blockProducer(block *types.Block) *Validator {
+	if c.Parent == nil {
+		return nil
+	}
+
+	return c.Parent.GetValidator(block.Timestamp)
+}
+
+// applyValidatorStats count the block for the producer, and the slots between the
+// previous block and the block as missed by the validators of them, it must be
+// called before the timestamp of the checkpoint is updated by the block
+func (c *Checkpoint) applyValidatorStats(block *types.Block, producer *Validator) {
+	if producer == nil {
+		return
+	}
+
+	c.validatorStats(producer.PubKey).ProposedBlocks++
+
+	// the slots are counted from the last block of the parent checkpoint, the
+	// slot 1 is the first slot of the epoch
+	interval := consensus.ActiveNetParams.BlockTimeInterval
+	baseTimestamp := c.Parent.Timestamp
+	if c.Timestamp < baseTimestamp || block.Timestamp < c.Timestamp {
+		return
+	}
+
+	prevSlot := (c.Timestamp - baseTimestamp) / interval
+	slot := (block.Timestamp - baseTimestamp) / interval
+	if slot <= prevSlot+1 {
+		return
+	}
+
+	validators := c.Parent.EffectiveValidators()
+	numOfValidators := uint64(len(validators))
+	missedSlots := slot - prevSlot - 1
+	for _, validator := range validators {
+		c.validatorStats(validator.PubKey).MissedSlots += missedSlots / numOfValidators
+	}
+
+	for i := uint64(1); i <= missedSlots%numOfValidators; i++ {
+		order := int((prevSlot + i - 1) % numOfValidators)
+		for _, validator := range validators {
+			if validator.Order == order {
+				c.validatorStats(validator.PubKey).MissedSlots++
+			}
+		}
+	}
+}
+
+// applyVerificationStats record the validator of the order signed the verification to the
+// checkpoint, the order is of the validators decided by the parent checkpoint
+func (c *Checkpoint) applyVerificationStats(validatorOrder int) {
+	if c.Parent == nil {
+		return
+	}
+
+	for _, validator := range c.Parent.EffectiveValidators() {
+		if validator.Order == validatorOrder {
+			c.validatorStats(validator.PubKey).SignedVerification = true
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestApplyValidatorStats(t *testing.T) {
+	minVoteNum := consensus.ActiveNetParams.MinValidatorVoteNum
+	parent := &Checkpoint{
+		Height:    consensus.ActiveNetParams.BlocksOfEpoch,
+		Hash:      bc.Hash{V0: 1},
+		Timestamp: 1000,
+		Status:    Unjustified,
+		Votes:     map[string]uint64{"aa": 3 * minVoteNum, "bb": 2 * minVoteNum, "cc": minVoteNum},
+	}
+	checkpoint := NewCheckpoint(parent)
+
+	// the validators aa, bb and cc produce the slot 1, 2, 3 of each round, the slot 3, 4, 5 and 7 are missed
+	interval := consensus.ActiveNetParams.BlockTimeInterval
+	for _, slot := range []uint64{1, 2, 6, 8} {
+		block := &types.Block{
+			BlockHeader: types.BlockHeader{
+				Height:            checkpoint.Height + 1,
+				PreviousBlockHash: checkpoint.Hash,
+				Timestamp:         parent.Timestamp + slot*interval + 1,
+			},
+			Transactions: []*types.Tx{
+				types.NewTx(types.TxData{
+					Inputs:  []*types.TxInput{types.NewCoinbaseInput(nil)},
+					Outputs: []*types.TxOutput{types.NewOriginalTxOutput(*consensus.BTMAssetID, 0, []byte{0x51}, nil)},
+				}),
+			},
+		}
+
+		if err := checkpoint.Increase(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]*ValidatorStats{
+		"aa": {ProposedBlocks: 1, MissedSlots: 2},
+		"bb": {ProposedBlocks: 2, MissedSlots: 1},
+		"cc": {ProposedBlocks: 1, MissedSlots: 1},
+	}
+
+	totalReward := uint64(0)
+	for _, stats := range checkpoint.Stats {
+		totalReward += stats.Reward
+		stats.Reward = 0
+	}
+
+	if !testutil.DeepEqual(checkpoint.Stats, want) {
+		t.Errorf("got validator stats %v, want %v", checkpoint.Stats, want)
+	}
+
+	if totalReward != checkpoint.Rewards["51"] {
+		t.Errorf("got total reward %d of validators, want %d", totalReward, checkpoint.Rewards["51"])
+	}
+}
+
+func TestApplyVerificationStats(t *testing.T) {
+	minVoteNum := consensus.ActiveNetParams.MinValidatorVoteNum
+	parent := &Checkpoint{
+		Height: consensus.ActiveNetParams.BlocksOfEpoch,
+		Hash:   bc.Hash{V0: 1},
+		Status: Justified,
+		Votes:  map[string]uint64{"aa": 3 * minVoteNum, "bb": 2 * minVoteNum, "cc": minVoteNum},
+	}
+	checkpoint := NewCheckpoint(parent)
+
+	validators := parent.EffectiveValidators()
+	checkpoint.AddVerification(parent.Hash, parent.Height, validators["aa"].Order, []byte{0x01})
+	checkpoint.AddVerification(parent.Hash, parent.Height, validators["cc"].Order, []byte{0x02})
+
+	want := map[string]*ValidatorStats{
+		"aa": {SignedVerification: true},
+		"cc": {SignedVerification: true},
+	}
+	if !testutil.DeepEqual(checkpoint.Stats, want) {
+		t.Errorf("got validator stats %v, want %v", checkpoint.Stats, want)
+	}
+}
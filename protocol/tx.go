@@ -1,9 +1,13 @@
 package protocol
 
 import (
+	"time"
+
 	log "github.com/sirupsen/logrus"
 
+	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/consensus/bcrp"
+	"github.com/bytom/bytom/database/storage"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
@@ -11,18 +15,79 @@ import (
 	"github.com/bytom/bytom/protocol/validation"
 )
 
-// ErrBadTx is returned for transactions failing validation
-var ErrBadTx = errors.New("invalid transaction")
+var (
+	// ErrBadTx is returned for transactions failing validation
+	ErrBadTx = errors.New("invalid transaction")
+	// ErrMissingUtxo is returned when the spent output is found neither in the chain nor in the pool
+	ErrMissingUtxo = errors.New("spent output of the transaction is not found")
+)
 
 // GetTransactionsUtxo return all the utxos that related to the txs' inputs
 func (c *Chain) GetTransactionsUtxo(view *state.UtxoViewpoint, txs []*bc.Tx) error {
 	return c.store.GetTransactionsUtxo(view, txs)
 }
 
+// SpentUtxoView return the view of the utxos spent by the txs validated at the height, it's nil
+// if no opcode reading the utxos is activated at the height by the checkpoint. The spent outputs
+// not confirmed by the chain are created by the txs of the same block or the pool, and they are
+// regarded as confirmed at the height
+func (c *Chain) SpentUtxoView(txs []*bc.Tx, height uint64, checkpoint *state.Checkpoint) (*state.UtxoViewpoint, error) {
+	if !checkpoint.IsDeploymentActive(consensus.DeploymentRelativeTimelock, height) {
+		return nil, nil
+	}
+
+	view := state.NewUtxoViewpoint()
+	if err := c.loadSpentUtxos(view, txs, height); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// bestValidationContext return the best block header, the view of the utxos spent by the tx
+// and the checkpoint the tx is validated with on the best chain
+func (c *Chain) bestValidationContext(tx *types.Tx) (*types.BlockHeader, *state.UtxoViewpoint, *state.Checkpoint, error) {
+	bh := c.BestBlockHeader()
+	checkpoint, err := c.PrevCheckpointByPrevHash(&bh.PreviousBlockHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	view, err := c.SpentUtxoView([]*bc.Tx{tx.Tx}, bh.Height, checkpoint)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return bh, view, checkpoint, nil
+}
+
+func (c *Chain) loadSpentUtxos(view *state.UtxoViewpoint, txs []*bc.Tx, height uint64) error {
+	if err := c.store.GetTransactionsUtxo(view, txs); err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		for _, outputID := range tx.SpentOutputIDs {
+			if !view.HasUtxo(&outputID) {
+				view.Entries[outputID] = storage.NewUtxoEntry(storage.NormalUTXOType, height, false)
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateTx validates the given transaction. A cache holds
 // per-transaction validation results and is consulted before
 // performing full validation.
 func (c *Chain) ValidateTx(tx *types.Tx) (bool, error) {
+	return c.validateTx(tx, time.Now())
+}
+
+// ReloadTx validates the transaction dumped by the txpool on last stop and adds it back, the
+// transaction keeps the time it was first added to the txpool
+func (c *Chain) ReloadTx(tx *types.Tx, added time.Time) (bool, error) {
+	return c.validateTx(tx, added)
+}
+
+func (c *Chain) validateTx(tx *types.Tx, added time.Time) (bool, error) {
 	if ok := c.txPool.HaveTransaction(&tx.ID); ok {
 		return false, c.txPool.GetErrCache(&tx.ID)
 	}
@@ -32,15 +97,62 @@ func (c *Chain) ValidateTx(tx *types.Tx) (bool, error) {
 		return false, ErrDustTx
 	}
 
-	bh := c.BestBlockHeader()
-	gasStatus, err := validation.ValidateTx(tx.Tx, types.MapBlock(&types.Block{BlockHeader: *bh}), c.ProgramConverter)
+	bh, view, checkpoint, err := c.bestValidationContext(tx)
+	if err != nil {
+		return false, err
+	}
+
+	gasStatus, err := validation.ValidateTxWithView(tx.Tx, types.MapBlock(&types.Block{BlockHeader: *bh}), view, checkpoint, c.ProgramConverter)
 	if err != nil {
 		log.WithFields(log.Fields{"module": logModule, "tx_id": tx.Tx.ID.String(), "error": err}).Info("transaction status fail")
 		c.txPool.AddErrCache(&tx.ID, err)
 		return false, err
 	}
 
-	return c.txPool.ProcessTransaction(tx, bh.Height, gasStatus.BTMValue)
+	return c.txPool.processTransaction(tx, bh.Height, gasStatus.BTMValue, uint64(gasStatus.GasUsed), added)
+}
+
+// checkTxUtxos check the spent outputs of the tx are in the best chain or the pool
+func (c *Chain) checkTxUtxos(tx *types.Tx) error {
+	missing, err := c.txPool.MissingUtxos(tx)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return errors.WithDetailf(ErrMissingUtxo, "spent output %s", missing[0].String())
+	}
+	return nil
+}
+
+// TraceTx validates the transaction against the utxos of the best chain and the pool without
+// adding it to the pool, the vm steps of the input programs are reported to the tracer
+func (c *Chain) TraceTx(tx *types.Tx, tracer validation.Tracer) (*validation.GasState, error) {
+	if err := c.checkTxUtxos(tx); err != nil {
+		return nil, err
+	}
+
+	bh, view, checkpoint, err := c.bestValidationContext(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return validation.ValidateTxWithTracer(tx.Tx, types.MapBlock(&types.Block{BlockHeader: *bh}), view, checkpoint, c.ProgramConverter, tracer)
+}
+
+// SimulateTx runs the transaction against the utxos of the best chain and the pool without
+// adding it to the pool, the results of the input programs are returned even if they fail
+func (c *Chain) SimulateTx(tx *types.Tx, skipSig bool) (*validation.GasState, map[bc.Hash]*validation.ProgramResult, error) {
+	if err := c.checkTxUtxos(tx); err != nil {
+		return nil, nil, err
+	}
+
+	bh, view, checkpoint, err := c.bestValidationContext(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return validation.SimulateTx(tx.Tx, types.MapBlock(&types.Block{BlockHeader: *bh}), view, checkpoint, c.ProgramConverter, skipSig)
 }
 
 //ProgramConverter convert program. Only for BCRP now
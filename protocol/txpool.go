@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"container/heap"
 	"errors"
 	"sync"
 	"sync/atomic"
@@ -37,6 +38,12 @@ var (
 	ErrTransactionNotExist = errors.New("transaction are not existed in the mempool")
 	// ErrPoolIsFull indicates the pool is full
 	ErrPoolIsFull = errors.New("transaction pool reach the max number")
+	// ErrLowFeeRate indicates the pool is full and the tx can't evict any tx by fee rate
+	ErrLowFeeRate = errors.New("transaction fee rate is too low for the full pool")
+	// ErrReplaceFeeTooLow indicates the replacement tx doesn't pay more than the replaced txs
+	ErrReplaceFeeTooLow = errors.New("replacement transaction fee is not higher than the replaced ones")
+	// ErrReplaceSpendConflict indicates the replacement tx spends the outputs of the replaced txs
+	ErrReplaceSpendConflict = errors.New("replacement transaction spends the outputs of the replaced ones")
 	// ErrDustTx indicates transaction is dust tx
 	ErrDustTx = errors.New("transaction is dust tx")
 )
@@ -45,11 +52,28 @@ type TxMsgEvent struct{ TxMsg *TxPoolMsg }
 
 // TxDesc store tx and related info for mining strategy
 type TxDesc struct {
-	Tx     *types.Tx `json:"transaction"`
-	Added  time.Time `json:"-"`
-	Height uint64    `json:"-"`
-	Weight uint64    `json:"-"`
-	Fee    uint64    `json:"-"`
+	Tx      *types.Tx `json:"transaction"`
+	Added   time.Time `json:"-"`
+	Height  uint64    `json:"-"`
+	Weight  uint64    `json:"-"`
+	Fee     uint64    `json:"-"`
+	GasUsed uint64    `json:"-"`
+}
+
+// FeeRate return the fee paid per unit of weight
+func (t *TxDesc) FeeRate() float64 {
+	if t.Weight == 0 {
+		return 0
+	}
+	return float64(t.Fee) / float64(t.Weight)
+}
+
+// GasRate return the fee paid per unit of gas used
+func (t *TxDesc) GasRate() float64 {
+	if t.GasUsed == 0 {
+		return 0
+	}
+	return float64(t.Fee) / float64(t.GasUsed)
 }
 
 // TxPoolMsg is use for notify pool changes
@@ -68,8 +92,13 @@ type TxPool struct {
 	lastUpdated     int64
 	mtx             sync.RWMutex
 	store           state.Store
+	maxNumTxs       int
 	pool            map[bc.Hash]*TxDesc
 	utxo            map[bc.Hash]*types.Tx
+	spent           map[bc.Hash]*types.Tx
+	feeRateQueue    txFeeRateQueue
+	feeRateItems    map[bc.Hash]*txFeeRateItem
+	feeEstimator    feeEstimator
 	orphans         map[bc.Hash]*orphanTx
 	orphansByPrev   map[bc.Hash]map[bc.Hash]*orphanTx
 	errCache        *lru.Cache
@@ -81,8 +110,11 @@ func NewTxPool(store state.Store, dispatcher *event.Dispatcher) *TxPool {
 	tp := &TxPool{
 		lastUpdated:     time.Now().Unix(),
 		store:           store,
+		maxNumTxs:       maxNewTxNum,
 		pool:            make(map[bc.Hash]*TxDesc),
 		utxo:            make(map[bc.Hash]*types.Tx),
+		spent:           make(map[bc.Hash]*types.Tx),
+		feeRateItems:    make(map[bc.Hash]*txFeeRateItem),
 		orphans:         make(map[bc.Hash]*orphanTx),
 		orphansByPrev:   make(map[bc.Hash]map[bc.Hash]*orphanTx),
 		errCache:        lru.New(maxCachedErrTxs),
@@ -100,6 +132,18 @@ func (tp *TxPool) AddErrCache(txHash *bc.Hash, err error) {
 	tp.errCache.Add(txHash, err)
 }
 
+// SetMaxNumTxs set the max number of transactions the pool holds, the non-positive value is ignored
+func (tp *TxPool) SetMaxNumTxs(num int) {
+	if num <= 0 {
+		return
+	}
+
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+
+	tp.maxNumTxs = num
+}
+
 // ExpireOrphan expire all the orphans that before the input time range
 func (tp *TxPool) ExpireOrphan(now time.Time) {
 	tp.mtx.Lock()
@@ -129,21 +173,71 @@ func (tp *TxPool) RemoveTransaction(txHash *bc.Hash) {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 
+	tp.removeTransaction(txHash)
+}
+
+func (tp *TxPool) removeTransaction(txHash *bc.Hash) {
 	txD, ok := tp.pool[*txHash]
 	if !ok {
 		return
 	}
 
-	for _, output := range txD.Tx.ResultIds {
-		delete(tp.utxo, *output)
-	}
-	delete(tp.pool, *txHash)
-
+	tp.deleteTransaction(txD)
 	atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 	tp.eventDispatcher.Post(TxMsgEvent{TxMsg: &TxPoolMsg{TxDesc: txD, MsgType: MsgRemoveTx}})
 	log.WithFields(log.Fields{"module": logModule, "tx_id": txHash}).Debug("remove tx from mempool")
 }
 
+// removeTransactionWithDescendants remove the tx and all the pool txs rely on it
+func (tp *TxPool) removeTransactionWithDescendants(txD *TxDesc) {
+	for _, descendant := range tp.descendants(txD) {
+		tp.removeTransaction(&descendant.Tx.ID)
+	}
+	tp.removeTransaction(&txD.Tx.ID)
+}
+
+// insertTransaction put the tx into the pool indexes and update the package score of its ancestors
+func (tp *TxPool) insertTransaction(txD *TxDesc) {
+	tx := txD.Tx
+	tp.pool[tx.ID] = txD
+	for _, id := range tx.ResultIds {
+		_, err := tx.OriginalOutput(*id)
+		if err != nil {
+			// error due to it's a retirement, utxo doesn't care this output type so skip it
+			continue
+		}
+
+		tp.utxo[*id] = tx
+	}
+	for _, spent := range tx.SpentOutputIDs {
+		tp.spent[spent] = tx
+	}
+
+	item := &txFeeRateItem{TxDesc: txD, descendantFee: txD.Fee, descendantGas: txD.GasUsed}
+	heap.Push(&tp.feeRateQueue, item)
+	tp.feeRateItems[tx.ID] = item
+	tp.updateDescendantScore(tp.ancestors(txD))
+}
+
+// deleteTransaction drop the tx from the pool indexes and update the package score of its ancestors
+func (tp *TxPool) deleteTransaction(txD *TxDesc) {
+	ancestors := tp.ancestors(txD)
+	for _, output := range txD.Tx.ResultIds {
+		delete(tp.utxo, *output)
+	}
+	for _, spent := range txD.Tx.SpentOutputIDs {
+		if tp.spent[spent] == txD.Tx {
+			delete(tp.spent, spent)
+		}
+	}
+	if item, ok := tp.feeRateItems[txD.Tx.ID]; ok {
+		heap.Remove(&tp.feeRateQueue, item.index)
+		delete(tp.feeRateItems, txD.Tx.ID)
+	}
+	delete(tp.pool, txD.Tx.ID)
+	tp.updateDescendantScore(ancestors)
+}
+
 // GetTransaction return the TxDesc by hash
 func (tp *TxPool) GetTransaction(txHash *bc.Hash) (*TxDesc, error) {
 	tp.mtx.RLock()
@@ -223,15 +317,19 @@ func (tp *TxPool) IsDust(tx *types.Tx) bool {
 	return isTransactionNoBtmInput(tx) || isTransactionZeroOutput(tx) || isInvalidBCRPTx(tx)
 }
 
-func (tp *TxPool) processTransaction(tx *types.Tx, height, fee uint64) (bool, error) {
+// processTransaction add the tx to the pool or the orphans, the added time is kept by the
+// tx so that the tx reloaded from the dump expires as if it's never reloaded
+func (tp *TxPool) processTransaction(tx *types.Tx, height, fee, gasUsed uint64, added time.Time) (bool, error) {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 
 	txD := &TxDesc{
-		Tx:     tx,
-		Weight: tx.SerializedSize,
-		Height: height,
-		Fee:    fee,
+		Tx:      tx,
+		Added:   added,
+		Weight:  tx.SerializedSize,
+		Height:  height,
+		Fee:     fee,
+		GasUsed: gasUsed,
 	}
 	requireParents, err := tp.checkOrphanUtxos(tx)
 	if err != nil {
@@ -251,12 +349,12 @@ func (tp *TxPool) processTransaction(tx *types.Tx, height, fee uint64) (bool, er
 }
 
 // ProcessTransaction is the main entry for txpool handle new tx, ignore dust tx.
-func (tp *TxPool) ProcessTransaction(tx *types.Tx, height, fee uint64) (bool, error) {
+func (tp *TxPool) ProcessTransaction(tx *types.Tx, height, fee, gasUsed uint64) (bool, error) {
 	if tp.IsDust(tx) {
 		log.WithFields(log.Fields{"module": logModule, "tx_id": tx.ID.String()}).Warn("dust tx")
 		return false, nil
 	}
-	return tp.processTransaction(tx, height, fee)
+	return tp.processTransaction(tx, height, fee, gasUsed, time.Now())
 }
 
 func (tp *TxPool) addOrphan(txD *TxDesc, requireParents []*bc.Hash) error {
@@ -264,7 +362,12 @@ func (tp *TxPool) addOrphan(txD *TxDesc, requireParents []*bc.Hash) error {
 		return ErrPoolIsFull
 	}
 
-	orphan := &orphanTx{txD, time.Now().Add(orphanTTL)}
+	expiration := time.Now().Add(orphanTTL)
+	if !txD.Added.IsZero() {
+		expiration = txD.Added.Add(orphanTTL)
+	}
+
+	orphan := &orphanTx{txD, expiration}
 	tp.orphans[txD.Tx.ID] = orphan
 	for _, hash := range requireParents {
 		if _, ok := tp.orphansByPrev[*hash]; !ok {
@@ -276,21 +379,25 @@ func (tp *TxPool) addOrphan(txD *TxDesc, requireParents []*bc.Hash) error {
 }
 
 func (tp *TxPool) addTransaction(txD *TxDesc) error {
-	if len(tp.pool) >= maxNewTxNum {
-		return ErrPoolIsFull
+	replaced, err := tp.checkReplacement(txD)
+	if err != nil {
+		return err
+	}
+
+	for _, replacedTx := range replaced {
+		tp.removeTransaction(&replacedTx.Tx.ID)
+		log.WithFields(log.Fields{"module": logModule, "tx_id": replacedTx.Tx.ID.String(), "replaced_by": txD.Tx.ID.String()}).Debug("replace tx in mempool")
 	}
 
 	tx := txD.Tx
-	txD.Added = time.Now()
-	tp.pool[tx.ID] = txD
-	for _, id := range tx.ResultIds {
-		_, err := tx.OriginalOutput(*id)
-		if err != nil {
-			// error due to it's a retirement, utxo doesn't care this output type so skip it
-			continue
-		}
+	if txD.Added.IsZero() {
+		txD.Added = time.Now()
+	}
 
-		tp.utxo[*id] = tx
+	tp.insertTransaction(txD)
+	if err := tp.evictLowFeeRate(txD); err != nil {
+		tp.deleteTransaction(txD)
+		return err
 	}
 
 	atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
@@ -299,6 +406,81 @@ func (tp *TxPool) addTransaction(txD *TxDesc) error {
 	return nil
 }
 
+// checkReplacement return the pool txs replaced by the new tx. The tx which double spends with
+// the pool txs is accepted only if it pays a strictly higher fee than all the replaced txs and
+// their descendants, and a higher fee rate than every directly conflicted tx.
+func (tp *TxPool) checkReplacement(txD *TxDesc) ([]*TxDesc, error) {
+	conflicts := map[bc.Hash]*TxDesc{}
+	for _, spent := range txD.Tx.SpentOutputIDs {
+		if tx, ok := tp.spent[spent]; ok {
+			conflicts[tx.ID] = tp.pool[tx.ID]
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	replaced := map[bc.Hash]*TxDesc{}
+	for hash, conflict := range conflicts {
+		if txD.FeeRate() <= conflict.FeeRate() {
+			return nil, ErrReplaceFeeTooLow
+		}
+
+		replaced[hash] = conflict
+		for _, descendant := range tp.descendants(conflict) {
+			replaced[descendant.Tx.ID] = descendant
+		}
+	}
+
+	for _, parent := range tp.parents(txD.Tx) {
+		if _, ok := replaced[parent.ID]; ok {
+			return nil, ErrReplaceSpendConflict
+		}
+	}
+
+	replacedFee := uint64(0)
+	result := []*TxDesc{}
+	for _, desc := range replaced {
+		replacedFee += desc.Fee
+		result = append(result, desc)
+	}
+
+	if txD.Fee <= replacedFee {
+		return nil, ErrReplaceFeeTooLow
+	}
+	return result, nil
+}
+
+// evictLowFeeRate keep the pool size under the limit by evicting the packages with the lowest
+// fee per unit of gas, the new tx is rejected if the package it belongs to is the one to be evicted
+func (tp *TxPool) evictLowFeeRate(txD *TxDesc) error {
+	for len(tp.pool) > tp.maxNumTxs {
+		lowest := tp.feeRateQueue[0].TxDesc
+		if lowest == txD {
+			return ErrLowFeeRate
+		}
+
+		for _, descendant := range tp.descendants(lowest) {
+			if descendant == txD {
+				return ErrLowFeeRate
+			}
+		}
+
+		tp.removeTransactionWithDescendants(lowest)
+		log.WithFields(log.Fields{"module": logModule, "tx_id": lowest.Tx.ID.String()}).Debug("evict tx from mempool")
+	}
+	return nil
+}
+
+// MissingUtxos return the spent outputs of the tx found neither in the chain nor in the pool
+func (tp *TxPool) MissingUtxos(tx *types.Tx) ([]*bc.Hash, error) {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+
+	return tp.checkOrphanUtxos(tx)
+}
+
 func (tp *TxPool) checkOrphanUtxos(tx *types.Tx) ([]*bc.Hash, error) {
 	view := state.NewUtxoViewpoint()
 	if err := tp.store.GetTransactionsUtxo(view, []*bc.Tx{tx.Tx}); err != nil {
@@ -307,6 +489,7 @@ func (tp *TxPool) checkOrphanUtxos(tx *types.Tx) ([]*bc.Hash, error) {
 
 	hashes := []*bc.Hash{}
 	for _, hash := range tx.SpentOutputIDs {
+		hash := hash
 		if !view.CanSpend(&hash) && tp.utxo[hash] == nil {
 			hashes = append(hashes, &hash)
 		}
@@ -349,9 +532,13 @@ func (tp *TxPool) processOrphans(txD *TxDesc) {
 		}
 
 		if len(requireParents) == 0 {
-			addRely(processOrphan.Tx)
 			tp.removeOrphan(&processOrphan.Tx.ID)
-			tp.addTransaction(processOrphan.TxDesc)
+			if err := tp.addTransaction(processOrphan.TxDesc); err != nil {
+				log.WithFields(log.Fields{"module": logModule, "tx_id": processOrphan.Tx.ID.String(), "err": err}).Debug("processOrphans fail to add tx")
+				continue
+			}
+
+			addRely(processOrphan.Tx)
 		}
 	}
 }
@@ -0,0 +1,59 @@
+package protocol
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// DumpTx is the persisted form of the pool and orphan transactions
+type DumpTx struct {
+	Tx     *types.Tx `json:"transaction"`
+	Added  time.Time `json:"added"`
+	Orphan bool      `json:"orphan"`
+}
+
+// Dump write the pool and orphan transactions to the writer, the pool transactions
+// are sorted by the added time so that the parents are reloaded before the children
+func (tp *TxPool) Dump(w io.Writer) error {
+	tp.mtx.RLock()
+	dumpTxs := make([]*DumpTx, 0, len(tp.pool)+len(tp.orphans))
+	for _, txD := range tp.pool {
+		dumpTxs = append(dumpTxs, &DumpTx{Tx: txD.Tx, Added: txD.Added})
+	}
+
+	for _, orphan := range tp.orphans {
+		dumpTxs = append(dumpTxs, &DumpTx{Tx: orphan.Tx, Added: orphan.expiration.Add(-orphanTTL), Orphan: true})
+	}
+	tp.mtx.RUnlock()
+
+	sort.SliceStable(dumpTxs, func(i, j int) bool {
+		if dumpTxs[i].Orphan != dumpTxs[j].Orphan {
+			return !dumpTxs[i].Orphan
+		}
+		return dumpTxs[i].Added.Before(dumpTxs[j].Added)
+	})
+	return json.NewEncoder(w).Encode(dumpTxs)
+}
+
+// LoadDump read the dumped transactions from the reader, the ones added before
+// the expire time are dropped
+func LoadDump(r io.Reader, expire time.Time) ([]*DumpTx, error) {
+	dumpTxs := []*DumpTx{}
+	if err := json.NewDecoder(r).Decode(&dumpTxs); err != nil {
+		return nil, err
+	}
+
+	result := []*DumpTx{}
+	for _, dumpTx := range dumpTxs {
+		if dumpTx.Tx == nil || dumpTx.Added.Before(expire) {
+			continue
+		}
+
+		result = append(result, dumpTx)
+	}
+	return result, nil
+}
@@ -0,0 +1,218 @@
+package protocol
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+const (
+	// feeEstimateBlocks is the number of recent blocks tracked by the fee estimator
+	feeEstimateBlocks = 100
+	// feeEstimateSuccessRate is the least ratio of the tracked txs confirmed in the target blocks
+	feeEstimateSuccessRate = 0.85
+	// MaxFeeEstimateTarget is the max confirmation target could be estimated
+	MaxFeeEstimateTarget = feeEstimateBlocks / 2
+)
+
+var (
+	// ErrBadFeeEstimateTarget indicates the confirmation target is out of range
+	ErrBadFeeEstimateTarget = errors.New("fee estimate target should be in range of 1 to max estimate target")
+
+	// feeRateBuckets are the lower bounds of the fee per unit of gas ranges for the mempool histogram and fee estimation
+	feeRateBuckets = []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000, 20000, 50000, 100000}
+)
+
+// FeeRateBucket is the stat of the pool transactions in the range of fee per unit of gas
+type FeeRateBucket struct {
+	MinFeeRate  float64 `json:"min_fee_rate"`
+	TxCount     int     `json:"tx_count"`
+	TotalWeight uint64  `json:"total_weight"`
+}
+
+// TxPoolInfo is the summary of the pool status
+type TxPoolInfo struct {
+	TxCount     int              `json:"tx_count"`
+	OrphanCount int              `json:"orphan_count"`
+	TotalWeight uint64           `json:"total_weight"`
+	MaxNumTxs   int              `json:"max_num_txs"`
+	MinFeeRate  float64          `json:"min_fee_rate"`
+	Histogram   []*FeeRateBucket `json:"fee_rate_histogram"`
+}
+
+type confirmedTx struct {
+	gasRate    float64
+	waitBlocks uint64
+}
+
+type blockFeeStat struct {
+	gas uint64
+	txs []confirmedTx
+}
+
+// feeEstimator records how many blocks the pool transactions waited before
+// confirmed, grouped by the fee paid per unit of gas
+type feeEstimator struct {
+	blocks []*blockFeeStat
+}
+
+func feeRateBucketIndex(feeRate float64) int {
+	return sort.Search(len(feeRateBuckets), func(i int) bool { return feeRateBuckets[i] > feeRate }) - 1
+}
+
+func (e *feeEstimator) processBlock(height uint64, gas uint64, txDs []*TxDesc) {
+	stat := &blockFeeStat{gas: gas}
+	for _, txD := range txDs {
+		waitBlocks := uint64(1)
+		if height > txD.Height {
+			waitBlocks = height - txD.Height
+		}
+		stat.txs = append(stat.txs, confirmedTx{gasRate: txD.GasRate(), waitBlocks: waitBlocks})
+	}
+
+	if e.blocks = append(e.blocks, stat); len(e.blocks) > feeEstimateBlocks {
+		e.blocks = e.blocks[1:]
+	}
+}
+
+// avgBlockGas return the average transaction gas of the tracked blocks
+func (e *feeEstimator) avgBlockGas() uint64 {
+	if len(e.blocks) == 0 {
+		return 0
+	}
+
+	total := uint64(0)
+	for _, stat := range e.blocks {
+		total += stat.gas
+	}
+	return total / uint64(len(e.blocks))
+}
+
+// estimate return the lowest bucket gas rate that the transactions paying no less than
+// it are confirmed in the target blocks at the success rate, 0 means no enough data
+func (e *feeEstimator) estimate(target uint64) float64 {
+	confirmed := make([]int, len(feeRateBuckets))
+	total := make([]int, len(feeRateBuckets))
+	for _, stat := range e.blocks {
+		for _, tx := range stat.txs {
+			index := feeRateBucketIndex(tx.gasRate)
+			if total[index]++; tx.waitBlocks <= target {
+				confirmed[index]++
+			}
+		}
+	}
+
+	result, sumConfirmed, sumTotal := float64(0), 0, 0
+	for i := len(feeRateBuckets) - 1; i >= 0; i-- {
+		if total[i] == 0 {
+			continue
+		}
+
+		sumConfirmed, sumTotal = sumConfirmed+confirmed[i], sumTotal+total[i]
+		if float64(sumConfirmed)/float64(sumTotal) < feeEstimateSuccessRate {
+			break
+		}
+		result = feeRateBuckets[i]
+	}
+	return result
+}
+
+// ConfirmBlock record the fee stat of the pool transactions packed by the connected block,
+// it should be called before the block transactions are removed from the pool. The gas of
+// the transactions never in the pool is unknown, their storage gas is counted instead
+func (tp *TxPool) ConfirmBlock(block *types.Block) {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+
+	gas, txDs := uint64(0), []*TxDesc{}
+	for _, tx := range block.Transactions[1:] {
+		txD, ok := tp.pool[tx.ID]
+		if !ok {
+			gas += tx.SerializedSize * uint64(consensus.StorageGasRate)
+			continue
+		}
+
+		gas += txD.GasUsed
+		txDs = append(txDs, txD)
+	}
+	tp.feeEstimator.processBlock(block.Height, gas, txDs)
+}
+
+// GetInfo return the summary of the pool transactions
+func (tp *TxPool) GetInfo() *TxPoolInfo {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+
+	info := &TxPoolInfo{
+		TxCount:     len(tp.pool),
+		OrphanCount: len(tp.orphans),
+		MaxNumTxs:   tp.maxNumTxs,
+		MinFeeRate:  tp.minFeeRate(),
+	}
+	for _, feeRate := range feeRateBuckets {
+		info.Histogram = append(info.Histogram, &FeeRateBucket{MinFeeRate: feeRate})
+	}
+
+	for _, txD := range tp.pool {
+		bucket := info.Histogram[feeRateBucketIndex(txD.GasRate())]
+		bucket.TxCount++
+		bucket.TotalWeight += txD.Weight
+		info.TotalWeight += txD.Weight
+	}
+	return info
+}
+
+// EstimateFeeRate return the recommended fee per unit of gas for the transaction to be
+// confirmed in the target number of blocks, the result is the higher one of the rate
+// estimated from the recently confirmed transactions and the rate needed to get ahead
+// of the pool transactions which fill the target blocks
+func (tp *TxPool) EstimateFeeRate(target uint64) (float64, error) {
+	if target == 0 || target > MaxFeeEstimateTarget {
+		return 0, ErrBadFeeEstimateTarget
+	}
+
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+
+	feeRate := tp.feeEstimator.estimate(target)
+	if poolRate := tp.poolGasRate(target * tp.feeEstimator.avgBlockGas()); poolRate > feeRate {
+		feeRate = poolRate
+	}
+
+	if minRate := tp.minFeeRate(); minRate > feeRate {
+		feeRate = minRate
+	}
+	return feeRate, nil
+}
+
+// minFeeRate return the gas rate a new tx must exceed to enter the full pool
+func (tp *TxPool) minFeeRate() float64 {
+	if len(tp.pool) < tp.maxNumTxs || tp.feeRateQueue.Len() == 0 {
+		return 0
+	}
+	return tp.feeRateQueue[0].score()
+}
+
+// poolGasRate return the gas rate of the first pool transaction which couldn't be
+// packed in the given gas in the order of gas rate, 0 means all of them fit
+func (tp *TxPool) poolGasRate(gas uint64) float64 {
+	if gas == 0 {
+		return 0
+	}
+
+	txDs := make([]*TxDesc, 0, len(tp.pool))
+	for _, txD := range tp.pool {
+		txDs = append(txDs, txD)
+	}
+	sort.Slice(txDs, func(i, j int) bool { return txDs[i].GasRate() > txDs[j].GasRate() })
+
+	packed := uint64(0)
+	for _, txD := range txDs {
+		if packed += txD.GasUsed; packed > gas {
+			return txD.GasRate()
+		}
+	}
+	return 0
+}
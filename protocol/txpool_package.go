@@ -0,0 +1,191 @@
+package protocol
+
+import (
+	"container/heap"
+
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// TxPackage is a group of pool transactions that must be packed into block together,
+// the parents are always placed before the children
+type TxPackage struct {
+	Txs    []*TxDesc
+	Fee    uint64
+	Weight uint64
+}
+
+// FeeRate return the fee paid per unit of weight by the whole package
+func (p *TxPackage) FeeRate() float64 {
+	if p.Weight == 0 {
+		return 0
+	}
+	return float64(p.Fee) / float64(p.Weight)
+}
+
+func (p *TxPackage) add(txD *TxDesc) {
+	p.Txs = append(p.Txs, txD)
+	p.Fee += txD.Fee
+	p.Weight += txD.Weight
+}
+
+// GetTxPackages return all the transactions in the pool grouped as packages, a package is
+// formed by a tx and its ancestors which are not in the previous packages. The packages are
+// ordered by the package fee rate from high to low, so a high fee child pays for its parents.
+func (tp *TxPool) GetTxPackages() []*TxPackage {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+
+	selected := map[bc.Hash]bool{}
+	versions := map[bc.Hash]int{}
+	queue := &txPackageQueue{}
+	for _, txD := range tp.pool {
+		heap.Push(queue, &txPackageItem{TxPackage: tp.ancestorPackage(txD, selected), txD: txD})
+	}
+
+	packages := []*TxPackage{}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(*txPackageItem)
+		if selected[item.txD.Tx.ID] || item.version != versions[item.txD.Tx.ID] {
+			continue
+		}
+
+		packages = append(packages, item.TxPackage)
+		for _, txD := range item.Txs {
+			selected[txD.Tx.ID] = true
+		}
+
+		updates := map[bc.Hash]*TxDesc{}
+		for _, txD := range item.Txs {
+			for _, descendant := range tp.descendants(txD) {
+				if !selected[descendant.Tx.ID] {
+					updates[descendant.Tx.ID] = descendant
+				}
+			}
+		}
+
+		for hash, txD := range updates {
+			versions[hash]++
+			heap.Push(queue, &txPackageItem{TxPackage: tp.ancestorPackage(txD, selected), txD: txD, version: versions[hash]})
+		}
+	}
+	return packages
+}
+
+// ancestorPackage return the package formed by the tx and its ancestors excluding the selected ones
+func (tp *TxPool) ancestorPackage(txD *TxDesc, selected map[bc.Hash]bool) *TxPackage {
+	pkg := &TxPackage{}
+	visited := map[bc.Hash]bool{}
+	var visit func(txD *TxDesc)
+	visit = func(txD *TxDesc) {
+		visited[txD.Tx.ID] = true
+		for _, parent := range tp.parents(txD.Tx) {
+			if !selected[parent.ID] && !visited[parent.ID] {
+				visit(tp.pool[parent.ID])
+			}
+		}
+		pkg.add(txD)
+	}
+
+	visit(txD)
+	return pkg
+}
+
+// ancestors return all the pool txs whose outputs are directly or indirectly spent by the tx
+func (tp *TxPool) ancestors(txD *TxDesc) []*TxDesc {
+	ancestors := []*TxDesc{}
+	visited := map[bc.Hash]bool{txD.Tx.ID: true}
+	for queue := []*types.Tx{txD.Tx}; len(queue) > 0; queue = queue[1:] {
+		for _, parent := range tp.parents(queue[0]) {
+			if visited[parent.ID] {
+				continue
+			}
+
+			visited[parent.ID] = true
+			ancestors = append(ancestors, tp.pool[parent.ID])
+			queue = append(queue, parent)
+		}
+	}
+	return ancestors
+}
+
+// descendants return all the pool txs which directly or indirectly spend the outputs of the tx
+func (tp *TxPool) descendants(txD *TxDesc) []*TxDesc {
+	descendants := []*TxDesc{}
+	visited := map[bc.Hash]bool{txD.Tx.ID: true}
+	for queue := []*types.Tx{txD.Tx}; len(queue) > 0; queue = queue[1:] {
+		for _, id := range queue[0].ResultIds {
+			child, ok := tp.spent[*id]
+			if !ok || visited[child.ID] {
+				continue
+			}
+
+			visited[child.ID] = true
+			descendants = append(descendants, tp.pool[child.ID])
+			queue = append(queue, child)
+		}
+	}
+	return descendants
+}
+
+// parents return the pool txs whose outputs are spent by the tx
+func (tp *TxPool) parents(tx *types.Tx) []*types.Tx {
+	parents := []*types.Tx{}
+	for _, spent := range tx.SpentOutputIDs {
+		if parent, ok := tp.utxo[spent]; ok {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// updateDescendantScore recalculate the descendant package of the txs and fix their position in fee rate queue
+func (tp *TxPool) updateDescendantScore(txDs []*TxDesc) {
+	for _, txD := range txDs {
+		item, ok := tp.feeRateItems[txD.Tx.ID]
+		if !ok {
+			continue
+		}
+
+		item.descendantFee, item.descendantGas = txD.Fee, txD.GasUsed
+		for _, descendant := range tp.descendants(txD) {
+			item.descendantFee += descendant.Fee
+			item.descendantGas += descendant.GasUsed
+		}
+		heap.Fix(&tp.feeRateQueue, item.index)
+	}
+}
+
+// txPackageItem is the element of txPackageQueue, version is used to skip the outdated
+// package once the ancestors of the tx are selected
+type txPackageItem struct {
+	*TxPackage
+	txD     *TxDesc
+	version int
+}
+
+// A txPackageQueue implements heap.Interface and holds the package with the highest fee rate on the top.
+type txPackageQueue []*txPackageItem
+
+func (q txPackageQueue) Len() int { return len(q) }
+
+func (q txPackageQueue) Less(i, j int) bool {
+	if q[i].FeeRate() == q[j].FeeRate() {
+		return q[i].txD.Added.Before(q[j].txD.Added)
+	}
+	return q[i].FeeRate() > q[j].FeeRate()
+}
+
+func (q txPackageQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *txPackageQueue) Push(x interface{}) {
+	*q = append(*q, x.(*txPackageItem))
+}
+
+func (q *txPackageQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+	return item
+}
@@ -0,0 +1,58 @@
+package protocol
+
+// txFeeRateItem is the element of txFeeRateQueue, the descendant fields sum up
+// the tx itself and all its in pool descendants
+type txFeeRateItem struct {
+	*TxDesc
+	descendantFee uint64
+	descendantGas uint64
+	index         int
+}
+
+// score return the higher one of the tx gas rate and the gas rate of the package
+// formed by the tx and its descendants, so a low fee parent rescued by a high fee
+// child is evicted by the gas rate of the whole package
+func (t *txFeeRateItem) score() float64 {
+	if t.descendantGas == 0 {
+		return t.GasRate()
+	}
+
+	if rate := float64(t.descendantFee) / float64(t.descendantGas); rate > t.GasRate() {
+		return rate
+	}
+	return t.GasRate()
+}
+
+// A txFeeRateQueue implements heap.Interface and keeps the pool transactions
+// ordered by score, the transaction with the lowest score is on the top.
+type txFeeRateQueue []*txFeeRateItem
+
+func (q txFeeRateQueue) Len() int { return len(q) }
+
+func (q txFeeRateQueue) Less(i, j int) bool {
+	if q[i].score() == q[j].score() {
+		return q[i].Added.After(q[j].Added)
+	}
+	return q[i].score() < q[j].score()
+}
+
+func (q txFeeRateQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *txFeeRateQueue) Push(x interface{}) {
+	item := x.(*txFeeRateItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *txFeeRateQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	item.index = -1
+	*q = old[0 : n-1]
+	return item
+}
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -111,9 +112,14 @@ func (s *mockStore) GetTransactionsUtxo(*state.UtxoViewpoint, []*bc.Tx) error {
 func (s *mockStore) GetUtxo(*bc.Hash) (*storage.UtxoEntry, error)             { return nil, nil }
 func (s *mockStore) GetMainChainHash(uint64) (*bc.Hash, error)                { return nil, nil }
 func (s *mockStore) GetContract(hash [32]byte) ([]byte, error)                { return nil, nil }
+func (s *mockStore) GetTransactionIndex(*bc.Hash) (*bc.Hash, uint64, error)   { return nil, 0, nil }
+func (s *mockStore) GetBlockSpentUtxos(*state.UtxoViewpoint, *bc.Hash) error  { return nil }
+func (s *mockStore) GetEvidence(*bc.Hash) (*state.Evidence, error)            { return nil, nil }
+func (s *mockStore) ListEvidences() ([]*state.Evidence, error)                { return nil, nil }
+func (s *mockStore) SaveEvidence(*state.Evidence) error                       { return nil }
 func (s *mockStore) SaveBlock(*types.Block) error                             { return nil }
 func (s *mockStore) SaveBlockHeader(*types.BlockHeader) error                 { return nil }
-func (s *mockStore) SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *state.UtxoViewpoint, *state.ContractViewpoint, uint64, *bc.Hash) error {
+func (s *mockStore) SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *state.UtxoViewpoint, *state.ContractViewpoint, *state.IndexViewpoint, uint64, *bc.Hash) error {
 	return nil
 }
 
@@ -253,8 +259,11 @@ func TestAddTransaction(t *testing.T) {
 	}{
 		{
 			before: &TxPool{
+				maxNumTxs:       maxNewTxNum,
 				pool:            map[bc.Hash]*TxDesc{},
 				utxo:            map[bc.Hash]*types.Tx{},
+				spent:           map[bc.Hash]*types.Tx{},
+				feeRateItems:    map[bc.Hash]*txFeeRateItem{},
 				eventDispatcher: dispatcher,
 			},
 			after: &TxPool{
@@ -274,8 +283,11 @@ func TestAddTransaction(t *testing.T) {
 		},
 		{
 			before: &TxPool{
+				maxNumTxs:       maxNewTxNum,
 				pool:            map[bc.Hash]*TxDesc{},
 				utxo:            map[bc.Hash]*types.Tx{},
+				spent:           map[bc.Hash]*types.Tx{},
+				feeRateItems:    map[bc.Hash]*txFeeRateItem{},
 				eventDispatcher: dispatcher,
 			},
 			after: &TxPool{
@@ -380,8 +392,11 @@ func TestProcessOrphans(t *testing.T) {
 	}{
 		{
 			before: &TxPool{
+				maxNumTxs:       maxNewTxNum,
 				pool:            map[bc.Hash]*TxDesc{},
 				utxo:            map[bc.Hash]*types.Tx{},
+				spent:           map[bc.Hash]*types.Tx{},
+				feeRateItems:    map[bc.Hash]*txFeeRateItem{},
 				eventDispatcher: dispatcher,
 				orphans: map[bc.Hash]*orphanTx{
 					testTxs[3].ID: {
@@ -418,8 +433,11 @@ func TestProcessOrphans(t *testing.T) {
 		},
 		{
 			before: &TxPool{
+				maxNumTxs:       maxNewTxNum,
 				pool:            map[bc.Hash]*TxDesc{},
 				utxo:            map[bc.Hash]*types.Tx{},
+				spent:           map[bc.Hash]*types.Tx{},
+				feeRateItems:    map[bc.Hash]*txFeeRateItem{},
 				eventDispatcher: dispatcher,
 				orphans: map[bc.Hash]*orphanTx{
 					testTxs[3].ID: {
@@ -612,19 +630,27 @@ func (s *mockStore1) GetTransactionsUtxo(utxoView *state.UtxoViewpoint, tx []*bc
 	}
 	return nil
 }
-func (s *mockStore1) GetUtxo(*bc.Hash) (*storage.UtxoEntry, error) { return nil, nil }
-func (s *mockStore1) GetMainChainHash(uint64) (*bc.Hash, error)    { return nil, nil }
-func (s *mockStore1) GetContract(hash [32]byte) ([]byte, error)    { return nil, nil }
-func (s *mockStore1) SaveBlock(*types.Block) error                 { return nil }
-func (s *mockStore1) SaveBlockHeader(*types.BlockHeader) error     { return nil }
-func (s *mockStore1) SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *state.UtxoViewpoint, *state.ContractViewpoint, uint64, *bc.Hash) error {
+func (s *mockStore1) GetUtxo(*bc.Hash) (*storage.UtxoEntry, error)            { return nil, nil }
+func (s *mockStore1) GetMainChainHash(uint64) (*bc.Hash, error)               { return nil, nil }
+func (s *mockStore1) GetContract(hash [32]byte) ([]byte, error)               { return nil, nil }
+func (s *mockStore1) GetTransactionIndex(*bc.Hash) (*bc.Hash, uint64, error)  { return nil, 0, nil }
+func (s *mockStore1) GetBlockSpentUtxos(*state.UtxoViewpoint, *bc.Hash) error { return nil }
+func (s *mockStore1) GetEvidence(*bc.Hash) (*state.Evidence, error)           { return nil, nil }
+func (s *mockStore1) ListEvidences() ([]*state.Evidence, error)               { return nil, nil }
+func (s *mockStore1) SaveEvidence(*state.Evidence) error                      { return nil }
+func (s *mockStore1) SaveBlock(*types.Block) error                            { return nil }
+func (s *mockStore1) SaveBlockHeader(*types.BlockHeader) error                { return nil }
+func (s *mockStore1) SaveChainStatus(*types.BlockHeader, []*types.BlockHeader, *state.UtxoViewpoint, *state.ContractViewpoint, *state.IndexViewpoint, uint64, *bc.Hash) error {
 	return nil
 }
 
 func TestProcessTransaction(t *testing.T) {
 	txPool := &TxPool{
+		maxNumTxs:       maxNewTxNum,
 		pool:            make(map[bc.Hash]*TxDesc),
 		utxo:            make(map[bc.Hash]*types.Tx),
+		spent:           make(map[bc.Hash]*types.Tx),
+		feeRateItems:    make(map[bc.Hash]*txFeeRateItem),
 		orphans:         make(map[bc.Hash]*orphanTx),
 		orphansByPrev:   make(map[bc.Hash]map[bc.Hash]*orphanTx),
 		store:           &mockStore1{},
@@ -683,7 +709,7 @@ func TestProcessTransaction(t *testing.T) {
 	}
 
 	for i, c := range cases {
-		txPool.ProcessTransaction(c.addTx.Tx, 0, 0)
+		txPool.ProcessTransaction(c.addTx.Tx, 0, 0, 0)
 		for _, txD := range txPool.pool {
 			txD.Added = time.Time{}
 		}
@@ -706,3 +732,441 @@ func TestProcessTransaction(t *testing.T) {
 		}
 	}
 }
+
+func mockSpendTx(sourceID bc.Hash, sourcePos, amount uint64, outputProgram []byte) *types.Tx {
+	return types.NewTx(types.TxData{
+		SerializedSize: 100,
+		Inputs: []*types.TxInput{
+			types.NewSpendInput(nil, sourceID, *consensus.BTMAssetID, amount, sourcePos, []byte{0x51}, nil),
+		},
+		Outputs: []*types.TxOutput{
+			types.NewOriginalTxOutput(*consensus.BTMAssetID, amount, outputProgram, nil),
+		},
+	})
+}
+
+func mockChildTx(parent *types.Tx, outputProgram []byte) *types.Tx {
+	output := parent.Entries[*parent.ResultIds[0]].(*bc.OriginalOutput)
+	return mockSpendTx(*output.Source.Ref, output.Source.Position, output.Source.Value.Amount, outputProgram)
+}
+
+func newTestTxPool(maxNumTxs int) *TxPool {
+	return &TxPool{
+		maxNumTxs:       maxNumTxs,
+		pool:            make(map[bc.Hash]*TxDesc),
+		utxo:            make(map[bc.Hash]*types.Tx),
+		spent:           make(map[bc.Hash]*types.Tx),
+		feeRateItems:    make(map[bc.Hash]*txFeeRateItem),
+		orphans:         make(map[bc.Hash]*orphanTx),
+		orphansByPrev:   make(map[bc.Hash]map[bc.Hash]*orphanTx),
+		eventDispatcher: event.NewDispatcher(),
+	}
+}
+
+func TestReplaceByFee(t *testing.T) {
+	parent := mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51})
+	child := mockChildTx(parent, []byte{0x52})
+	cases := []struct {
+		desc      string
+		before    []*TxDesc
+		addTx     *TxDesc
+		wantErr   error
+		wantTxIDs []bc.Hash
+	}{
+		{
+			desc:      "replace with higher fee",
+			before:    []*TxDesc{{Tx: parent, Weight: 100, Fee: 10}},
+			addTx:     &TxDesc{Tx: mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x53}), Weight: 100, Fee: 20},
+			wantTxIDs: []bc.Hash{mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x53}).ID},
+		},
+		{
+			desc:      "replace with same fee",
+			before:    []*TxDesc{{Tx: parent, Weight: 100, Fee: 10}},
+			addTx:     &TxDesc{Tx: mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x53}), Weight: 100, Fee: 10},
+			wantErr:   ErrReplaceFeeTooLow,
+			wantTxIDs: []bc.Hash{parent.ID},
+		},
+		{
+			desc:      "replace without paying for the descendants",
+			before:    []*TxDesc{{Tx: parent, Weight: 100, Fee: 10}, {Tx: child, Weight: 100, Fee: 10}},
+			addTx:     &TxDesc{Tx: mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x53}), Weight: 100, Fee: 15},
+			wantErr:   ErrReplaceFeeTooLow,
+			wantTxIDs: []bc.Hash{parent.ID, child.ID},
+		},
+		{
+			desc:      "replace with the descendants",
+			before:    []*TxDesc{{Tx: parent, Weight: 100, Fee: 10}, {Tx: child, Weight: 100, Fee: 10}},
+			addTx:     &TxDesc{Tx: mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x53}), Weight: 100, Fee: 21},
+			wantTxIDs: []bc.Hash{mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x53}).ID},
+		},
+	}
+
+	for i, c := range cases {
+		txPool := newTestTxPool(maxNewTxNum)
+		for _, txD := range c.before {
+			if err := txPool.addTransaction(txD); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := txPool.addTransaction(c.addTx); err != c.wantErr {
+			t.Errorf("case %d(%s): got err %v want %v", i, c.desc, err, c.wantErr)
+		}
+
+		if len(txPool.pool) != len(c.wantTxIDs) || len(txPool.feeRateQueue) != len(c.wantTxIDs) {
+			t.Errorf("case %d(%s): got pool size %d want %d", i, c.desc, len(txPool.pool), len(c.wantTxIDs))
+		}
+
+		for _, txID := range c.wantTxIDs {
+			if _, ok := txPool.pool[txID]; !ok {
+				t.Errorf("case %d(%s): tx %s is not in pool", i, c.desc, txID.String())
+			}
+		}
+	}
+}
+
+func TestEvictLowFeeRate(t *testing.T) {
+	txPool := newTestTxPool(2)
+	tx1 := mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51})
+	tx2 := mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51})
+	tx3 := mockSpendTx(bc.NewHash([32]byte{0x03}), 0, 1000, []byte{0x51})
+	tx4 := mockSpendTx(bc.NewHash([32]byte{0x04}), 0, 1000, []byte{0x51})
+	for _, txD := range []*TxDesc{{Tx: tx1, Weight: 100, GasUsed: 100, Fee: 20}, {Tx: tx2, Weight: 100, GasUsed: 100, Fee: 10}} {
+		if err := txPool.addTransaction(txD); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := txPool.addTransaction(&TxDesc{Tx: tx3, Weight: 100, GasUsed: 100, Fee: 10}); err != ErrLowFeeRate {
+		t.Errorf("add low fee rate tx: got err %v want %v", err, ErrLowFeeRate)
+	}
+
+	if err := txPool.addTransaction(&TxDesc{Tx: tx4, Weight: 100, GasUsed: 100, Fee: 30}); err != nil {
+		t.Errorf("add high fee rate tx: got err %v", err)
+	}
+
+	if _, ok := txPool.pool[tx2.ID]; ok || len(txPool.pool) != 2 {
+		t.Errorf("lowest fee rate tx is not evicted")
+	}
+
+	if lowest := txPool.feeRateQueue[0]; lowest.Tx.ID != tx1.ID {
+		t.Errorf("got lowest fee rate tx %s want %s", lowest.Tx.ID.String(), tx1.ID.String())
+	}
+}
+
+func TestGetTxPackages(t *testing.T) {
+	txPool := newTestTxPool(maxNewTxNum)
+	parent := mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51})
+	child := mockChildTx(parent, []byte{0x52})
+	grandchild := mockChildTx(child, []byte{0x53})
+	other := mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51})
+	for _, txD := range []*TxDesc{
+		{Tx: parent, Weight: 100, GasUsed: 100, Fee: 10},
+		{Tx: child, Weight: 100, GasUsed: 100, Fee: 50},
+		{Tx: grandchild, Weight: 100, GasUsed: 100, Fee: 1},
+		{Tx: other, Weight: 100, GasUsed: 100, Fee: 20},
+	} {
+		if err := txPool.addTransaction(txD); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := [][]bc.Hash{{parent.ID, child.ID}, {other.ID}, {grandchild.ID}}
+	got := txPool.GetTxPackages()
+	if len(got) != len(want) {
+		t.Fatalf("got %d packages want %d", len(got), len(want))
+	}
+
+	for i, pkg := range got {
+		if len(pkg.Txs) != len(want[i]) {
+			t.Fatalf("package %d: got %d txs want %d", i, len(pkg.Txs), len(want[i]))
+		}
+
+		for j, txD := range pkg.Txs {
+			if txD.Tx.ID != want[i][j] {
+				t.Errorf("package %d index %d: got tx %s want %s", i, j, txD.Tx.ID.String(), want[i][j].String())
+			}
+		}
+	}
+}
+
+func TestEvictPackage(t *testing.T) {
+	txPool := newTestTxPool(3)
+	parent := mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51})
+	child := mockChildTx(parent, []byte{0x52})
+	other := mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51})
+	another := mockSpendTx(bc.NewHash([32]byte{0x03}), 0, 1000, []byte{0x51})
+	for _, txD := range []*TxDesc{{Tx: parent, Weight: 100, GasUsed: 100, Fee: 1}, {Tx: other, Weight: 100, GasUsed: 100, Fee: 10}, {Tx: child, Weight: 100, GasUsed: 100, Fee: 100}} {
+		if err := txPool.addTransaction(txD); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := txPool.addTransaction(&TxDesc{Tx: another, Weight: 100, GasUsed: 100, Fee: 80}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := txPool.pool[other.ID]; ok {
+		t.Errorf("tx with the lowest package fee rate is not evicted")
+	}
+
+	if err := txPool.addTransaction(&TxDesc{Tx: other, Weight: 100, GasUsed: 100, Fee: 60}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, txID := range []bc.Hash{parent.ID, child.ID} {
+		if _, ok := txPool.pool[txID]; ok {
+			t.Errorf("package tx %s is not evicted together", txID.String())
+		}
+	}
+
+	if len(txPool.pool) != 2 || len(txPool.spent) != 2 || len(txPool.feeRateQueue) != 2 {
+		t.Errorf("got pool size %d want 2", len(txPool.pool))
+	}
+}
+
+func TestDumpAndLoad(t *testing.T) {
+	txPool := newTestTxPool(10)
+	parent := mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51})
+	child := mockChildTx(parent, []byte{0x52})
+	orphan := mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51})
+	now := time.Now()
+	for i, tx := range []*types.Tx{parent, child} {
+		if err := txPool.addTransaction(&TxDesc{Tx: tx, Weight: 100, Fee: 10}); err != nil {
+			t.Fatal(err)
+		}
+
+		txPool.pool[tx.ID].Added = now.Add(time.Duration(i-2) * time.Hour)
+	}
+
+	if err := txPool.addOrphan(&TxDesc{Tx: orphan, Weight: 100, Fee: 10}, []*bc.Hash{&orphan.SpentOutputIDs[0]}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := txPool.Dump(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		expire    time.Time
+		wantTxIDs []bc.Hash
+	}{
+		{
+			expire:    now.Add(-3 * time.Hour),
+			wantTxIDs: []bc.Hash{parent.ID, child.ID, orphan.ID},
+		},
+		{
+			expire:    now.Add(-90 * time.Minute),
+			wantTxIDs: []bc.Hash{child.ID, orphan.ID},
+		},
+		{
+			expire:    now.Add(time.Hour),
+			wantTxIDs: []bc.Hash{},
+		},
+	}
+
+	for i, c := range cases {
+		dumpTxs, err := LoadDump(bytes.NewReader(buf.Bytes()), c.expire)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gotTxIDs := []bc.Hash{}
+		for _, dumpTx := range dumpTxs {
+			gotTxIDs = append(gotTxIDs, dumpTx.Tx.ID)
+		}
+
+		if !testutil.DeepEqual(gotTxIDs, c.wantTxIDs) {
+			t.Errorf("case %d: got %v want %v", i, gotTxIDs, c.wantTxIDs)
+		}
+	}
+}
+
+func TestReloadDump(t *testing.T) {
+	added := time.Now().Add(-2 * time.Hour)
+	txPool := newTestTxPool(10)
+	txPool.store = &mockStore1{}
+	if _, err := txPool.processTransaction(testTxs[2], 0, 0, 0, added); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := txPool.Dump(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	dumpTxs, err := LoadDump(buf, added.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dumpTxs) != 1 || dumpTxs[0].Tx.ID != testTxs[2].ID {
+		t.Fatalf("got %d dumped txs want the added one", len(dumpTxs))
+	}
+
+	reloaded := newTestTxPool(10)
+	reloaded.store = &mockStore1{}
+	if _, err := reloaded.processTransaction(dumpTxs[0].Tx, 0, 0, 0, dumpTxs[0].Added); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := reloaded.pool[testTxs[2].ID].Added; !got.Equal(added) {
+		t.Errorf("got added time %v after reload want %v", got, added)
+	}
+
+	buf.Reset()
+	if err := reloaded.Dump(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if dumpTxs, err = LoadDump(buf, added.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dumpTxs) != 0 {
+		t.Errorf("got %d dumped txs want the expired tx dropped", len(dumpTxs))
+	}
+}
+
+func TestEstimateFeeRate(t *testing.T) {
+	cases := []struct {
+		desc        string
+		blocks      [][]*TxDesc
+		poolTxs     []*TxDesc
+		maxNumTxs   int
+		target      uint64
+		wantFeeRate float64
+		wantErr     error
+	}{
+		{
+			desc:    "target out of range",
+			target:  0,
+			wantErr: ErrBadFeeEstimateTarget,
+		},
+		{
+			desc:        "no data",
+			target:      1,
+			wantFeeRate: 0,
+		},
+		{
+			desc: "low fee rate txs wait longer",
+			blocks: [][]*TxDesc{
+				{{Height: 9, Weight: 100, GasUsed: 100, Fee: 1000}, {Height: 5, Weight: 100, GasUsed: 100, Fee: 100}},
+				{{Height: 10, Weight: 100, GasUsed: 100, Fee: 2000}, {Height: 10, Weight: 100, GasUsed: 100, Fee: 1000}},
+			},
+			target:      1,
+			wantFeeRate: 10,
+		},
+		{
+			desc: "low fee rate txs wait longer with loose target",
+			blocks: [][]*TxDesc{
+				{{Height: 9, Weight: 100, GasUsed: 100, Fee: 1000}, {Height: 5, Weight: 100, GasUsed: 100, Fee: 100}},
+				{{Height: 10, Weight: 100, GasUsed: 100, Fee: 2000}, {Height: 10, Weight: 100, GasUsed: 100, Fee: 1000}},
+			},
+			target:      5,
+			wantFeeRate: 1,
+		},
+		{
+			desc: "fee rate by gas instead of weight",
+			blocks: [][]*TxDesc{
+				{{Height: 9, Weight: 1000, GasUsed: 100, Fee: 1000}, {Height: 5, Weight: 10, GasUsed: 1000, Fee: 1000}},
+			},
+			target:      1,
+			wantFeeRate: 10,
+		},
+		{
+			desc: "pool txs fill the target blocks",
+			blocks: [][]*TxDesc{
+				{{Height: 9, Weight: 100, GasUsed: 100, Fee: 100}},
+			},
+			poolTxs: []*TxDesc{
+				{Tx: mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51}), Weight: 100, GasUsed: 100, Fee: 5000},
+				{Tx: mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51}), Weight: 100, GasUsed: 100, Fee: 3000},
+			},
+			target:      1,
+			wantFeeRate: 30,
+		},
+		{
+			desc: "full pool min fee rate",
+			poolTxs: []*TxDesc{
+				{Tx: mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51}), Weight: 100, GasUsed: 100, Fee: 5000},
+				{Tx: mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51}), Weight: 100, GasUsed: 100, Fee: 3000},
+			},
+			maxNumTxs:   2,
+			target:      1,
+			wantFeeRate: 30,
+		},
+	}
+
+	for i, c := range cases {
+		maxNumTxs := c.maxNumTxs
+		if maxNumTxs == 0 {
+			maxNumTxs = 10
+		}
+
+		txPool := newTestTxPool(maxNumTxs)
+		for j, txDs := range c.blocks {
+			gas := uint64(0)
+			for _, txD := range txDs {
+				gas += txD.GasUsed
+			}
+			txPool.feeEstimator.processBlock(uint64(j+10), gas, txDs)
+		}
+
+		for _, txD := range c.poolTxs {
+			if err := txPool.addTransaction(txD); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		feeRate, err := txPool.EstimateFeeRate(c.target)
+		if err != c.wantErr {
+			t.Fatalf("case %d(%s): got err %v want err %v", i, c.desc, err, c.wantErr)
+		}
+
+		if feeRate != c.wantFeeRate {
+			t.Errorf("case %d(%s): got fee rate %v want fee rate %v", i, c.desc, feeRate, c.wantFeeRate)
+		}
+	}
+}
+
+func TestGetInfo(t *testing.T) {
+	txPool := newTestTxPool(2)
+	tx1 := mockSpendTx(bc.NewHash([32]byte{0x01}), 0, 1000, []byte{0x51})
+	tx2 := mockSpendTx(bc.NewHash([32]byte{0x02}), 0, 1000, []byte{0x51})
+	tx3 := mockSpendTx(bc.NewHash([32]byte{0x03}), 0, 1000, []byte{0x51})
+	for _, txD := range []*TxDesc{{Tx: tx1, Weight: 1000, GasUsed: 100, Fee: 1000}, {Tx: tx2, Weight: 10, GasUsed: 1000, Fee: 1000}} {
+		if err := txPool.addTransaction(txD); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info := txPool.GetInfo()
+	if info.MinFeeRate != 1 {
+		t.Errorf("got min fee rate %v want 1", info.MinFeeRate)
+	}
+
+	for _, bucket := range info.Histogram {
+		if wantCount := map[float64]int{1: 1, 10: 1}[bucket.MinFeeRate]; bucket.TxCount != wantCount {
+			t.Errorf("bucket %v: got tx count %d want %d", bucket.MinFeeRate, bucket.TxCount, wantCount)
+		}
+	}
+
+	feeRate, err := txPool.EstimateFeeRate(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feeRate != info.MinFeeRate {
+		t.Errorf("got estimated fee rate %v want the min fee rate %v", feeRate, info.MinFeeRate)
+	}
+
+	if err := txPool.addTransaction(&TxDesc{Tx: tx3, Weight: 1000, GasUsed: 100, Fee: 500}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := txPool.pool[tx2.ID]; ok {
+		t.Errorf("tx with the lowest fee per unit of gas is not evicted")
+	}
+}
@@ -22,7 +22,10 @@ var (
 	errMismatchedMerkleRoot  = errors.New("mismatched merkle root")
 	errMisorderedBlockHeight = errors.New("misordered block height")
 	errOverBlockLimit        = errors.New("block's gas is over the limit")
+	errBadSlashEvidences     = errors.New("invalid slash evidences of block")
+	errBadParamProposals     = errors.New("invalid param proposals of block")
 	errVersionRegression     = errors.New("version regression")
+	errBadVersionBits        = errors.New("block version signals unknown deployments")
 )
 
 func checkBlockTime(b, parent *types.BlockHeader) error {
@@ -49,7 +52,8 @@ func checkCoinbaseAmount(b *types.Block, checkpoint *state.Checkpoint) error {
 		}
 	}
 
-	if b.Height%consensus.ActiveNetParams.BlocksOfEpoch != 1 {
+	// only the first block of the epoch, which is next to the checkpoint, pays the rewards
+	if b.PreviousBlockHash != checkpoint.Hash {
 		if len(tx.Outputs) != 1 || tx.Outputs[0].Amount != 0 {
 			return errors.Wrap(ErrWrongCoinbaseTransaction, "dismatch output number or amount")
 		}
@@ -83,8 +87,8 @@ func checkoutRewardCoinbase(tx *types.Tx, checkpoint *state.Checkpoint) error {
 
 // ValidateBlockHeader check the block's header
 func ValidateBlockHeader(b, parent *types.BlockHeader, checkpoint *state.Checkpoint) error {
-	if b.Version != 1 {
-		return errors.WithDetailf(errVersionRegression, "previous block verson %d, current block version %d", parent.Version, b.Version)
+	if err := checkBlockVersion(b, parent); err != nil {
+		return err
 	}
 
 	if b.Height != parent.Height+1 {
@@ -99,9 +103,71 @@ func ValidateBlockHeader(b, parent *types.BlockHeader, checkpoint *state.Checkpo
 		return err
 	}
 
+	if err := checkSlashEvidences(b, checkpoint); err != nil {
+		return err
+	}
+
+	if err := checkParamProposals(b, checkpoint); err != nil {
+		return err
+	}
+
 	return verifyBlockSignature(b, checkpoint)
 }
 
+// checkBlockVersion check the base version of the block, and the block only signals
+// the deployments in their signal windows
+func checkBlockVersion(b, parent *types.BlockHeader) error {
+	if consensus.BaseVersion(b.Version) != consensus.BaseBlockVersion {
+		return errors.WithDetailf(errVersionRegression, "previous block verson %d, current block version %d", parent.Version, b.Version)
+	}
+
+	bits := consensus.VersionBits(b.Version)
+	for _, deployment := range consensus.ActiveNetParams.Deployments {
+		if deployment.InSignalWindow(b.Height) {
+			bits &^= 1 << uint(deployment.Bit)
+		}
+	}
+
+	if bits != 0 {
+		return errors.WithDetailf(errBadVersionBits, "block version %d at height %d", b.Version, b.Height)
+	}
+	return nil
+}
+
+// checkSlashEvidences check the number of slash evidences, the evidences themselves
+// are verified by casper since it depends on the checkpoints
+func checkSlashEvidences(b *types.BlockHeader, checkpoint *state.Checkpoint) error {
+	if len(b.SlashEvidences) == 0 {
+		return nil
+	}
+
+	if !checkpoint.IsDeploymentActive(consensus.DeploymentSlashing, b.Height) {
+		return errors.WithDetailf(errBadSlashEvidences, "slashing is not activated at height %d", b.Height)
+	}
+
+	if len(b.SlashEvidences) > consensus.MaxSlashEvidences {
+		return errors.WithDetailf(errBadSlashEvidences, "block has %d slash evidences, exceeds the limit %d", len(b.SlashEvidences), consensus.MaxSlashEvidences)
+	}
+	return nil
+}
+
+// checkParamProposals check the number of param proposals, the signatures of the
+// proposals are verified by casper since it depends on the validators
+func checkParamProposals(b *types.BlockHeader, checkpoint *state.Checkpoint) error {
+	if len(b.ParamProposals) == 0 {
+		return nil
+	}
+
+	if !checkpoint.IsDeploymentActive(consensus.DeploymentGovernance, b.Height) {
+		return errors.WithDetailf(errBadParamProposals, "governance is not activated at height %d", b.Height)
+	}
+
+	if len(b.ParamProposals) > consensus.MaxParamProposals {
+		return errors.WithDetailf(errBadParamProposals, "block has %d param proposals, exceeds the limit %d", len(b.ParamProposals), consensus.MaxParamProposals)
+	}
+	return nil
+}
+
 func verifyBlockSignature(blockHeader *types.BlockHeader, checkpoint *state.Checkpoint) error {
 	validator := checkpoint.GetValidator(blockHeader.Timestamp)
 	xPub := chainkd.XPub{}
@@ -118,8 +184,9 @@ func verifyBlockSignature(blockHeader *types.BlockHeader, checkpoint *state.Chec
 	return nil
 }
 
-// ValidateBlock validates a block and the transactions within.
-func ValidateBlock(b *types.Block, parent *types.BlockHeader, checkpoint *state.Checkpoint, converter ProgramConverterFunc) error {
+// ValidateBlock validates a block and the transactions within, the view holds the utxos
+// spent by the transactions and may be nil if no program reads them.
+func ValidateBlock(b *types.Block, parent *types.BlockHeader, checkpoint *state.Checkpoint, view *state.UtxoViewpoint, converter ProgramConverterFunc) error {
 	startTime := time.Now()
 	if err := ValidateBlockHeader(&b.BlockHeader, parent, checkpoint); err != nil {
 		return err
@@ -127,13 +194,14 @@ func ValidateBlock(b *types.Block, parent *types.BlockHeader, checkpoint *state.
 
 	bcBlock := types.MapBlock(b)
 	blockGasSum := uint64(0)
-	validateResults := ValidateTxs(bcBlock.Transactions, bcBlock, converter)
+	maxBlockGas := checkpoint.Param(consensus.ParamMaxBlockGas, b.Height)
+	validateResults := ValidateTxs(bcBlock.Transactions, bcBlock, view, checkpoint, converter)
 	for i, validateResult := range validateResults {
 		if validateResult.err != nil {
 			return errors.Wrapf(validateResult.err, "validate of transaction %d of %d", i, len(b.Transactions))
 		}
 
-		if blockGasSum += uint64(validateResult.gasStatus.GasUsed); blockGasSum > consensus.MaxBlockGas {
+		if blockGasSum += uint64(validateResult.gasStatus.GasUsed); blockGasSum > maxBlockGas {
 			return errOverBlockLimit
 		}
 	}
@@ -62,6 +62,64 @@ func TestCheckBlockTime(t *testing.T) {
 	}
 }
 
+func TestCheckBlockVersion(t *testing.T) {
+	deployment := consensus.ActiveNetParams.Deployment(consensus.DeploymentGovernance)
+	startHeight, timeoutHeight := deployment.StartHeight, deployment.TimeoutHeight
+	deployment.StartHeight, deployment.TimeoutHeight = 100, 200
+	defer func() { deployment.StartHeight, deployment.TimeoutHeight = startHeight, timeoutHeight }()
+
+	signalVersion := consensus.BaseBlockVersion | 1<<(8+uint(deployment.Bit))
+	cases := []struct {
+		desc    string
+		version uint64
+		height  uint64
+		err     error
+	}{
+		{
+			desc:    "base version",
+			version: 1,
+			height:  150,
+		},
+		{
+			desc:    "signal the deployment in the signal window",
+			version: signalVersion,
+			height:  150,
+		},
+		{
+			desc:    "signal the deployment before the signal window",
+			version: signalVersion,
+			height:  99,
+			err:     errBadVersionBits,
+		},
+		{
+			desc:    "signal the deployment after the signal window",
+			version: signalVersion,
+			height:  200,
+			err:     errBadVersionBits,
+		},
+		{
+			desc:    "signal the unknown bit",
+			version: consensus.BaseBlockVersion | 1<<(8+uint(consensus.MaxVersionBits-1)),
+			height:  150,
+			err:     errBadVersionBits,
+		},
+		{
+			desc:    "wrong base version",
+			version: 2,
+			height:  150,
+			err:     errVersionRegression,
+		},
+	}
+
+	parent := &types.BlockHeader{Version: 1}
+	for _, c := range cases {
+		block := &types.BlockHeader{Version: c.version, Height: c.height}
+		if err := checkBlockVersion(block, parent); rootErr(err) != c.err {
+			t.Errorf("case %s: got error %v, want %v", c.desc, err, c.err)
+		}
+	}
+}
+
 func TestCheckCoinbaseAmount(t *testing.T) {
 	cases := []struct {
 		block      *types.Block
@@ -70,7 +128,7 @@ func TestCheckCoinbaseAmount(t *testing.T) {
 	}{
 		{
 			block: &types.Block{
-				BlockHeader: types.BlockHeader{Height: 0},
+				BlockHeader: types.BlockHeader{Height: 2, PreviousBlockHash: bc.Hash{V0: 1}},
 				Transactions: []*types.Tx{
 					types.NewTx(types.TxData{
 						Inputs: []*types.TxInput{types.NewCoinbaseInput(nil)},
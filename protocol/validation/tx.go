@@ -10,6 +10,7 @@ import (
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/math/checked"
 	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
 	"github.com/bytom/bytom/protocol/vm"
 )
 
@@ -107,14 +108,40 @@ type ProgramConverterFunc func(prog []byte) ([]byte, error)
 // validationState contains the context that must propagate through
 // the transaction graph when validating entries.
 type validationState struct {
-	block     *bc.Block
-	tx        *bc.Tx
-	gasStatus *GasState
-	entryID   bc.Hash              // The ID of the nearest enclosing entry
-	sourcePos uint64               // The source position, for validate ValueSources
-	destPos   uint64               // The destination position, for validate ValueDestinations
-	cache     map[bc.Hash]error    // Memoized per-entry validation results
-	converter ProgramConverterFunc // Program converter function
+	block      *bc.Block
+	tx         *bc.Tx
+	gasStatus  *GasState
+	entryID    bc.Hash                    // The ID of the nearest enclosing entry
+	sourcePos  uint64                     // The source position, for validate ValueSources
+	destPos    uint64                     // The destination position, for validate ValueDestinations
+	cache      map[bc.Hash]error          // Memoized per-entry validation results
+	converter  ProgramConverterFunc       // Program converter function
+	tracer     Tracer                     // Receives the vm steps of the entry programs, may be nil
+	skipSig    bool                       // Treat the signatures checked by the programs as valid
+	results    map[bc.Hash]*ProgramResult // Records the program results of the entries when simulating
+	view       *state.UtxoViewpoint       // The utxos spent by the transaction, may be nil
+	checkpoint *state.Checkpoint          // Records the deployments activated by the signals, may be nil
+}
+
+// ProgramResult is the result of running the program of the entry in the simulation
+type ProgramResult struct {
+	GasUsed int64
+	Err     error
+}
+
+// verifyProgram run the program of the entry and charge the gas it used, the failure of
+// the program is recorded instead of returned when simulating
+func verifyProgram(vs *validationState, entry bc.Entry, prog *bc.Program, stateData [][]byte, args [][]byte) error {
+	gasLeft, err := vm.Verify(NewTxVMContext(vs, entry, prog, stateData, args), vs.gasStatus.GasLeft)
+	if vs.results != nil {
+		vs.results[bc.EntryID(entry)] = &ProgramResult{GasUsed: vs.gasStatus.GasLeft - gasLeft, Err: err}
+		err = nil
+	}
+
+	if err != nil {
+		return err
+	}
+	return vs.gasStatus.updateUsage(gasLeft)
 }
 
 func checkValid(vs *validationState, e bc.Entry) (err error) {
@@ -238,13 +265,9 @@ func checkValid(vs *validationState, e bc.Entry) (err error) {
 			return errors.WithDetailf(ErrMismatchedAssetID, "asset ID is %x, issuance wants %x", computedAssetID.Bytes(), e.Value.AssetId.Bytes())
 		}
 
-		gasLeft, err := vm.Verify(NewTxVMContext(vs, e, e.WitnessAssetDefinition.IssuanceProgram, [][]byte{}, e.WitnessArguments), vs.gasStatus.GasLeft)
-		if err != nil {
+		if err := verifyProgram(vs, e, e.WitnessAssetDefinition.IssuanceProgram, [][]byte{}, e.WitnessArguments); err != nil {
 			return errors.Wrap(err, "checking issuance program")
 		}
-		if err = vs.gasStatus.updateUsage(gasLeft); err != nil {
-			return err
-		}
 
 		destVS := *vs
 		destVS.destPos = 0
@@ -261,13 +284,9 @@ func checkValid(vs *validationState, e bc.Entry) (err error) {
 			return errors.Wrap(err, "getting spend prevout")
 		}
 
-		gasLeft, err := vm.Verify(NewTxVMContext(vs, e, spentOutput.ControlProgram, spentOutput.StateData, e.WitnessArguments), vs.gasStatus.GasLeft)
-		if err != nil {
+		if err := verifyProgram(vs, e, spentOutput.ControlProgram, spentOutput.StateData, e.WitnessArguments); err != nil {
 			return errors.Wrap(err, "checking control program")
 		}
-		if err = vs.gasStatus.updateUsage(gasLeft); err != nil {
-			return err
-		}
 
 		eq, err := spentOutput.Source.Value.Equal(e.WitnessDestination.Value)
 		if err != nil {
@@ -303,13 +322,9 @@ func checkValid(vs *validationState, e bc.Entry) (err error) {
 			return ErrVotePubKey
 		}
 
-		gasLeft, err := vm.Verify(NewTxVMContext(vs, e, voteOutput.ControlProgram, voteOutput.StateData, e.WitnessArguments), vs.gasStatus.GasLeft)
-		if err != nil {
+		if err := verifyProgram(vs, e, voteOutput.ControlProgram, voteOutput.StateData, e.WitnessArguments); err != nil {
 			return errors.Wrap(err, "checking control program")
 		}
-		if err = vs.gasStatus.updateUsage(gasLeft); err != nil {
-			return err
-		}
 
 		eq, err := voteOutput.Source.Value.Equal(e.WitnessDestination.Value)
 		if err != nil {
@@ -524,9 +539,30 @@ func checkTimeRange(tx *bc.Tx, block *bc.Block) error {
 	return nil
 }
 
+// Tracer receives the vm steps running the program of the entry
+type Tracer func(entryID bc.Hash, step *vm.TraceStep)
+
 // ValidateTx validates a transaction.
 func ValidateTx(tx *bc.Tx, block *bc.Block, converter ProgramConverterFunc) (*GasState, error) {
-	if block.Version == 1 && tx.Version != 1 {
+	return ValidateTxWithView(tx, block, nil, nil, converter)
+}
+
+// ValidateTxWithView validates a transaction, the confirmation heights of the spent outputs
+// are read from the view by the programs, and the opcodes activated by the signals are
+// read from the checkpoint. Only the activation heights are checked if the checkpoint is nil
+func ValidateTxWithView(tx *bc.Tx, block *bc.Block, view *state.UtxoViewpoint, checkpoint *state.Checkpoint, converter ProgramConverterFunc) (*GasState, error) {
+	return validateTx(tx, block, &validationState{converter: converter, view: view, checkpoint: checkpoint})
+}
+
+// ValidateTxWithTracer validates a transaction, the vm steps of the input programs are
+// reported to the tracer
+func ValidateTxWithTracer(tx *bc.Tx, block *bc.Block, view *state.UtxoViewpoint, checkpoint *state.Checkpoint, converter ProgramConverterFunc, tracer Tracer) (*GasState, error) {
+	return validateTx(tx, block, &validationState{converter: converter, tracer: tracer, view: view, checkpoint: checkpoint})
+}
+
+// validateTx validates the transaction with the options set in the validation state
+func validateTx(tx *bc.Tx, block *bc.Block, vs *validationState) (*GasState, error) {
+	if consensus.BaseVersion(block.Version) == consensus.BaseBlockVersion && tx.Version != 1 {
 		return nil, errors.WithDetailf(ErrTxVersion, "block version %d, transaction version %d", block.Version, tx.Version)
 	}
 
@@ -542,14 +578,11 @@ func ValidateTx(tx *bc.Tx, block *bc.Block, converter ProgramConverterFunc) (*Ga
 		return nil, err
 	}
 
-	vs := &validationState{
-		block:     block,
-		tx:        tx,
-		entryID:   tx.ID,
-		gasStatus: &GasState{},
-		cache:     make(map[bc.Hash]error),
-		converter: converter,
-	}
+	vs.block = block
+	vs.tx = tx
+	vs.entryID = tx.ID
+	vs.gasStatus = &GasState{}
+	vs.cache = make(map[bc.Hash]error)
 
 	if err := checkValid(vs, tx.TxHeader); err != nil {
 		return nil, err
@@ -558,6 +591,16 @@ func ValidateTx(tx *bc.Tx, block *bc.Block, converter ProgramConverterFunc) (*Ga
 	return vs.gasStatus, nil
 }
 
+// SimulateTx validates the transaction like ValidateTx except the failure of each program is
+// recorded to the result of the entry instead of stopping the validation, and the gas state is
+// returned even if the validation fails. The signature checks of the programs always pass if
+// skipSig is true
+func SimulateTx(tx *bc.Tx, block *bc.Block, view *state.UtxoViewpoint, checkpoint *state.Checkpoint, converter ProgramConverterFunc, skipSig bool) (*GasState, map[bc.Hash]*ProgramResult, error) {
+	vs := &validationState{converter: converter, skipSig: skipSig, results: make(map[bc.Hash]*ProgramResult), view: view, checkpoint: checkpoint}
+	_, err := validateTx(tx, block, vs)
+	return vs.gasStatus, vs.results, err
+}
+
 type validateTxWork struct {
 	i     int
 	tx    *bc.Tx
@@ -581,16 +624,16 @@ func (r *ValidateTxResult) GetError() error {
 	return r.err
 }
 
-func validateTxWorker(workCh chan *validateTxWork, resultCh chan *ValidateTxResult, wg *sync.WaitGroup, converter ProgramConverterFunc) {
+func validateTxWorker(workCh chan *validateTxWork, resultCh chan *ValidateTxResult, wg *sync.WaitGroup, view *state.UtxoViewpoint, checkpoint *state.Checkpoint, converter ProgramConverterFunc) {
 	for work := range workCh {
-		gasStatus, err := ValidateTx(work.tx, work.block, converter)
+		gasStatus, err := ValidateTxWithView(work.tx, work.block, view, checkpoint, converter)
 		resultCh <- &ValidateTxResult{i: work.i, gasStatus: gasStatus, err: err}
 	}
 	wg.Done()
 }
 
-// ValidateTxs validates txs in async mode
-func ValidateTxs(txs []*bc.Tx, block *bc.Block, converter ProgramConverterFunc) []*ValidateTxResult {
+// ValidateTxs validates txs in async mode, the view and the checkpoint are only read by the workers
+func ValidateTxs(txs []*bc.Tx, block *bc.Block, view *state.UtxoViewpoint, checkpoint *state.Checkpoint, converter ProgramConverterFunc) []*ValidateTxResult {
 	txSize := len(txs)
 	validateWorkerNum := runtime.NumCPU()
 	//init the goroutine validate worker
@@ -599,7 +642,7 @@ func ValidateTxs(txs []*bc.Tx, block *bc.Block, converter ProgramConverterFunc)
 	resultCh := make(chan *ValidateTxResult, txSize)
 	for i := 0; i <= validateWorkerNum && i < txSize; i++ {
 		wg.Add(1)
-		go validateTxWorker(workCh, resultCh, &wg, converter)
+		go validateTxWorker(workCh, resultCh, &wg, view, checkpoint, converter)
 	}
 
 	//sent the works
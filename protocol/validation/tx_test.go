@@ -8,9 +8,11 @@ import (
 
 	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/crypto/sha3pool"
+	"github.com/bytom/bytom/database/storage"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
 	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/state"
 	"github.com/bytom/bytom/protocol/vm"
 	"github.com/bytom/bytom/protocol/vm/vmutil"
 	"github.com/bytom/bytom/testutil"
@@ -986,6 +988,181 @@ func TestTimeRange(t *testing.T) {
 	}
 }
 
+func TestValidateTxWithTracer(t *testing.T) {
+	converter := func(prog []byte) ([]byte, error) { return nil, nil }
+	cases := []struct {
+		desc    string
+		program []byte
+		err     error
+	}{
+		{desc: "program of input passes", program: []byte{byte(vm.OP_1), byte(vm.OP_1), byte(vm.OP_EQUAL)}},
+		{desc: "program of input fails", program: []byte{byte(vm.OP_1), byte(vm.OP_0), byte(vm.OP_EQUAL)}, err: vm.ErrFalseVMResult},
+	}
+
+	for i, c := range cases {
+		tx := types.MapTx(&types.TxData{
+			SerializedSize: 1,
+			Inputs: []*types.TxInput{
+				mockGasTxInput(),
+				types.NewSpendInput(nil, *newHash(9), *consensus.BTMAssetID, 1, 0, c.program, nil),
+			},
+			Outputs: []*types.TxOutput{
+				types.NewOriginalTxOutput(*consensus.BTMAssetID, 1, []byte{0x6a}, nil),
+			},
+		})
+
+		steps := map[bc.Hash][]vm.Op{}
+		_, err := ValidateTxWithTracer(tx, mockBlock(), nil, nil, converter, func(entryID bc.Hash, step *vm.TraceStep) {
+			steps[entryID] = append(steps[entryID], step.Op)
+		})
+		if rootErr(err) != c.err {
+			t.Fatalf("case #%d(%s) got error %v, want %v", i, c.desc, err, c.err)
+		}
+
+		want := []vm.Op{vm.OP_1, vm.OP_0, vm.OP_EQUAL}
+		if c.err == nil {
+			want[1] = vm.OP_1
+		}
+		if !testutil.DeepEqual(steps[tx.InputIDs[1]], want) {
+			t.Errorf("case #%d(%s) got steps %v, want %v", i, c.desc, steps[tx.InputIDs[1]], want)
+		}
+	}
+}
+
+func TestValidateTxWithView(t *testing.T) {
+	deployment := consensus.ActiveNetParams.Deployment(consensus.DeploymentRelativeTimelock)
+	activationHeight := deployment.ActivationHeight
+	defer func() { deployment.ActivationHeight = activationHeight }()
+
+	converter := func(prog []byte) ([]byte, error) { return nil, nil }
+	program, err := vm.Assemble("BLOCKHEIGHT INPUTHEIGHT SUB 100 GREATERTHANOREQUAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := types.MapTx(&types.TxData{
+		Version:        1,
+		SerializedSize: 1,
+		Inputs: []*types.TxInput{
+			mockGasTxInput(),
+			types.NewSpendInput(nil, *newHash(9), *consensus.BTMAssetID, 1, 0, program, nil),
+		},
+		Outputs: []*types.TxOutput{
+			types.NewOriginalTxOutput(*consensus.BTMAssetID, 1, []byte{0x6a}, nil),
+		},
+	})
+
+	cases := []struct {
+		desc             string
+		activationHeight uint64
+		checkpoint       *state.Checkpoint
+		utxoHeight       *uint64
+		err              error
+	}{
+		{
+			desc:             "the spent output is old enough",
+			activationHeight: 0,
+			utxoHeight:       uint64Ptr(566),
+		},
+		{
+			desc:             "the spent output is not old enough",
+			activationHeight: 0,
+			utxoHeight:       uint64Ptr(567),
+			err:              vm.ErrFalseVMResult,
+		},
+		{
+			desc:             "the spent output is not in the view",
+			activationHeight: 0,
+			err:              vm.ErrContext,
+		},
+		{
+			desc:             "the opcode is not activated",
+			activationHeight: 667,
+			utxoHeight:       uint64Ptr(566),
+			err:              vm.ErrDisallowedOpcode,
+		},
+		{
+			desc:             "the opcode is activated by the signals",
+			activationHeight: math.MaxUint64,
+			checkpoint:       &state.Checkpoint{Activations: map[string]uint64{consensus.DeploymentRelativeTimelock: 600}},
+			utxoHeight:       uint64Ptr(566),
+		},
+		{
+			desc:             "the opcode is locked in by the signals but not activated",
+			activationHeight: math.MaxUint64,
+			checkpoint:       &state.Checkpoint{Activations: map[string]uint64{consensus.DeploymentRelativeTimelock: 667}},
+			utxoHeight:       uint64Ptr(566),
+			err:              vm.ErrDisallowedOpcode,
+		},
+	}
+
+	for i, c := range cases {
+		deployment.ActivationHeight = c.activationHeight
+		view := state.NewUtxoViewpoint()
+		if c.utxoHeight != nil {
+			view.Entries[*tx.Entries[tx.InputIDs[1]].(*bc.Spend).SpentOutputId] = storage.NewUtxoEntry(storage.NormalUTXOType, *c.utxoHeight, false)
+		}
+
+		if _, err := ValidateTxWithView(tx, mockBlock(), view, c.checkpoint, converter); rootErr(err) != c.err {
+			t.Errorf("case #%d(%s) got error %v, want %v", i, c.desc, err, c.err)
+		}
+	}
+}
+
+func uint64Ptr(n uint64) *uint64 {
+	return &n
+}
+
+func TestSimulateTx(t *testing.T) {
+	converter := func(prog []byte) ([]byte, error) { return nil, nil }
+	sigProgram, err := vmutil.NewBuilder().AddData(make([]byte, 64)).AddData(make([]byte, 32)).AddData(make([]byte, 32)).AddOp(vm.OP_CHECKSIG).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := types.MapTx(&types.TxData{
+		SerializedSize: 1,
+		Inputs: []*types.TxInput{
+			types.NewSpendInput(nil, *newHash(9), *consensus.BTMAssetID, 1, 0, sigProgram, nil),
+			mockGasTxInput(),
+		},
+		Outputs: []*types.TxOutput{
+			types.NewOriginalTxOutput(*consensus.BTMAssetID, 1, []byte{0x6a}, nil),
+		},
+	})
+
+	cases := []struct {
+		skipSig bool
+		err     error
+	}{
+		{skipSig: false, err: vm.ErrFalseVMResult},
+		{skipSig: true},
+	}
+
+	for i, c := range cases {
+		gasStatus, results, err := SimulateTx(tx, mockBlock(), nil, nil, converter, c.skipSig)
+		if err != nil {
+			t.Fatalf("case #%d got error %v", i, err)
+		}
+
+		if len(results) != 2 {
+			t.Fatalf("case #%d got %d program results, want 2", i, len(results))
+		}
+
+		if result := results[tx.InputIDs[0]]; rootErr(result.Err) != c.err || result.GasUsed <= 0 {
+			t.Errorf("case #%d got result %+v of the signature program, want error %v", i, result, c.err)
+		}
+
+		if results[tx.InputIDs[1]].Err != nil {
+			t.Errorf("case #%d got error %v of the gas input", i, results[tx.InputIDs[1]].Err)
+		}
+
+		if gasUsed := results[tx.InputIDs[0]].GasUsed + results[tx.InputIDs[1]].GasUsed + gasStatus.StorageGas; gasUsed != gasStatus.GasUsed {
+			t.Errorf("case #%d got gas used %d, want %d", i, gasStatus.GasUsed, gasUsed)
+		}
+	}
+}
+
 func TestValidateTxVersion(t *testing.T) {
 	converter := func(prog []byte) ([]byte, error) { return nil, nil }
 	cases := []struct {
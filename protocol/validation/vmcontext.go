@@ -3,11 +3,13 @@ package validation
 import (
 	"bytes"
 
+	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/consensus/bcrp"
 	"github.com/bytom/bytom/consensus/segwit"
 	"github.com/bytom/bytom/crypto/sha3pool"
 	"github.com/bytom/bytom/errors"
 	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
 	"github.com/bytom/bytom/protocol/vm"
 )
 
@@ -19,10 +21,11 @@ func NewTxVMContext(vs *validationState, entry bc.Entry, prog *bc.Program, state
 		numResults  = uint64(len(tx.ResultIds))
 		entryID     = bc.EntryID(entry) // TODO(bobg): pass this in, don't recompute it
 
-		assetID       *[]byte
-		amount        *uint64
-		destPos       *uint64
-		spentOutputID *[]byte
+		assetID           *[]byte
+		amount            *uint64
+		destPos           *uint64
+		spentOutputID     *[]byte
+		spentOutputHeight *uint64
 	)
 
 	switch e := entry.(type) {
@@ -40,6 +43,10 @@ func NewTxVMContext(vs *validationState, entry bc.Entry, prog *bc.Program, state
 		destPos = &e.WitnessDestination.Position
 		s := e.SpentOutputId.Bytes()
 		spentOutputID = &s
+		spentOutputHeight = utxoHeight(vs.view, e.SpentOutputId)
+
+	case *bc.VetoInput:
+		spentOutputHeight = utxoHeight(vs.view, e.SpentOutputId)
 	}
 
 	var txSigHash *[]byte
@@ -82,11 +89,63 @@ func NewTxVMContext(vs *validationState, entry bc.Entry, prog *bc.Program, state
 		DestPos:       destPos,
 		SpentOutputID: spentOutputID,
 		CheckOutput:   ec.checkOutput,
+		NumOutputs:    ec.numOutputs,
+		GetOutput:     ec.getOutput,
+		SkipSigCheck:  vs.skipSig,
+
+		SpentOutputHeight: spentOutputHeight,
+		ActivatedOps:      activatedOps(vs.checkpoint, blockHeight),
 	}
 
+	if vs.tracer != nil {
+		result.Tracer = func(step *vm.TraceStep) {
+			vs.tracer(entryID, step)
+		}
+	}
 	return result
 }
 
+// utxoHeight return the confirmation height of the utxo, it's nil if the view doesn't hold it
+func utxoHeight(view *state.UtxoViewpoint, outputID *bc.Hash) *uint64 {
+	if view == nil {
+		return nil
+	}
+
+	entry, ok := view.Entries[*outputID]
+	if !ok {
+		return nil
+	}
+
+	height := entry.BlockHeight
+	return &height
+}
+
+// isDeploymentActive check the deployment by the checkpoint which records the activations
+// by the signals, only the activation height is checked without the checkpoint
+func isDeploymentActive(checkpoint *state.Checkpoint, name string, height uint64) bool {
+	if checkpoint == nil {
+		return consensus.ActiveNetParams.IsActive(name, height)
+	}
+	return checkpoint.IsDeploymentActive(name, height)
+}
+
+// activatedOps return the opcodes of the vm expansion space activated at the height
+func activatedOps(checkpoint *state.Checkpoint, height uint64) map[vm.Op]bool {
+	ops := make(map[vm.Op]bool)
+	if isDeploymentActive(checkpoint, consensus.DeploymentRelativeTimelock, height) {
+		ops[vm.OP_INPUTHEIGHT] = true
+	}
+	if isDeploymentActive(checkpoint, consensus.DeploymentSigFromStack, height) {
+		ops[vm.OP_CHECKSIGFROMSTACK] = true
+	}
+	if isDeploymentActive(checkpoint, consensus.DeploymentIntrospection, height) {
+		for _, op := range []vm.Op{vm.OP_OUTPUTCOUNT, vm.OP_OUTPUTASSET, vm.OP_OUTPUTAMOUNT, vm.OP_OUTPUTPROGRAM, vm.OP_STATEDATACOUNT} {
+			ops[op] = true
+		}
+	}
+	return ops
+}
+
 func convertProgram(prog []byte, converter ProgramConverterFunc) []byte {
 	if segwit.IsP2WPKHScript(prog) {
 		if witnessProg, err := segwit.ConvertP2PKHSigProgram([]byte(prog)); err == nil {
@@ -202,6 +261,83 @@ func (ec *entryContext) checkOutput(index uint64, amount uint64, assetID []byte,
 	return false, vm.ErrContext
 }
 
+// destinations return the ids of the outputs of the entry, they are indexed the same as checkOutput
+func (ec *entryContext) destinations() ([]*bc.Hash, error) {
+	muxDestinations := func(m *bc.Mux) []*bc.Hash {
+		var ids []*bc.Hash
+		for _, d := range m.WitnessDestinations {
+			ids = append(ids, d.Ref)
+		}
+		return ids
+	}
+
+	var dest *bc.ValueDestination
+	switch e := ec.entry.(type) {
+	case *bc.Mux:
+		return muxDestinations(e), nil
+
+	case *bc.Issuance:
+		dest = e.WitnessDestination
+
+	case *bc.Spend:
+		dest = e.WitnessDestination
+
+	case *bc.VetoInput:
+		dest = e.WitnessDestination
+
+	default:
+		return nil, vm.ErrContext
+	}
+
+	d, ok := ec.entries[*dest.Ref]
+	if !ok {
+		return nil, errors.Wrapf(bc.ErrMissingEntry, "entry for destination %x not found", dest.Ref.Bytes())
+	}
+
+	if m, ok := d.(*bc.Mux); ok {
+		return muxDestinations(m), nil
+	}
+	return []*bc.Hash{dest.Ref}, nil
+}
+
+func (ec *entryContext) numOutputs() (uint64, error) {
+	ids, err := ec.destinations()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(ids)), nil
+}
+
+func (ec *entryContext) getOutput(index uint64) ([]byte, uint64, []byte, error) {
+	ids, err := ec.destinations()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	if index >= uint64(len(ids)) {
+		return nil, 0, nil, errors.Wrapf(vm.ErrBadValue, "index %d >= %d", index, len(ids))
+	}
+
+	e, ok := ec.entries[*ids[index]]
+	if !ok {
+		return nil, 0, nil, errors.Wrapf(bc.ErrMissingEntry, "entry for output %d, id %x, not found", index, ids[index].Bytes())
+	}
+
+	switch e := e.(type) {
+	case *bc.OriginalOutput:
+		return e.Source.Value.AssetId.Bytes(), e.Source.Value.Amount, e.ControlProgram.Code, nil
+
+	case *bc.VoteOutput:
+		return e.Source.Value.AssetId.Bytes(), e.Source.Value.Amount, e.ControlProgram.Code, nil
+
+	case *bc.Retirement:
+		return e.Source.Value.AssetId.Bytes(), e.Source.Value.Amount, []byte{}, nil
+	}
+
+	return nil, 0, nil, vm.ErrContext
+}
+
 func bytesEqual(a, b [][]byte) bool {
 	if (a == nil) != (b == nil) {
 		return false
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -101,3 +102,65 @@ func TestCheckOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOutput(t *testing.T) {
+	tx := types.NewTx(types.TxData{
+		Inputs: []*types.TxInput{
+			types.NewSpendInput(nil, bc.Hash{}, bc.NewAssetID([32]byte{1}), 5, 1, []byte("spendprog"), nil),
+		},
+		Outputs: []*types.TxOutput{
+			types.NewOriginalTxOutput(bc.NewAssetID([32]byte{1}), 3, []byte("controlprog"), nil),
+			types.NewOriginalTxOutput(bc.NewAssetID([32]byte{1}), 2, []byte{byte(vm.OP_FAIL)}, nil),
+		},
+	})
+
+	txCtx := &entryContext{
+		entry:   tx.Tx.Entries[tx.Tx.InputIDs[0]],
+		entries: tx.Tx.Entries,
+	}
+
+	numOutputs, err := txCtx.numOutputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numOutputs != 2 {
+		t.Errorf("numOutputs() = %d, want 2", numOutputs)
+	}
+
+	cases := []struct {
+		index       uint64
+		wantAssetID []byte
+		wantAmount  uint64
+		wantCode    []byte
+		wantErr     error
+	}{
+		{
+			index:       0,
+			wantAssetID: append([]byte{1}, make([]byte, 31)...),
+			wantAmount:  3,
+			wantCode:    []byte("controlprog"),
+		},
+		{
+			index:       1,
+			wantAssetID: append([]byte{1}, make([]byte, 31)...),
+			wantAmount:  2,
+			wantCode:    []byte{},
+		},
+		{
+			index:   2,
+			wantErr: vm.ErrBadValue,
+		},
+	}
+
+	for i, test := range cases {
+		assetID, amount, code, err := txCtx.getOutput(test.index)
+		if g := errors.Root(err); g != test.wantErr {
+			t.Errorf("case %d: getOutput(%d) err = %v, want %v", i, test.index, g, test.wantErr)
+			continue
+		}
+
+		if !bytes.Equal(assetID, test.wantAssetID) || amount != test.wantAmount || !bytes.Equal(code, test.wantCode) {
+			t.Errorf("case %d: getOutput(%d) = (%x, %d, %x), want (%x, %d, %x)", i, test.index, assetID, amount, code, test.wantAssetID, test.wantAmount, test.wantCode)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package protocol
+
+import (
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/state"
+)
+
+// EpochValidatorStats is the performance of the validators in one epoch of the main chain
+type EpochValidatorStats struct {
+	CheckpointHeight uint64
+	CheckpointHash   bc.Hash
+	Status           state.CheckpointStatus
+	Validators       map[string]*state.Validator
+	Stats            map[string]*state.ValidatorStats
+	// Signers is the validators signed the verification to the checkpoint
+	Signers map[string]bool
+}
+
+func newEpochValidatorStats(checkpoint *state.Checkpoint, validators map[string]*state.Validator) *EpochValidatorStats {
+	epochStats := &EpochValidatorStats{
+		CheckpointHeight: checkpoint.Height,
+		CheckpointHash:   checkpoint.Hash,
+		Status:           checkpoint.Status,
+		Validators:       validators,
+		Stats:            checkpoint.Stats,
+		Signers:          make(map[string]bool),
+	}
+
+	for pubKey, stats := range checkpoint.Stats {
+		if stats.SignedVerification {
+			epochStats.Signers[pubKey] = true
+		}
+	}
+	return epochStats
+}
+
+// GetValidatorStats return the performance of the validators in the recent epochs of the
+// main chain, from the current epoch back to the earlier ones
+func (c *Chain) GetValidatorStats(epochs int) ([]*EpochValidatorStats, error) {
+	bestHash := c.BestBlockHeader().Hash()
+	checkpoint, err := c.casper.Checkpoint(&bestHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*EpochValidatorStats
+	for len(result) < epochs && checkpoint.Height != 0 {
+		parent := checkpoint.Parent
+		if parent == nil {
+			if parent, err = c.store.GetCheckpoint(&checkpoint.ParentHash); err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, newEpochValidatorStats(checkpoint, parent.EffectiveValidators()))
+		checkpoint = parent
+	}
+	return result, nil
+}
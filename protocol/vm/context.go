@@ -31,6 +31,28 @@ type Context struct {
 	DestPos       *uint64
 	SpentOutputID *[]byte
 
+	// SpentOutputHeight is the height of the block confirming the output
+	// spent by the input.
+	SpentOutputHeight *uint64
+
+	// ActivatedOps are the opcodes of the expansion space activated at the
+	// block height, the other opcodes of the expansion space keep the
+	// expansion behavior.
+	ActivatedOps map[Op]bool
+
 	TxSigHash   func() []byte
 	CheckOutput func(index uint64, amount uint64, assetID []byte, vmVersion uint64, code []byte, state [][]byte, expansion bool) (bool, error)
+
+	// NumOutputs and GetOutput read the outputs of the entry, the outputs
+	// are indexed the same as CHECKOUTPUT.
+	NumOutputs func() (uint64, error)
+	GetOutput  func(index uint64) (assetID []byte, amount uint64, code []byte, err error)
+
+	// SkipSigCheck makes the signature checks always pass, it's only used to
+	// simulate the programs before the transaction is signed.
+	SkipSigCheck bool
+
+	// Tracer - if non-nil - receives the state of the vm before every
+	// instruction executes, including the instructions of the child vms.
+	Tracer func(step *TraceStep)
 }
@@ -68,7 +68,7 @@ func opCheckSig(vm *virtualMachine) error {
 	if len(pubkeyBytes) != ed25519.PublicKeySize {
 		return vm.pushBool(false, true)
 	}
-	return vm.pushBool(ed25519.Verify(ed25519.PublicKey(pubkeyBytes), msg, sig), true)
+	return vm.pushBool(vm.verifySig(ed25519.PublicKey(pubkeyBytes), msg, sig), true)
 }
 
 func opCheckMultiSig(vm *virtualMachine) error {
@@ -141,7 +141,7 @@ func opCheckMultiSig(vm *virtualMachine) error {
 	}
 
 	for len(sigs) > 0 && len(pubkeys) > 0 {
-		if ed25519.Verify(pubkeys[0], msg, sigs[0]) {
+		if vm.verifySig(pubkeys[0], msg, sigs[0]) {
 			sigs = sigs[1:]
 		}
 		pubkeys = pubkeys[1:]
@@ -150,6 +150,60 @@ func opCheckMultiSig(vm *virtualMachine) error {
 	return vm.pushBool(len(sigs) == 0, true)
 }
 
+// sigFromStackDomain separates the messages checked by CHECKSIGFROMSTACK from the
+// signature hashes of the transactions
+var sigFromStackDomain = []byte("bytom/checksigfromstack:")
+
+// SigFromStackHash return the hash of the message signed for CHECKSIGFROMSTACK
+func SigFromStackHash(msg []byte) []byte {
+	h := sha3.New256()
+	h.Write(sigFromStackDomain)
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+func opCheckSigFromStack(vm *virtualMachine) error {
+	if err := vm.applyCost(1024); err != nil {
+		return err
+	}
+
+	pubkeyBytes, err := vm.pop(true)
+	if err != nil {
+		return err
+	}
+
+	msg, err := vm.pop(true)
+	if err != nil {
+		return err
+	}
+
+	sig, err := vm.pop(true)
+	if err != nil {
+		return err
+	}
+
+	hashCost := int64(len(msg))
+	if hashCost < 64 {
+		hashCost = 64
+	}
+
+	if err := vm.applyCost(hashCost); err != nil {
+		return err
+	}
+
+	if len(pubkeyBytes) != ed25519.PublicKeySize {
+		return vm.pushBool(false, true)
+	}
+	return vm.pushBool(vm.verifySig(ed25519.PublicKey(pubkeyBytes), SigFromStackHash(msg), sig), true)
+}
+
+func (vm *virtualMachine) verifySig(pubkey ed25519.PublicKey, msg, sig []byte) bool {
+	if vm.context != nil && vm.context.SkipSigCheck {
+		return true
+	}
+	return ed25519.Verify(pubkey, msg, sig)
+}
+
 func opTxSigHash(vm *virtualMachine) error {
 	if err := vm.applyCost(256); err != nil {
 		return err
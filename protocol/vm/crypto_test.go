@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"crypto/ed25519"
 	"testing"
 
 	"github.com/bytom/bytom/testutil"
@@ -84,6 +85,95 @@ func TestCheckSig(t *testing.T) {
 	}
 }
 
+func TestCheckSigFromStack(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("BTC/USD 60000 at 1700000000")
+	sig := ed25519.Sign(privkey, SigFromStackHash(msg))
+	activatedOps := map[Op]bool{OP_CHECKSIGFROMSTACK: true}
+
+	cases := []struct {
+		desc         string
+		sig          []byte
+		msg          []byte
+		pubkey       []byte
+		activatedOps map[Op]bool
+		skipSigCheck bool
+		ok           bool
+		err          error
+	}{
+		{
+			desc:         "valid signature",
+			sig:          sig,
+			msg:          msg,
+			pubkey:       pubkey,
+			activatedOps: activatedOps,
+			ok:           true,
+		},
+		{
+			desc:         "signature over the message without domain separation",
+			sig:          ed25519.Sign(privkey, msg),
+			msg:          msg,
+			pubkey:       pubkey,
+			activatedOps: activatedOps,
+		},
+		{
+			desc:         "wrong message",
+			sig:          sig,
+			msg:          []byte("BTC/USD 1 at 1700000000"),
+			pubkey:       pubkey,
+			activatedOps: activatedOps,
+		},
+		{
+			desc:         "wrong-length pubkey",
+			sig:          sig,
+			msg:          msg,
+			pubkey:       pubkey[1:],
+			activatedOps: activatedOps,
+		},
+		{
+			desc:         "signature check skipped",
+			sig:          make([]byte, 64),
+			msg:          msg,
+			pubkey:       pubkey,
+			activatedOps: activatedOps,
+			skipSigCheck: true,
+			ok:           true,
+		},
+		{
+			desc:   "the opcode is not activated",
+			sig:    sig,
+			msg:    msg,
+			pubkey: pubkey,
+			err:    ErrDisallowedOpcode,
+		},
+	}
+
+	for _, c := range cases {
+		prog := append(PushDataBytes(c.sig), PushDataBytes(c.msg)...)
+		prog = append(prog, PushDataBytes(c.pubkey)...)
+		prog = append(prog, byte(OP_CHECKSIGFROMSTACK))
+		vm := &virtualMachine{
+			program:           prog,
+			runLimit:          50000,
+			expansionReserved: true,
+			context:           &Context{ActivatedOps: c.activatedOps, SkipSigCheck: c.skipSigCheck},
+		}
+		err := vm.run()
+		if err != c.err {
+			t.Errorf("case %s: got error %v, want %v", c.desc, err, c.err)
+			continue
+		}
+
+		if err == nil && vm.falseResult() == c.ok {
+			t.Errorf("case %s: got result %v, want %v", c.desc, !vm.falseResult(), c.ok)
+		}
+	}
+}
+
 func TestCryptoOps(t *testing.T) {
 	type testStruct struct {
 		op      Op
@@ -1,6 +1,6 @@
 package vm
 
-import "errors"
+import "github.com/bytom/bytom/errors"
 
 var (
 	ErrAltStackUnderflow  = errors.New("alt stack underflow")
@@ -20,3 +20,27 @@ var (
 	ErrUnsupportedVM      = errors.New("unsupported VM")
 	ErrVerifyFailed       = errors.New("VERIFY failed")
 )
+
+var vmErrors = map[error]bool{
+	ErrAltStackUnderflow:  true,
+	ErrBadValue:           true,
+	ErrContext:            true,
+	ErrDataStackUnderflow: true,
+	ErrDisallowedOpcode:   true,
+	ErrDivZero:            true,
+	ErrFalseVMResult:      true,
+	ErrLongProgram:        true,
+	ErrRange:              true,
+	ErrReturn:             true,
+	ErrRunLimitExceeded:   true,
+	ErrShortProgram:       true,
+	ErrToken:              true,
+	ErrUnexpected:         true,
+	ErrUnsupportedVM:      true,
+	ErrVerifyFailed:       true,
+}
+
+// IsVMError check whether the root of the error is raised by running the program
+func IsVMError(err error) bool {
+	return vmErrors[errors.Root(err)]
+}
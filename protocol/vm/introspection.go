@@ -122,3 +122,90 @@ func opBlockHeight(vm *virtualMachine) error {
 
 	return vm.pushBigInt(uint256.NewInt(*vm.context.BlockHeight), true)
 }
+
+func opInputHeight(vm *virtualMachine) error {
+	if err := vm.applyCost(1); err != nil {
+		return err
+	}
+
+	if vm.context.SpentOutputHeight == nil {
+		return ErrContext
+	}
+
+	return vm.pushBigInt(uint256.NewInt(*vm.context.SpentOutputHeight), true)
+}
+
+func opOutputCount(vm *virtualMachine) error {
+	if err := vm.applyCost(1); err != nil {
+		return err
+	}
+
+	if vm.context.NumOutputs == nil {
+		return ErrContext
+	}
+
+	n, err := vm.context.NumOutputs()
+	if err != nil {
+		return err
+	}
+
+	return vm.pushBigInt(uint256.NewInt(n), true)
+}
+
+func opOutputAsset(vm *virtualMachine) error {
+	assetID, _, _, err := vm.popOutput()
+	if err != nil {
+		return err
+	}
+
+	return vm.pushDataStack(assetID, true)
+}
+
+func opOutputAmount(vm *virtualMachine) error {
+	_, amount, _, err := vm.popOutput()
+	if err != nil {
+		return err
+	}
+
+	return vm.pushBigInt(uint256.NewInt(amount), true)
+}
+
+func opOutputProgram(vm *virtualMachine) error {
+	_, _, code, err := vm.popOutput()
+	if err != nil {
+		return err
+	}
+
+	return vm.pushDataStack(code, true)
+}
+
+// popOutput pop the index of the output from the data stack and read the output
+func (vm *virtualMachine) popOutput() ([]byte, uint64, []byte, error) {
+	if err := vm.applyCost(8); err != nil {
+		return nil, 0, nil, err
+	}
+
+	indexInt, err := vm.popBigInt(true)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	index, overflow := indexInt.Uint64WithOverflow()
+	if overflow {
+		return nil, 0, nil, ErrBadValue
+	}
+
+	if vm.context.GetOutput == nil {
+		return nil, 0, nil, ErrContext
+	}
+
+	return vm.context.GetOutput(index)
+}
+
+func opStateDataCount(vm *virtualMachine) error {
+	if err := vm.applyCost(1); err != nil {
+		return err
+	}
+
+	return vm.pushBigInt(uint256.NewInt(uint64(len(vm.context.StateData))), true)
+}
@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -85,6 +86,133 @@ func TestBlockHeight(t *testing.T) {
 	}
 }
 
+func TestInputHeight(t *testing.T) {
+	var blockHeight, spentOutputHeight uint64 = 6666, 6566
+	activatedOps := map[Op]bool{OP_INPUTHEIGHT: true}
+
+	cases := []struct {
+		desc              string
+		prog              string
+		spentOutputHeight *uint64
+		activatedOps      map[Op]bool
+		expansionReserved bool
+		wantErr           error
+	}{
+		{
+			desc:              "the spent output is 100 blocks old",
+			prog:              "BLOCKHEIGHT INPUTHEIGHT SUB 100 GREATERTHANOREQUAL",
+			spentOutputHeight: &spentOutputHeight,
+			activatedOps:      activatedOps,
+		},
+		{
+			desc:              "the spent output is not 101 blocks old",
+			prog:              "BLOCKHEIGHT INPUTHEIGHT SUB 101 GREATERTHANOREQUAL",
+			spentOutputHeight: &spentOutputHeight,
+			activatedOps:      activatedOps,
+			wantErr:           ErrFalseVMResult,
+		},
+		{
+			desc:         "the height of the spent output is absent",
+			prog:         "INPUTHEIGHT",
+			activatedOps: activatedOps,
+			wantErr:      ErrContext,
+		},
+		{
+			desc:              "the opcode is not activated",
+			prog:              "INPUTHEIGHT 1",
+			spentOutputHeight: &spentOutputHeight,
+		},
+		{
+			desc:              "the opcode is not activated and the expansion is reserved",
+			prog:              "INPUTHEIGHT 1",
+			spentOutputHeight: &spentOutputHeight,
+			expansionReserved: true,
+			wantErr:           ErrDisallowedOpcode,
+		},
+	}
+
+	for _, c := range cases {
+		prog, err := Assemble(c.prog)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm := &virtualMachine{
+			runLimit:          50000,
+			program:           prog,
+			expansionReserved: c.expansionReserved,
+			context:           &Context{BlockHeight: &blockHeight, SpentOutputHeight: c.spentOutputHeight, ActivatedOps: c.activatedOps},
+		}
+		err = vm.run()
+		if err == nil && vm.falseResult() {
+			err = ErrFalseVMResult
+		}
+		if err != c.wantErr {
+			t.Errorf("case %s: got error %v, want %v", c.desc, err, c.wantErr)
+		}
+	}
+}
+
+func TestOutputOps(t *testing.T) {
+	assetID := mustDecodeHex("0100000000000000000000000000000000000000000000000000000000000000")
+	activatedOps := map[Op]bool{OP_OUTPUTCOUNT: true, OP_OUTPUTASSET: true, OP_OUTPUTAMOUNT: true, OP_OUTPUTPROGRAM: true, OP_STATEDATACOUNT: true}
+	outputs := []struct {
+		amount uint64
+		code   []byte
+	}{{amount: 100, code: []byte("vaultprog")}, {amount: 5, code: []byte("controlprog")}}
+
+	context := &Context{
+		StateData:    [][]byte{[]byte("limit"), []byte("spent")},
+		ActivatedOps: activatedOps,
+		NumOutputs: func() (uint64, error) {
+			return uint64(len(outputs)), nil
+		},
+		GetOutput: func(index uint64) ([]byte, uint64, []byte, error) {
+			if index >= uint64(len(outputs)) {
+				return nil, 0, nil, ErrBadValue
+			}
+			return assetID, outputs[index].amount, outputs[index].code, nil
+		},
+	}
+
+	cases := []struct {
+		prog    string
+		context *Context
+		wantErr error
+	}{
+		{prog: "OUTPUTCOUNT 2 NUMEQUAL", context: context},
+		{prog: "0 OUTPUTASSET 0x" + hex.EncodeToString(assetID) + " EQUAL", context: context},
+		{prog: "0 OUTPUTAMOUNT 100 NUMEQUAL", context: context},
+		{prog: "1 OUTPUTPROGRAM 0x" + hex.EncodeToString([]byte("controlprog")) + " EQUAL", context: context},
+		{prog: "STATEDATACOUNT 2 NUMEQUAL", context: context},
+		{prog: "2 OUTPUTAMOUNT", context: context, wantErr: ErrBadValue},
+		{prog: "OUTPUTCOUNT", context: &Context{ActivatedOps: activatedOps}, wantErr: ErrContext},
+		{prog: "0 OUTPUTAMOUNT", context: &Context{ActivatedOps: activatedOps}, wantErr: ErrContext},
+		{prog: "OUTPUTCOUNT", context: &Context{NumOutputs: context.NumOutputs}, wantErr: ErrDisallowedOpcode},
+	}
+
+	for i, c := range cases {
+		prog, err := Assemble(c.prog)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm := &virtualMachine{
+			runLimit:          50000,
+			program:           prog,
+			expansionReserved: true,
+			context:           c.context,
+		}
+		err = vm.run()
+		if err == nil && vm.falseResult() {
+			err = ErrFalseVMResult
+		}
+		if err != c.wantErr {
+			t.Errorf("case %d(%s): got error %v, want %v", i, c.prog, err, c.wantErr)
+		}
+	}
+}
+
 func TestIntrospectionOps(t *testing.T) {
 	// arbitrary
 	entryID := mustDecodeHex("2e68d78cdeaa98944c12512cf9c719eb4881e9afb61e4b766df5f369aee6392c")
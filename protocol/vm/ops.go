@@ -207,6 +207,16 @@ const (
 	OP_ENTRYID     Op = 0xca
 	OP_OUTPUTID    Op = 0xcb
 	OP_BLOCKHEIGHT Op = 0xcd
+
+	// the opcodes below are taken from the expansion space, they keep the
+	// expansion behavior until they are activated by the context
+	OP_CHECKSIGFROMSTACK Op = 0xaf
+	OP_OUTPUTCOUNT       Op = 0xc5
+	OP_OUTPUTASSET       Op = 0xc6
+	OP_OUTPUTAMOUNT      Op = 0xc7
+	OP_OUTPUTPROGRAM     Op = 0xc8
+	OP_STATEDATACOUNT    Op = 0xcc
+	OP_INPUTHEIGHT       Op = 0xce
 )
 
 type opInfo struct {
@@ -301,6 +311,8 @@ var (
 		OP_CHECKMULTISIG: {OP_CHECKMULTISIG, "CHECKMULTISIG", opCheckMultiSig},
 		OP_TXSIGHASH:     {OP_TXSIGHASH, "TXSIGHASH", opTxSigHash},
 
+		OP_CHECKSIGFROMSTACK: {OP_CHECKSIGFROMSTACK, "CHECKSIGFROMSTACK", opCheckSigFromStack},
+
 		OP_CHECKOUTPUT: {OP_CHECKOUTPUT, "CHECKOUTPUT", opCheckOutput},
 		OP_ASSET:       {OP_ASSET, "ASSET", opAsset},
 		OP_AMOUNT:      {OP_AMOUNT, "AMOUNT", opAmount},
@@ -309,6 +321,13 @@ var (
 		OP_ENTRYID:     {OP_ENTRYID, "ENTRYID", opEntryID},
 		OP_OUTPUTID:    {OP_OUTPUTID, "OUTPUTID", opOutputID},
 		OP_BLOCKHEIGHT: {OP_BLOCKHEIGHT, "BLOCKHEIGHT", opBlockHeight},
+		OP_INPUTHEIGHT: {OP_INPUTHEIGHT, "INPUTHEIGHT", opInputHeight},
+
+		OP_OUTPUTCOUNT:    {OP_OUTPUTCOUNT, "OUTPUTCOUNT", opOutputCount},
+		OP_OUTPUTASSET:    {OP_OUTPUTASSET, "OUTPUTASSET", opOutputAsset},
+		OP_OUTPUTAMOUNT:   {OP_OUTPUTAMOUNT, "OUTPUTAMOUNT", opOutputAmount},
+		OP_OUTPUTPROGRAM:  {OP_OUTPUTPROGRAM, "OUTPUTPROGRAM", opOutputProgram},
+		OP_STATEDATACOUNT: {OP_STATEDATACOUNT, "STATEDATACOUNT", opStateDataCount},
 	}
 
 	opsByName map[string]opInfo
@@ -450,7 +469,15 @@ func ParseProgram(prog []byte) ([]Instruction, error) {
 	return result, nil
 }
 
-var isExpansion [256]bool
+var (
+	isExpansion [256]bool
+
+	// expansionOps are the opcodes defined in the expansion space
+	expansionOps = []Op{
+		OP_CHECKSIGFROMSTACK, OP_INPUTHEIGHT,
+		OP_OUTPUTCOUNT, OP_OUTPUTASSET, OP_OUTPUTAMOUNT, OP_OUTPUTPROGRAM, OP_STATEDATACOUNT,
+	}
+)
 
 func init() {
 	for i := 1; i <= 75; i++ {
@@ -477,6 +504,10 @@ func init() {
 			isExpansion[i] = true
 		}
 	}
+
+	for _, op := range expansionOps {
+		isExpansion[op] = true
+	}
 }
 
 // IsPushdata judge instruction whether is a pushdata operation(include opFalse operation)
@@ -37,6 +37,17 @@ type virtualMachine struct {
 // execution.
 var TraceOut io.Writer
 
+// TraceStep is the state of the vm before the instruction at the pc executes
+type TraceStep struct {
+	Depth     int
+	PC        uint32
+	Op        Op
+	Data      []byte
+	RunLimit  int64
+	DataStack [][]byte
+	AltStack  [][]byte
+}
+
 // Verify program by running VM
 func Verify(context *Context, gasLimit int64) (gasLeft int64, err error) {
 	defer func() {
@@ -102,6 +113,10 @@ func (vm *virtualMachine) step() error {
 
 	vm.nextPC = vm.pc + inst.Len
 
+	if vm.context != nil && vm.context.Tracer != nil {
+		vm.context.Tracer(vm.traceStep(inst))
+	}
+
 	if TraceOut != nil {
 		opname := inst.Op.String()
 		fmt.Fprintf(TraceOut, "vm %d pc %d limit %d %s", vm.depth, vm.pc, vm.runLimit, opname)
@@ -111,7 +126,7 @@ func (vm *virtualMachine) step() error {
 		fmt.Fprint(TraceOut, "\n")
 	}
 
-	if isExpansion[inst.Op] {
+	if isExpansion[inst.Op] && !vm.isActivated(inst.Op) {
 		if vm.expansionReserved {
 			return ErrDisallowedOpcode
 		}
@@ -140,6 +155,24 @@ func (vm *virtualMachine) step() error {
 	return nil
 }
 
+// isActivated return whether the opcode of the expansion space is activated by the context
+func (vm *virtualMachine) isActivated(op Op) bool {
+	return vm.context != nil && vm.context.ActivatedOps[op]
+}
+
+// traceStep copy the state of the vm, the items of the stacks are never modified in place
+func (vm *virtualMachine) traceStep(inst Instruction) *TraceStep {
+	return &TraceStep{
+		Depth:     vm.depth,
+		PC:        vm.pc,
+		Op:        inst.Op,
+		Data:      inst.Data,
+		RunLimit:  vm.runLimit,
+		DataStack: append([][]byte{}, vm.dataStack...),
+		AltStack:  append([][]byte{}, vm.altStack...),
+	}
+}
+
 func (vm *virtualMachine) pushDataStack(data []byte, deferred bool) error {
 	cost := 8 + int64(len(data))
 	if deferred {
@@ -198,6 +198,43 @@ func TestVerifyTxInput(t *testing.T) {
 	}
 }
 
+func TestTracer(t *testing.T) {
+	steps := []*TraceStep{}
+	vctx := &Context{
+		VMVersion: 1,
+		Code:      []byte{byte(OP_ADD), byte(OP_5), byte(OP_NUMEQUAL), byte(OP_VERIFY), byte(OP_1SUB)},
+		Arguments: [][]byte{{2}, {3}},
+		Tracer:    func(step *TraceStep) { steps = append(steps, step) },
+	}
+
+	if _, err := Verify(vctx, 10000); errors.Root(err) != ErrDataStackUnderflow {
+		t.Fatalf("got error %v, want %v", err, ErrDataStackUnderflow)
+	}
+
+	wantOps := []Op{OP_ADD, OP_5, OP_NUMEQUAL, OP_VERIFY, OP_1SUB}
+	if len(steps) != len(wantOps) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(wantOps))
+	}
+
+	for i, step := range steps {
+		if step.Op != wantOps[i] {
+			t.Errorf("step #%d got op %s, want %s", i, step.Op, wantOps[i])
+		}
+	}
+
+	if !testutil.DeepEqual(steps[0].DataStack, [][]byte{{2}, {3}}) {
+		t.Errorf("got data stack %x before the first step, want [02 03]", steps[0].DataStack)
+	}
+
+	if len(steps[3].DataStack) != 1 || !AsBool(steps[3].DataStack[0]) {
+		t.Errorf("got data stack %x before the verify step, want [01]", steps[3].DataStack)
+	}
+
+	if len(steps[4].DataStack) != 0 {
+		t.Errorf("got data stack %x before the failing step, want empty", steps[4].DataStack)
+	}
+}
+
 func TestRun(t *testing.T) {
 	cases := []struct {
 		vm      *virtualMachine
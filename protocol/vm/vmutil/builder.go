@@ -1,6 +1,7 @@
 package vmutil
 
 import (
+	"crypto/ed25519"
 	"encoding/binary"
 
 	"github.com/bytom/bytom/errors"
@@ -51,6 +52,19 @@ func (b *Builder) AddOp(op vm.Op) *Builder {
 	return b
 }
 
+// AddCheckSigFromStack adds the instructions checking the signature of the
+// pubkey over the message, the signature and the message are expected on the
+// stack in that order.
+func (b *Builder) AddCheckSigFromStack(pubkey ed25519.PublicKey) *Builder {
+	return b.AddData(pubkey).AddOp(vm.OP_CHECKSIGFROMSTACK)
+}
+
+// AddCheckSigFromStackVerify is like AddCheckSigFromStack, except the
+// program fails if the signature is invalid.
+func (b *Builder) AddCheckSigFromStackVerify(pubkey ed25519.PublicKey) *Builder {
+	return b.AddCheckSigFromStack(pubkey).AddOp(vm.OP_VERIFY)
+}
+
 // NewJumpTarget allocates a number that can be used as a jump target
 // in AddJump and AddJumpIf. Call SetJumpTarget to associate the
 // number with a program location.
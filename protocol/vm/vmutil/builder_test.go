@@ -2,6 +2,7 @@ package vmutil
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"encoding/hex"
 	"testing"
 
@@ -120,3 +121,41 @@ func TestAddJump(t *testing.T) {
 		})
 	}
 }
+
+func TestAddCheckSigFromStack(t *testing.T) {
+	pubkey := bytes.Repeat([]byte{0x01}, ed25519.PublicKeySize)
+	cases := []struct {
+		name string
+		want string
+		fn   func(b *Builder)
+	}{
+		{
+			"check signature from stack",
+			"0x" + hex.EncodeToString(pubkey) + " CHECKSIGFROMSTACK",
+			func(b *Builder) { b.AddCheckSigFromStack(pubkey) },
+		},
+		{
+			"check signature from stack and verify",
+			"0x" + hex.EncodeToString(pubkey) + " CHECKSIGFROMSTACK VERIFY",
+			func(b *Builder) { b.AddCheckSigFromStackVerify(pubkey) },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := NewBuilder()
+			c.fn(b)
+			prog, err := b.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := vm.Disassemble(prog)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+}
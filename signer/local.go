@@ -0,0 +1,60 @@
+package signer
+
+import (
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// LocalSigner sign by the private key held in the process
+type LocalSigner struct {
+	xPrv  chainkd.XPrv
+	guard *guard
+}
+
+// NewLocalSigner create the signer of the private key, the signed state is kept in the state
+// file, it's only kept in memory if the state file is empty
+func NewLocalSigner(xPrv chainkd.XPrv, stateFile string) (*LocalSigner, error) {
+	g, err := newGuard(stateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalSigner{xPrv: xPrv, guard: g}, nil
+}
+
+// XPub return the pub key of the signer
+func (s *LocalSigner) XPub() chainkd.XPub {
+	return s.xPrv.XPub()
+}
+
+// SignBlock sign the block header
+func (s *LocalSigner) SignBlock(blockHeader *types.BlockHeader) ([]byte, error) {
+	hash := blockHeader.Hash()
+	return s.guard.signBlock(blockHeader.Height, hash, func() []byte {
+		return s.xPrv.Sign(hash.Bytes())
+	})
+}
+
+// SignVerification sign the verification from the source checkpoint to the target checkpoint
+func (s *LocalSigner) SignVerification(sourceHeight uint64, sourceHash bc.Hash, targetHeight uint64, targetHash bc.Hash) ([]byte, error) {
+	message, err := VerificationMessage(sourceHash, targetHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.guard.signVerification(sourceHeight, targetHeight, targetHash, func() []byte {
+		return s.xPrv.Sign(message)
+	})
+}
+
+// SignParamProposal sign the change of the consensus parameter, the proposals are not
+// checked by the signed state since signing different proposals is not slashable
+func (s *LocalSigner) SignParamProposal(key string, value, activateHeight uint64) ([]byte, error) {
+	message, err := ParamProposalMessage(key, value, activateHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.xPrv.Sign(message), nil
+}
@@ -0,0 +1,316 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	chainjson "github.com/bytom/bytom/encoding/json"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+const (
+	logModule      = "signer"
+	requestTimeout = 10 * time.Second
+	// the host of the request url, the connection is dialed by the signer address
+	remoteHost = "http://signer"
+	// the header carries the hmac of the request by the shared secret
+	authHeader = "X-Signer-Auth"
+)
+
+var (
+	errBadAddress   = errors.New("signer address must be tcp://host:port or unix:///path")
+	errNoSecret     = errors.New("signer secret is required for the tcp signer address")
+	errUnauthorized = errors.New("signing request is unauthorized")
+)
+
+type xPubResp struct {
+	XPub chainkd.XPub `json:"xpub"`
+}
+
+type signBlockReq struct {
+	BlockHeader *types.BlockHeader `json:"block_header"`
+}
+
+type signVerificationReq struct {
+	SourceHeight uint64  `json:"source_height"`
+	SourceHash   bc.Hash `json:"source_hash"`
+	TargetHeight uint64  `json:"target_height"`
+	TargetHash   bc.Hash `json:"target_hash"`
+}
+
+type signParamProposalReq struct {
+	Key            string `json:"key"`
+	Value          uint64 `json:"value"`
+	ActivateHeight uint64 `json:"activate_height"`
+}
+
+type signResp struct {
+	Signature  chainjson.HexBytes `json:"signature,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	DoubleSign bool               `json:"double_sign,omitempty"`
+}
+
+// splitAddress split the signer address to the network and the address of the network
+func splitAddress(address string) (string, string, error) {
+	parts := strings.SplitN(address, "://", 2)
+	if len(parts) != 2 || parts[1] == "" || (parts[0] != "tcp" && parts[0] != "unix") {
+		return "", "", errBadAddress
+	}
+	return parts[0], parts[1], nil
+}
+
+// checkSecret require the shared secret on the tcp network, the unix socket is protected
+// by the permissions of the socket file
+func checkSecret(network, secret string) error {
+	if network == "tcp" && secret == "" {
+		return errNoSecret
+	}
+	return nil
+}
+
+// requestMAC return the hmac of the request path and body by the shared secret
+func requestMAC(secret, path string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(path))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// RemoteSigner sign by the signer process serving at the address
+type RemoteSigner struct {
+	client *http.Client
+	secret string
+	xPub   chainkd.XPub
+}
+
+// NewRemoteSigner connect to the signer at the address, the requests are authenticated by
+// the shared secret, the pub key of the signer is fetched once to check the signer is available
+func NewRemoteSigner(address, secret string) (*RemoteSigner, error) {
+	network, addr, err := splitAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkSecret(network, secret); err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: requestTimeout}
+	s := &RemoteSigner{secret: secret, client: &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
+		},
+	}}
+
+	resp := &xPubResp{}
+	if err := s.call("/xpub", nil, resp); err != nil {
+		return nil, errors.Wrap(err, "fail on get xpub of remote signer")
+	}
+
+	s.xPub = resp.XPub
+	return s, nil
+}
+
+func (s *RemoteSigner) call(path string, req, resp interface{}) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, remoteHost+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if s.secret != "" {
+		httpReq.Header.Set(authHeader, hex.EncodeToString(requestMAC(s.secret, path, data)))
+	}
+
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode == http.StatusUnauthorized {
+		return errUnauthorized
+	}
+	return json.NewDecoder(httpResp.Body).Decode(resp)
+}
+
+func (s *RemoteSigner) sign(path string, req interface{}) ([]byte, error) {
+	resp := &signResp{}
+	if err := s.call(path, req, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.DoubleSign {
+		return nil, errors.WithDetail(ErrDoubleSign, resp.Error)
+	} else if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp.Signature, nil
+}
+
+// XPub return the pub key of the signer
+func (s *RemoteSigner) XPub() chainkd.XPub {
+	return s.xPub
+}
+
+// SignBlock sign the block header by the remote signer
+func (s *RemoteSigner) SignBlock(blockHeader *types.BlockHeader) ([]byte, error) {
+	return s.sign("/sign-block", &signBlockReq{BlockHeader: blockHeader})
+}
+
+// SignVerification sign the verification by the remote signer
+func (s *RemoteSigner) SignVerification(sourceHeight uint64, sourceHash bc.Hash, targetHeight uint64, targetHash bc.Hash) ([]byte, error) {
+	return s.sign("/sign-verification", &signVerificationReq{
+		SourceHeight: sourceHeight,
+		SourceHash:   sourceHash,
+		TargetHeight: targetHeight,
+		TargetHash:   targetHash,
+	})
+}
+
+// SignParamProposal sign the param proposal by the remote signer
+func (s *RemoteSigner) SignParamProposal(key string, value, activateHeight uint64) ([]byte, error) {
+	return s.sign("/sign-param-proposal", &signParamProposalReq{Key: key, Value: value, ActivateHeight: activateHeight})
+}
+
+// Server serve the signing requests of the remote signer
+type Server struct {
+	signer Signer
+	secret string
+	mux    *http.ServeMux
+}
+
+// NewServer create the server of the signer, only the requests authenticated by the shared
+// secret are served if the secret is not empty
+func NewServer(signer Signer, secret string) *Server {
+	s := &Server{signer: signer, secret: secret, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/xpub", s.handleXPub)
+	s.mux.HandleFunc("/sign-block", s.handleSignBlock)
+	s.mux.HandleFunc("/sign-verification", s.handleSignVerification)
+	s.mux.HandleFunc("/sign-param-proposal", s.handleSignParamProposal)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if s.secret != "" && !s.authenticate(req) {
+		log.WithFields(log.Fields{"module": logModule, "remote": req.RemoteAddr, "path": req.URL.Path}).Warn("reject unauthorized request")
+		http.Error(w, errUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	s.mux.ServeHTTP(w, req)
+}
+
+// authenticate check the hmac of the request, the body is restored for the handlers
+func (s *Server) authenticate(req *http.Request) bool {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return false
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	mac, err := hex.DecodeString(req.Header.Get(authHeader))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(mac, requestMAC(s.secret, req.URL.Path, body))
+}
+
+// ListenAndServe listen on the signer address and serve the requests, it blocks until
+// the listener fails
+func (s *Server) ListenAndServe(address string) error {
+	network, addr, err := splitAddress(address)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSecret(network, s.secret); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"module": logModule, "address": address, "xpub": s.signer.XPub().String()}).Info("signer is serving")
+	return http.Serve(listener, s)
+}
+
+func writeJSON(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on write response")
+	}
+}
+
+func writeSignResp(w http.ResponseWriter, signature []byte, err error) {
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warn("reject signing request")
+		writeJSON(w, &signResp{Error: err.Error(), DoubleSign: errors.Root(err) == ErrDoubleSign})
+		return
+	}
+
+	writeJSON(w, &signResp{Signature: signature})
+}
+
+func (s *Server) handleXPub(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, &xPubResp{XPub: s.signer.XPub()})
+}
+
+func (s *Server) handleSignBlock(w http.ResponseWriter, req *http.Request) {
+	in := &signBlockReq{}
+	if err := json.NewDecoder(req.Body).Decode(in); err != nil || in.BlockHeader == nil {
+		writeSignResp(w, nil, errors.New("invalid sign block request"))
+		return
+	}
+
+	signature, err := s.signer.SignBlock(in.BlockHeader)
+	writeSignResp(w, signature, err)
+}
+
+func (s *Server) handleSignVerification(w http.ResponseWriter, req *http.Request) {
+	in := &signVerificationReq{}
+	if err := json.NewDecoder(req.Body).Decode(in); err != nil {
+		writeSignResp(w, nil, errors.New("invalid sign verification request"))
+		return
+	}
+
+	signature, err := s.signer.SignVerification(in.SourceHeight, in.SourceHash, in.TargetHeight, in.TargetHash)
+	writeSignResp(w, signature, err)
+}
+
+func (s *Server) handleSignParamProposal(w http.ResponseWriter, req *http.Request) {
+	in := &signParamProposalReq{}
+	if err := json.NewDecoder(req.Body).Decode(in); err != nil {
+		writeSignResp(w, nil, errors.New("invalid sign param proposal request"))
+		return
+	}
+
+	signature, err := s.signer.SignParamProposal(in.Key, in.Value, in.ActivateHeight)
+	writeSignResp(w, signature, err)
+}
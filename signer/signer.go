@@ -0,0 +1,179 @@
+// Package signer signs the blocks, the casper verifications and the param proposals of the
+// validator, the signer
+// keeps the last signed block and verification so that it never signs the conflict ones,
+// the signer can run in the node process or in an isolated process served by the Server
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/encoding/blockchain"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+// ErrDoubleSign is returned when the signing request conflicts with the last signed one
+var ErrDoubleSign = errors.New("signing request may cause double sign")
+
+// Signer sign the blocks and verifications of the validator
+type Signer interface {
+	XPub() chainkd.XPub
+	SignBlock(blockHeader *types.BlockHeader) ([]byte, error)
+	SignVerification(sourceHeight uint64, sourceHash bc.Hash, targetHeight uint64, targetHash bc.Hash) ([]byte, error)
+	SignParamProposal(key string, value, activateHeight uint64) ([]byte, error)
+}
+
+// VerificationMessage encode the verification from the source checkpoint to the target
+// checkpoint for the validators to sign or verify
+func VerificationMessage(sourceHash, targetHash bc.Hash) ([]byte, error) {
+	buff := new(bytes.Buffer)
+	if _, err := sourceHash.WriteTo(buff); err != nil {
+		return nil, err
+	}
+
+	if _, err := targetHash.WriteTo(buff); err != nil {
+		return nil, err
+	}
+
+	msg := sha3.Sum256(buff.Bytes())
+	return msg[:], nil
+}
+
+// ParamProposalMessage encode the param proposal for the validators to sign or verify
+func ParamProposalMessage(key string, value, activateHeight uint64) ([]byte, error) {
+	buff := new(bytes.Buffer)
+	if _, err := blockchain.WriteVarstr31(buff, []byte(key)); err != nil {
+		return nil, err
+	}
+
+	if _, err := blockchain.WriteVarint63(buff, value); err != nil {
+		return nil, err
+	}
+
+	if _, err := blockchain.WriteVarint63(buff, activateHeight); err != nil {
+		return nil, err
+	}
+
+	msg := sha3.Sum256(buff.Bytes())
+	return msg[:], nil
+}
+
+// State is the last signed block and verification of the signer
+type State struct {
+	BlockHeight  uint64  `json:"block_height"`
+	BlockHash    bc.Hash `json:"block_hash"`
+	SourceHeight uint64  `json:"source_height"`
+	TargetHeight uint64  `json:"target_height"`
+	TargetHash   bc.Hash `json:"target_hash"`
+}
+
+// guard reject the signing requests conflict with the state, the state is saved to the
+// file before the signature is released if the file path is not empty
+type guard struct {
+	mu       sync.Mutex
+	state    State
+	filePath string
+}
+
+func newGuard(filePath string) (*guard, error) {
+	g := &guard{filePath: filePath}
+	if filePath == "" {
+		return g, nil
+	}
+
+	data, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return g, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &g.state); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// checkBlock only allow the block higher than the last signed one, or the same one
+func (g *guard) checkBlock(height uint64, hash bc.Hash) error {
+	if height < g.state.BlockHeight || (height == g.state.BlockHeight && hash != g.state.BlockHash) {
+		return errors.WithDetailf(ErrDoubleSign, "last signed block is %d %s", g.state.BlockHeight, g.state.BlockHash.String())
+	}
+	return nil
+}
+
+// checkVerification reject the verification with the same target height as the last signed
+// one but different, or lower target height, or surrounds the last signed one
+func (g *guard) checkVerification(sourceHeight, targetHeight uint64, targetHash bc.Hash) error {
+	last := g.state
+	switch {
+	case targetHeight < last.TargetHeight:
+		return errors.WithDetailf(ErrDoubleSign, "target height %d is lower than the last signed %d", targetHeight, last.TargetHeight)
+	case targetHeight == last.TargetHeight && (targetHash != last.TargetHash || sourceHeight != last.SourceHeight):
+		return errors.WithDetailf(ErrDoubleSign, "another verification of target height %d has been signed", targetHeight)
+	case sourceHeight < last.SourceHeight:
+		return errors.WithDetailf(ErrDoubleSign, "verification from %d to %d surrounds the last signed", sourceHeight, targetHeight)
+	}
+	return nil
+}
+
+func (g *guard) save(state State) error {
+	if g.filePath != "" {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+
+		tmpPath := g.filePath + ".tmp"
+		if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+			return err
+		}
+
+		if err := os.Rename(tmpPath, g.filePath); err != nil {
+			return err
+		}
+	}
+
+	g.state = state
+	return nil
+}
+
+func (g *guard) signBlock(height uint64, hash bc.Hash, sign func() []byte) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.checkBlock(height, hash); err != nil {
+		return nil, err
+	}
+
+	state := g.state
+	state.BlockHeight, state.BlockHash = height, hash
+	if err := g.save(state); err != nil {
+		return nil, err
+	}
+	return sign(), nil
+}
+
+func (g *guard) signVerification(sourceHeight, targetHeight uint64, targetHash bc.Hash, sign func() []byte) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.checkVerification(sourceHeight, targetHeight, targetHash); err != nil {
+		return nil, err
+	}
+
+	state := g.state
+	state.SourceHeight, state.TargetHeight, state.TargetHash = sourceHeight, targetHeight, targetHash
+	if err := g.save(state); err != nil {
+		return nil, err
+	}
+	return sign(), nil
+}
@@ -0,0 +1,168 @@
+package signer
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+)
+
+var testXPrv = chainkd.RootXPrv([]byte("signer test"))
+
+func TestSignBlock(t *testing.T) {
+	cases := []struct {
+		desc    string
+		header  *types.BlockHeader
+		wantErr error
+	}{
+		{desc: "first block", header: &types.BlockHeader{Height: 10, Timestamp: 1}},
+		{desc: "same block again", header: &types.BlockHeader{Height: 10, Timestamp: 1}},
+		{desc: "another block of same height", header: &types.BlockHeader{Height: 10, Timestamp: 2}, wantErr: ErrDoubleSign},
+		{desc: "lower block", header: &types.BlockHeader{Height: 9, Timestamp: 3}, wantErr: ErrDoubleSign},
+		{desc: "higher block", header: &types.BlockHeader{Height: 11, Timestamp: 3}},
+	}
+
+	s, err := NewLocalSigner(testXPrv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range cases {
+		signature, err := s.SignBlock(c.header)
+		if errors.Root(err) != c.wantErr {
+			t.Fatalf("case #%d(%s) got error %v, want %v", i, c.desc, err, c.wantErr)
+		}
+
+		hash := c.header.Hash()
+		if err == nil && !testXPrv.XPub().Verify(hash.Bytes(), signature) {
+			t.Errorf("case #%d(%s) signature is invalid", i, c.desc)
+		}
+	}
+}
+
+func TestSignVerification(t *testing.T) {
+	hashA, hashB := bc.NewHash([32]byte{1}), bc.NewHash([32]byte{2})
+	cases := []struct {
+		desc         string
+		sourceHeight uint64
+		targetHeight uint64
+		targetHash   bc.Hash
+		wantErr      error
+	}{
+		{desc: "first verification", sourceHeight: 100, targetHeight: 200, targetHash: hashA},
+		{desc: "same verification again", sourceHeight: 100, targetHeight: 200, targetHash: hashA},
+		{desc: "another target of same height", sourceHeight: 100, targetHeight: 200, targetHash: hashB, wantErr: ErrDoubleSign},
+		{desc: "another source of same target", sourceHeight: 0, targetHeight: 200, targetHash: hashA, wantErr: ErrDoubleSign},
+		{desc: "lower target", sourceHeight: 0, targetHeight: 100, targetHash: hashB, wantErr: ErrDoubleSign},
+		{desc: "surround the last", sourceHeight: 0, targetHeight: 300, targetHash: hashB, wantErr: ErrDoubleSign},
+		{desc: "next verification", sourceHeight: 200, targetHeight: 300, targetHash: hashB},
+	}
+
+	s, err := NewLocalSigner(testXPrv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range cases {
+		signature, err := s.SignVerification(c.sourceHeight, hashA, c.targetHeight, c.targetHash)
+		if errors.Root(err) != c.wantErr {
+			t.Fatalf("case #%d(%s) got error %v, want %v", i, c.desc, err, c.wantErr)
+		}
+
+		message, _ := VerificationMessage(hashA, c.targetHash)
+		if err == nil && !testXPrv.XPub().Verify(message, signature) {
+			t.Errorf("case #%d(%s) signature is invalid", i, c.desc)
+		}
+	}
+}
+
+func TestStatePersistence(t *testing.T) {
+	dirPath, err := ioutil.TempDir(".", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+
+	stateFile := filepath.Join(dirPath, "signer_state.json")
+	s, err := NewLocalSigner(testXPrv, stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.SignBlock(&types.BlockHeader{Height: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewLocalSigner(testXPrv, stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := restarted.SignBlock(&types.BlockHeader{Height: 10, Timestamp: 1}); errors.Root(err) != ErrDoubleSign {
+		t.Errorf("got error %v after restart, want %v", err, ErrDoubleSign)
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	s, err := NewLocalSigner(testXPrv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(NewServer(s, "secret"))
+	defer server.Close()
+
+	address := strings.Replace(server.URL, "http://", "tcp://", 1)
+	if _, err := NewRemoteSigner(address, ""); err != errNoSecret {
+		t.Errorf("got error %v, want %v", err, errNoSecret)
+	}
+
+	if _, err := NewRemoteSigner(address, "wrong secret"); errors.Root(err) != errUnauthorized {
+		t.Errorf("got error %v, want %v", err, errUnauthorized)
+	}
+
+	remote, err := NewRemoteSigner(address, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if remote.XPub() != testXPrv.XPub() {
+		t.Fatalf("got xpub %s, want %s", remote.XPub().String(), testXPrv.XPub().String())
+	}
+
+	header := &types.BlockHeader{Height: 10, SupLinks: types.SupLinks{}}
+	signature, err := remote.SignBlock(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := header.Hash()
+	if !testXPrv.XPub().Verify(hash.Bytes(), signature) {
+		t.Error("signature of remote signer is invalid")
+	}
+
+	signature, err = remote.SignParamProposal("max_block_gas", 100000, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, _ := ParamProposalMessage("max_block_gas", 100000, 1000)
+	if !testXPrv.XPub().Verify(message, signature) {
+		t.Error("param proposal signature of remote signer is invalid")
+	}
+
+	if _, err := remote.SignBlock(&types.BlockHeader{Height: 9}); errors.Root(err) != ErrDoubleSign {
+		t.Errorf("got error %v, want %v", err, ErrDoubleSign)
+	}
+
+	if _, err := NewRemoteSigner("http://127.0.0.1:9889", "secret"); err != errBadAddress {
+		t.Errorf("got error %v, want %v", err, errBadAddress)
+	}
+}
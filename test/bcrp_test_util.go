@@ -37,7 +37,7 @@ func registerContract(chain *protocol.Chain, store *database.Store, contract []b
 	}
 
 	utxoView := &state.UtxoViewpoint{}
-	return store.SaveChainStatus(&block.BlockHeader, []*types.BlockHeader{&block.BlockHeader}, utxoView, contractView, 0, &bc.Hash{})
+	return store.SaveChainStatus(&block.BlockHeader, []*types.BlockHeader{&block.BlockHeader}, utxoView, contractView, state.NewIndexViewpoint(), 0, &bc.Hash{})
 }
 
 func validateContract(chain *protocol.Chain, contract []byte, arguments [][]byte, stateData [][]byte) error {
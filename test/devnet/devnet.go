@@ -0,0 +1,320 @@
+// Package devnet runs a local network of validator nodes in one process for the integration
+// tests, the nodes use the memdb backend and connect each other by the loopback p2p, the blocks
+// are proposed on demand by the tests instead of the block proposer, so that the consensus and
+// sync paths can be tested deterministically
+package devnet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	cfg "github.com/bytom/bytom/config"
+	"github.com/bytom/bytom/consensus"
+	"github.com/bytom/bytom/crypto/ed25519/chainkd"
+	"github.com/bytom/bytom/errors"
+	"github.com/bytom/bytom/node"
+	"github.com/bytom/bytom/p2p"
+	"github.com/bytom/bytom/proposal/blockproposer"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/protocol/bc/types"
+	"github.com/bytom/bytom/protocol/vm"
+)
+
+const (
+	chainID             = "devnet"
+	bech32HRPSegwit     = "dn"
+	blockTimeInterval   = uint64(1000)
+	maxTimeOffsetMs     = uint64(24 * 3600 * 1000)
+	genesisAmount       = uint64(210000000000000000)
+	waitPollingInterval = 50 * time.Millisecond
+)
+
+var (
+	errNoBlocker = errors.New("no node is the blocker of the next block")
+	errTimeout   = errors.New("timeout on wait for the devnet")
+)
+
+// Node is one validator node of the devnet
+type Node struct {
+	*node.Node
+	Config *cfg.Config
+	XPrv   chainkd.XPrv
+
+	p2pPort uint16
+}
+
+// PeerID return the id of the node in the p2p network
+func (n *Node) PeerID() string {
+	return hex.EncodeToString(n.XPrv.XPub().PublicKey())
+}
+
+// BestHash return the hash of the best block of the node
+func (n *Node) BestHash() bc.Hash {
+	return n.Chain().BestBlockHeader().Hash()
+}
+
+// Devnet is the local network of the validator nodes, the genesis federation of the network is
+// made of the keys of the nodes, so every node is a validator
+type Devnet struct {
+	Nodes []*Node
+}
+
+// ValidatorXPrv return the deterministic key of the ith validator of the devnet
+func ValidatorXPrv(i int) chainkd.XPrv {
+	return chainkd.RootXPrv([]byte(fmt.Sprintf("devnet validator %d", i)))
+}
+
+// New create the devnet of the number of nodes under the root dir, the nodes are started
+// and connected with each other
+func New(rootDir string, numNodes int, blocksOfEpoch uint64) (*Devnet, error) {
+	spec := &cfg.GenesisSpec{
+		ChainID:             chainID,
+		Bech32HRPSegwit:     bech32HRPSegwit,
+		Timestamp:           uint64(time.Now().UnixNano() / 1e6),
+		BlockTimeInterval:   blockTimeInterval,
+		MaxTimeOffsetMs:     maxTimeOffsetMs,
+		BlocksOfEpoch:       blocksOfEpoch,
+		MinValidatorVoteNum: consensus.MinVoteOutputAmount,
+		VotePendingBlockNum: 1,
+		Allocations:         []*cfg.GenesisAllocation{{ControlProgram: []byte{byte(vm.OP_TRUE)}, Amount: genesisAmount}},
+	}
+	for i := 0; i < numNodes; i++ {
+		spec.FederationXpubs = append(spec.FederationXpubs, ValidatorXPrv(i).XPub())
+	}
+
+	if err := spec.Register(); err != nil {
+		return nil, err
+	}
+
+	d := &Devnet{}
+	for i := 0; i < numNodes; i++ {
+		n, err := newNode(filepath.Join(rootDir, fmt.Sprintf("node%d", i)), ValidatorXPrv(i))
+		if err != nil {
+			d.Stop()
+			return nil, err
+		}
+
+		d.Nodes = append(d.Nodes, n)
+	}
+
+	if err := d.Heal(); err != nil {
+		d.Stop()
+		return nil, err
+	}
+	return d, nil
+}
+
+func newNode(rootDir string, xPrv chainkd.XPrv) (*Node, error) {
+	if err := os.MkdirAll(rootDir, 0700); err != nil {
+		return nil, err
+	}
+
+	p2pPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	config := cfg.DefaultConfig()
+	config.SetRoot(rootDir)
+	config.ChainID = chainID
+	config.DBBackend = "memdb"
+	config.XPrv = &xPrv
+	config.ApiAddress = "127.0.0.1:0"
+	config.P2P.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", p2pPort)
+	config.P2P.SkipUPNP = true
+	config.P2P.LANDiscover = false
+	config.Wallet.Disable = true
+	config.Web.Closed = true
+	config.Mempool.Persist = false
+
+	n := &Node{Node: node.NewNode(config), Config: config, XPrv: xPrv, p2pPort: p2pPort}
+	if err := n.Start(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func freePort() (uint16, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+
+	defer listener.Close()
+	return uint16(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// Stop stop all the nodes of the devnet
+func (d *Devnet) Stop() {
+	for _, n := range d.Nodes {
+		n.Stop()
+	}
+}
+
+func (d *Devnet) isConnected(i, j int) bool {
+	for _, peer := range d.Nodes[i].SyncManager().GetPeerInfos() {
+		if peer.ID == d.Nodes[j].PeerID() {
+			return true
+		}
+	}
+	return false
+}
+
+// Connect dial the jth node from the ith node
+func (d *Devnet) Connect(i, j int) error {
+	if d.isConnected(i, j) {
+		return nil
+	}
+
+	addr := p2p.NewNetAddressIPPort(net.ParseIP("127.0.0.1"), d.Nodes[j].p2pPort)
+	return d.Nodes[i].SyncManager().DialPeerWithAddress(addr)
+}
+
+// Disconnect close the connection between the ith and jth node
+func (d *Devnet) Disconnect(i, j int) error {
+	if !d.isConnected(i, j) {
+		return nil
+	}
+
+	if err := d.Nodes[i].SyncManager().StopPeer(d.Nodes[j].PeerID()); err != nil {
+		return err
+	}
+
+	return d.waitFor(func() bool { return !d.isConnected(i, j) && !d.isConnected(j, i) })
+}
+
+// Partition split the devnet into the groups of nodes, the nodes of different groups
+// are disconnected, the nodes not in any group are isolated from all the others
+func (d *Devnet) Partition(groups ...[]int) error {
+	groupOf := map[int]int{}
+	for g, group := range groups {
+		for _, i := range group {
+			groupOf[i] = g + 1
+		}
+	}
+
+	for i := range d.Nodes {
+		for j := i + 1; j < len(d.Nodes); j++ {
+			if groupOf[i] != 0 && groupOf[i] == groupOf[j] {
+				continue
+			}
+
+			if err := d.Disconnect(i, j); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Heal connect every pair of the nodes of the devnet
+func (d *Devnet) Heal() error {
+	for i := range d.Nodes {
+		for j := i + 1; j < len(d.Nodes); j++ {
+			if err := d.Connect(i, j); err != nil {
+				return err
+			}
+		}
+	}
+
+	return d.waitFor(func() bool {
+		for i := range d.Nodes {
+			if d.Nodes[i].SyncManager().PeerCount() != len(d.Nodes)-1 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ProposeBlock let the ith node propose the block next to its best block at the first
+// timestamp the node is the blocker, the block is broadcast to the connected nodes
+func (d *Devnet) ProposeBlock(i int) (*types.Block, error) {
+	bestBlockHeader := d.Nodes[i].Chain().BestBlockHeader()
+	for k := 1; k <= len(d.Nodes); k++ {
+		timestamp := bestBlockHeader.Timestamp + uint64(k)*consensus.ActiveNetParams.BlockTimeInterval
+		block, err := d.Nodes[i].BlockProposer().ProposeBlock(timestamp)
+		if err == blockproposer.ErrNotBlocker {
+			continue
+		}
+
+		return block, err
+	}
+	return nil, errNoBlocker
+}
+
+// ProposeNextBlock let the blocker of the slot next to the best block of the ith node propose
+// the block, the blocker should have synced with the ith node
+func (d *Devnet) ProposeNextBlock(i int) (*types.Block, error) {
+	chain := d.Nodes[i].Chain()
+	bestBlockHeader := chain.BestBlockHeader()
+	bestBlockHash := bestBlockHeader.Hash()
+	timestamp := bestBlockHeader.Timestamp + consensus.ActiveNetParams.BlockTimeInterval
+	validator, err := chain.GetValidator(&bestBlockHash, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range d.Nodes {
+		if n.XPrv.XPub().String() == validator.PubKey {
+			return n.BlockProposer().ProposeBlock(timestamp)
+		}
+	}
+	return nil, errNoBlocker
+}
+
+// WaitForBestHash wait until the best block of the nodes is the hash
+func (d *Devnet) WaitForBestHash(hash bc.Hash, nodes ...int) error {
+	return d.waitFor(func() bool {
+		for _, i := range nodes {
+			if d.Nodes[i].BestHash() != hash {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// WaitForSync wait until all the nodes have the same best block
+func (d *Devnet) WaitForSync() error {
+	return d.waitFor(func() bool {
+		for _, n := range d.Nodes {
+			if n.BestHash() != d.Nodes[0].BestHash() {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// WaitForFinalized wait until the last finalized block of the nodes reaches the height
+func (d *Devnet) WaitForFinalized(height uint64, nodes ...int) error {
+	return d.waitFor(func() bool {
+		for _, i := range nodes {
+			header, err := d.Nodes[i].Chain().LastFinalizedHeader()
+			if err != nil || header.Height < height {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (d *Devnet) waitFor(cond func() bool) error {
+	timeout := time.After(time.Duration(len(d.Nodes)) * 5 * time.Second)
+	ticker := time.NewTicker(waitPollingInterval)
+	defer ticker.Stop()
+
+	for !cond() {
+		select {
+		case <-timeout:
+			return errTimeout
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
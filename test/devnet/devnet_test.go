@@ -0,0 +1,90 @@
+package devnet
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestDevnet(t *testing.T, numNodes int, blocksOfEpoch uint64) (*Devnet, func()) {
+	dirPath, err := ioutil.TempDir("", "devnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := New(dirPath, numNodes, blocksOfEpoch)
+	if err != nil {
+		os.RemoveAll(dirPath)
+		t.Fatal(err)
+	}
+
+	return d, func() {
+		d.Stop()
+		os.RemoveAll(dirPath)
+	}
+}
+
+func TestDevnetFinality(t *testing.T) {
+	d, cleanup := newTestDevnet(t, 4, 5)
+	defer cleanup()
+
+	for i := 0; i < 20; i++ {
+		if _, err := d.ProposeNextBlock(0); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := d.WaitForSync(); err != nil {
+			t.Fatalf("height %d: %v", i+1, err)
+		}
+	}
+
+	if err := d.WaitForFinalized(5, 0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevnetPartitionReorg(t *testing.T) {
+	d, cleanup := newTestDevnet(t, 4, 100)
+	defer cleanup()
+
+	if err := d.Partition([]int{0, 1}, []int{2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := d.ProposeBlock(0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := d.ProposeBlock(2); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := d.WaitForBestHash(d.Nodes[0].BestHash(), 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.WaitForBestHash(d.Nodes[2].BestHash(), 2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	forkHash := d.Nodes[0].BestHash()
+	if err := d.Heal(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.WaitForBestHash(d.Nodes[2].BestHash(), 0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Nodes[0].Chain().InMainChain(forkHash) {
+		t.Errorf("block %s of the minority fork is still in the main chain", forkHash.String())
+	}
+
+	if height := d.Nodes[0].Chain().BestBlockHeader().Height; height != 4 {
+		t.Errorf("got best height %d after reorg, want 4", height)
+	}
+}
@@ -188,7 +188,7 @@ func TestAttachOrDetachBlocks(t *testing.T) {
 			utxoViewpoint.Entries[k] = v
 		}
 		contractView := state.NewContractViewpoint()
-		if err := store.SaveChainStatus(mockBlockHeader, []*types.BlockHeader{mockBlockHeader}, utxoViewpoint, contractView, 0, &bc.Hash{}); err != nil {
+		if err := store.SaveChainStatus(mockBlockHeader, []*types.BlockHeader{mockBlockHeader}, utxoViewpoint, contractView, state.NewIndexViewpoint(), 0, &bc.Hash{}); err != nil {
 			t.Error(err)
 		}
 
@@ -206,11 +206,11 @@ func TestAttachOrDetachBlocks(t *testing.T) {
 			if err := store.GetTransactionsUtxo(utxoViewpoint, block.Transactions); err != nil {
 				t.Error(err)
 			}
-			if err := utxoViewpoint.ApplyBlock(block); err != nil {
+			if err := utxoViewpoint.ApplyBlock(block, nil); err != nil {
 				t.Error(err)
 			}
 		}
-		if err := store.SaveChainStatus(mockBlockHeader, []*types.BlockHeader{mockBlockHeader}, utxoViewpoint, contractView, 0, &bc.Hash{}); err != nil {
+		if err := store.SaveChainStatus(mockBlockHeader, []*types.BlockHeader{mockBlockHeader}, utxoViewpoint, contractView, state.NewIndexViewpoint(), 0, &bc.Hash{}); err != nil {
 			t.Error(err)
 		}
 
@@ -1,11 +1,15 @@
 package wallet
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -300,6 +304,66 @@ func (w *Wallet) GetTransactions(accountID string) ([]*query.AnnotatedTx, error)
 	return annotatedTxs, nil
 }
 
+// GetTransactionsAfter return at most count walletDB transactions which are stored after the cursor
+// and satisfy the match function, in the order of block height and position. The returned cursor
+// points to the last scanned transaction, so the next call resumes from there.
+func (w *Wallet) GetTransactionsAfter(after string, count int, match func(*query.AnnotatedTx) bool) ([]*query.AnnotatedTx, string, error) {
+	return w.scanTransactions(after, math.MaxUint64, count, match)
+}
+
+// QueryTransactions return at most count walletDB transactions which are stored after the cursor
+// and satisfy the filter, the block height range of the filter bounds the scan of the index.
+func (w *Wallet) QueryTransactions(filter *query.Filter, after string, count int) ([]*query.AnnotatedTx, string, error) {
+	minHeight, maxHeight := filter.HeightRange()
+	if minHeight > maxHeight {
+		return []*query.AnnotatedTx{}, after, nil
+	}
+
+	if minHeight > 0 {
+		if start := formatKey(minHeight-1, math.MaxUint32); after < start {
+			after = start
+		}
+	}
+	return w.scanTransactions(after, maxHeight, count, filter.Match)
+}
+
+func (w *Wallet) scanTransactions(after string, maxHeight uint64, count int, match func(*query.AnnotatedTx) bool) ([]*query.AnnotatedTx, string, error) {
+	annotatedTxs := []*query.AnnotatedTx{}
+	txIter := w.DB.IteratorPrefix([]byte(TxPrefix))
+	defer txIter.Release()
+
+	// Seek positions the iterator on the first key not less than the cursor, the key
+	// is handled here since Next moves beyond it
+	valid := txIter.Next()
+	if after != "" {
+		startKey := calcAnnotatedKey(after)
+		if valid = txIter.Seek(startKey); valid && bytes.Equal(txIter.Key(), startKey) {
+			valid = txIter.Next()
+		}
+	}
+
+	for ; valid && len(annotatedTxs) < count; valid = txIter.Next() {
+		key := strings.TrimPrefix(string(txIter.Key()), TxPrefix)
+		if height, err := strconv.ParseUint(key[:16], 16, 64); err != nil {
+			return nil, "", err
+		} else if height > maxHeight {
+			break
+		}
+
+		annotatedTx := &query.AnnotatedTx{}
+		if err := json.Unmarshal(txIter.Value(), annotatedTx); err != nil {
+			return nil, "", err
+		}
+
+		after = key
+		annotateTxsAsset(w, []*query.AnnotatedTx{annotatedTx})
+		if match(annotatedTx) {
+			annotatedTxs = append(annotatedTxs, annotatedTx)
+		}
+	}
+	return annotatedTxs, after, nil
+}
+
 // GetAccountBalances return all account balances
 func (w *Wallet) GetAccountBalances(accountID string, id string) ([]AccountBalance, error) {
 	return w.indexBalances(w.GetAccountUtxos(accountID, "", false, false, false))
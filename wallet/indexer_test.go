@@ -0,0 +1,178 @@
+package wallet
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/bytom/bytom/asset"
+	"github.com/bytom/bytom/blockchain/query"
+	"github.com/bytom/bytom/consensus"
+	dbm "github.com/bytom/bytom/database/leveldb"
+	"github.com/bytom/bytom/protocol/bc"
+	"github.com/bytom/bytom/testutil"
+)
+
+func TestGetTransactionsAfter(t *testing.T) {
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	defer os.RemoveAll("temp")
+
+	w := &Wallet{DB: testDB, AssetReg: asset.NewRegistry(testDB, nil)}
+	keys := []string{formatKey(1, 1), formatKey(1, 2), formatKey(3, 1), formatKey(10, 1)}
+	for i, key := range keys {
+		tx := &query.AnnotatedTx{
+			ID:      bc.Hash{V0: uint64(i)},
+			Outputs: []*query.AnnotatedOutput{{AssetID: *consensus.BTMAssetID, Amount: uint64(i * 100)}},
+		}
+		rawTx, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testDB.Set(calcAnnotatedKey(key), rawTx)
+	}
+
+	matchAll := func(*query.AnnotatedTx) bool { return true }
+	matchLarge := func(tx *query.AnnotatedTx) bool { return tx.Outputs[0].Amount >= 200 }
+	cases := []struct {
+		after     string
+		count     int
+		match     func(*query.AnnotatedTx) bool
+		wantIDs   []bc.Hash
+		wantAfter string
+	}{
+		{
+			after:     "",
+			count:     2,
+			match:     matchAll,
+			wantIDs:   []bc.Hash{{V0: 0}, {V0: 1}},
+			wantAfter: keys[1],
+		},
+		{
+			after:     keys[1],
+			count:     10,
+			match:     matchAll,
+			wantIDs:   []bc.Hash{{V0: 2}, {V0: 3}},
+			wantAfter: keys[3],
+		},
+		{
+			after:     formatKey(2, 0),
+			count:     1,
+			match:     matchAll,
+			wantIDs:   []bc.Hash{{V0: 2}},
+			wantAfter: keys[2],
+		},
+		{
+			after:     "",
+			count:     1,
+			match:     matchLarge,
+			wantIDs:   []bc.Hash{{V0: 2}},
+			wantAfter: keys[2],
+		},
+		{
+			after:     keys[3],
+			count:     10,
+			match:     matchAll,
+			wantIDs:   []bc.Hash{},
+			wantAfter: keys[3],
+		},
+	}
+
+	for i, c := range cases {
+		txs, after, err := w.GetTransactionsAfter(c.after, c.count, c.match)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gotIDs := []bc.Hash{}
+		for _, tx := range txs {
+			gotIDs = append(gotIDs, tx.ID)
+		}
+
+		if !testutil.DeepEqual(gotIDs, c.wantIDs) {
+			t.Errorf("case %d: got tx ids %v want %v", i, gotIDs, c.wantIDs)
+		}
+
+		if after != c.wantAfter {
+			t.Errorf("case %d: got after %s want %s", i, after, c.wantAfter)
+		}
+	}
+}
+
+func TestQueryTransactions(t *testing.T) {
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	defer os.RemoveAll("temp")
+
+	w := &Wallet{DB: testDB, AssetReg: asset.NewRegistry(testDB, nil)}
+	heights := []uint64{1, 5, 5, 9}
+	keys := []string{formatKey(1, 1), formatKey(5, 1), formatKey(5, 2), formatKey(9, 1)}
+	for i, key := range keys {
+		tx := &query.AnnotatedTx{
+			ID:          bc.Hash{V0: uint64(i)},
+			BlockHeight: heights[i],
+			Outputs:     []*query.AnnotatedOutput{{AssetID: *consensus.BTMAssetID, Amount: uint64(i * 100)}},
+		}
+		rawTx, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testDB.Set(calcAnnotatedKey(key), rawTx)
+	}
+
+	cases := []struct {
+		filter    string
+		after     string
+		wantIDs   []bc.Hash
+		wantAfter string
+	}{
+		{
+			filter:    "block_height>=5 AND block_height<9",
+			wantIDs:   []bc.Hash{{V0: 1}, {V0: 2}},
+			wantAfter: keys[2],
+		},
+		{
+			filter:    "block_height>=5 AND block_height<9",
+			after:     keys[1],
+			wantIDs:   []bc.Hash{{V0: 2}},
+			wantAfter: keys[2],
+		},
+		{
+			filter:    "block_height>5 AND amount>=100",
+			wantIDs:   []bc.Hash{{V0: 3}},
+			wantAfter: keys[3],
+		},
+		{
+			filter:    "block_height>9",
+			after:     keys[0],
+			wantIDs:   []bc.Hash{},
+			wantAfter: formatKey(9, math.MaxUint32),
+		},
+	}
+
+	for i, c := range cases {
+		filter, err := query.ParseFilter(c.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		txs, after, err := w.QueryTransactions(filter, c.after, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gotIDs := []bc.Hash{}
+		for _, tx := range txs {
+			gotIDs = append(gotIDs, tx.ID)
+		}
+
+		if !testutil.DeepEqual(gotIDs, c.wantIDs) {
+			t.Errorf("case %d: got tx ids %v want %v", i, gotIDs, c.wantIDs)
+		}
+
+		if after != c.wantAfter {
+			t.Errorf("case %d: got after %s want %s", i, after, c.wantAfter)
+		}
+	}
+}
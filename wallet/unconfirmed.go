@@ -10,6 +10,7 @@ import (
 
 	"github.com/bytom/bytom/account"
 	"github.com/bytom/bytom/blockchain/query"
+	"github.com/bytom/bytom/consensus"
 	"github.com/bytom/bytom/crypto/sha3pool"
 	"github.com/bytom/bytom/protocol"
 	"github.com/bytom/bytom/protocol/bc/types"
@@ -39,7 +40,7 @@ func (w *Wallet) AddUnconfirmedTx(txD *protocol.TxDesc) {
 		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("wallet fail on saveUnconfirmedTx")
 	}
 
-	utxos := txOutToUtxos(txD.Tx, 0)
+	utxos := txOutToUtxos(txD.Tx, 0, consensus.VotePendingBlockNums(0))
 	utxos = w.filterAccountUtxo(utxos)
 	w.AccountMgr.AddUnconfirmedUtxo(utxos)
 }
@@ -49,6 +49,7 @@ func (w *Wallet) GetAccountUtxos(accountID string, id string, unconfirmed, isSma
 }
 
 func (w *Wallet) attachUtxos(batch dbm.Batch, b *types.Block) {
+	votePendingNum := w.votePendingBlockNum(b)
 	for _, tx := range b.Transactions {
 		// hand update the transaction input utxos
 		inputUtxos := txInToUtxos(tx)
@@ -61,7 +62,7 @@ func (w *Wallet) attachUtxos(batch dbm.Batch, b *types.Block) {
 		}
 
 		// hand update the transaction output utxos
-		outputUtxos := txOutToUtxos(tx, b.Height)
+		outputUtxos := txOutToUtxos(tx, b.Height, votePendingNum)
 		utxos := w.filterAccountUtxo(outputUtxos)
 		if err := batchSaveUtxos(utxos, batch); err != nil {
 			log.WithFields(log.Fields{"module": logModule, "err": err}).Error("attachUtxos fail on batchSaveUtxos")
@@ -198,7 +199,18 @@ func txInToUtxos(tx *types.Tx) []*account.UTXO {
 	return utxos
 }
 
-func txOutToUtxos(tx *types.Tx, blockHeight uint64) []*account.UTXO {
+// votePendingBlockNum return the number of blocks the vote utxos of the block are locked, it's
+// read from the checkpoint previous to the block since it can be changed by the validators
+func (w *Wallet) votePendingBlockNum(b *types.Block) uint64 {
+	votePendingNum, err := w.chain.ConsensusParam(&b.PreviousBlockHash, b.Height, consensus.ParamVotePendingBlockNum)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err, "height": b.Height}).Warn("fail on get the vote pending block num of the checkpoint")
+		return consensus.VotePendingBlockNums(b.Height)
+	}
+	return votePendingNum
+}
+
+func txOutToUtxos(tx *types.Tx, blockHeight, votePendingNum uint64) []*account.UTXO {
 	utxos := []*account.UTXO{}
 	for i, out := range tx.Outputs {
 		validHeight := uint64(0)
@@ -230,7 +242,7 @@ func txOutToUtxos(tx *types.Tx, blockHeight uint64) []*account.UTXO {
 			}
 
 		case *bc.VoteOutput:
-			voteValidHeight := blockHeight + consensus.VotePendingBlockNums(blockHeight)
+			voteValidHeight := blockHeight + votePendingNum
 			if validHeight < voteValidHeight {
 				validHeight = voteValidHeight
 			}
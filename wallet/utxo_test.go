@@ -625,7 +625,7 @@ func TestTxOutToUtxos(t *testing.T) {
 	}
 
 	for i, c := range cases {
-		if gotUtxos := txOutToUtxos(c.tx, c.blockHeight); !testutil.DeepEqual(gotUtxos, c.wantUtxos) {
+		if gotUtxos := txOutToUtxos(c.tx, c.blockHeight, consensus.VotePendingBlockNums(c.blockHeight)); !testutil.DeepEqual(gotUtxos, c.wantUtxos) {
 			for k, v := range gotUtxos {
 				data, _ := json.Marshal(v)
 				fmt.Println("got:", k, string(data))